@@ -2,19 +2,23 @@ package api
 
 import (
 	"fmt"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
 	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
-	"github.com/micro/go-log"
 	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 	"net/url"
 	"strings"
+	"sync/atomic"
 )
 
 // Fixed map
 var endpoint = map[string]string{
-	"workspaces":   "workspaces.json",
-	"stories":      "stories.json",
-	"time_entries": "time_entries.json",
-	"users":        "users.json",
+	"workspaces":         "workspaces.json",
+	"stories":            "stories.json",
+	"time_entries":       "time_entries.json",
+	"users":              "users.json",
+	"story_dependencies": "story_dependencies.json",
+	"me":                 "users/me.json",
 }
 
 // MavenlinkApiInterface provides the interface definition for this service
@@ -27,27 +31,79 @@ type MavenlinkApiInterface interface {
 	GetIssueTasksFromProjectId(keyOrId string, subTask string) ([]*communicator.Task, error)
 	GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string, issueTaskKeyOrId string) ([]*communicator.Timeentry, error)
 	GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error)
-	GetUserFromProjectId(projectKeyOrId string, userId string) *communicator.User
+	GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error)
+	GetUserById(userId string) (*communicator.User, error)
+	GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error)
+	GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error)
+	GetTaskTreeFromProjectId(projectKeyOrId string, includeLoggedTime bool) ([]*communicator.TaskNode, error)
+	StreamProjects(perPage int32, emit func(*communicator.Project) error) error
+	StreamTasks(projectKeyOrId string, perPage int32, emit func(*communicator.Task) error) error
+	StreamTimeEntries(projectKeyOrId string, perPage int32, emit func(*communicator.Timeentry) error) error
+	GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error)
+	GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error)
+	GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error)
+	GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error)
 	FormatErrors(err error, message string) *communicator.Error
 }
 
 // MavenlinkApi provides a concrete instance of the interface MavenlinkApiInterface
 type MavenlinkApi struct {
-	env *communicator.EnvironmentConfiguration
+	// env holds the *communicator.EnvironmentConfiguration, swapped as a whole when
+	// the configuration is reloaded. Every call reads it once so that in-flight calls
+	// finish with the configuration they started with
+	env atomic.Value
+	ctx context.Context
+}
+
+// Contextual is implemented by the MavenlinkApiInterface implementations able to
+// make their requests on behalf of a context, e.g. to trace them as part of an RPC
+type Contextual interface {
+	WithContext(ctx context.Context) MavenlinkApiInterface
+}
+
+// WithContext returns the API(param: mavenlink) bound to the context(param: ctx)
+// when it supports it, or the API itself otherwise
+func WithContext(mavenlink MavenlinkApiInterface, ctx context.Context) MavenlinkApiInterface {
+	if contextual, ok := mavenlink.(Contextual); ok {
+		return contextual.WithContext(ctx)
+	}
+	return mavenlink
+}
+
+// WithContext returns a copy of the API sharing its configuration which makes
+// its requests on behalf of the context(param: ctx)
+func (mavenlink *MavenlinkApi) WithContext(ctx context.Context) MavenlinkApiInterface {
+	bound := &MavenlinkApi{ctx: ctx}
+	bound.env.Store(mavenlink.config())
+	return bound
+}
+
+// config returns the current configuration
+func (mavenlink *MavenlinkApi) config() *communicator.EnvironmentConfiguration {
+	configuration, _ := mavenlink.env.Load().(*communicator.EnvironmentConfiguration)
+	return configuration
+}
+
+// context returns the context the requests are made on behalf of
+func (mavenlink *MavenlinkApi) context() context.Context {
+	if mavenlink.ctx == nil {
+		return context.Background()
+	}
+	return mavenlink.ctx
 }
 
 func (mavenlink *MavenlinkApi) SetEnv(configuration *communicator.EnvironmentConfiguration) error {
 	if configuration == nil {
 		return errors.New("No configurations detected")
 	}
-	mavenlink.env = configuration
+	mavenlink.env.Store(configuration)
 	return nil
 }
 
 func (mavenlink *MavenlinkApi) FormatErrors(err error, message string) *communicator.Error {
 	errResp := new(communicator.Error)
 	errResp.Code = int32(400)
-	if mavenlink.env.Debug == true {
+	if mavenlink.config().Debug == true {
 		errResp.Description = err.Error()
 	} else {
 		errResp.Description = message
@@ -57,49 +113,34 @@ func (mavenlink *MavenlinkApi) FormatErrors(err error, message string) *communic
 
 // GetProjects is used to retrieve all the workspaces available in JIRA
 func (mavenlink *MavenlinkApi) GetProjects() ([]*communicator.Project, error) {
+	env := mavenlink.config()
 	var workspacesResponse *communicator.MavenlinkWorkspacesResponse
 	var projects []*communicator.Project
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return nil, errors.New("Failed to parse environment URL")
 	}
 	Url.Path += endpoint["workspaces"]
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &workspacesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &workspacesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return nil, apiErr
 	}
 	if workspacesResponse == nil {
 		var temp map[string]interface{}
-		someErr := InsecureRequest(Url.String(), "GET", nil, token, &temp)
+		someErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &temp)
 		if someErr != nil {
 			return nil, errors.New("Failed to retrieve response from workspaces endpoint(Level 2)")
 		}
-		log.Logf("JSON response: %v\n", temp)
+		LOG.Debug("Unexpected Mavenlink response", LOG.Fields{"response": temp})
 		return nil, errors.New("Failed to retrieve response from workspaces endpoint")
 	}
 	if workspacesResponse.Count > 0 {
 		for key, workspace := range workspacesResponse.Workspaces {
 			if fmt.Sprint(key) == workspace.Id {
-				project := new(communicator.Project)
-				project.Id = workspace.Id
-				project.Title = workspace.Title
-				project.Description = workspace.Description
-				project.AccessLevel = workspace.AccessLevel
-				project.AccountId = workspace.AccountId
-				project.Archived = workspace.Archived
-				project.Currency = workspace.Currency
-				project.CurrencySymbol = workspace.CurrencySymbol
-				project.CreatedAt = workspace.CreatedAt
-				project.DueDate = workspace.DueDate
-				project.EffectiveDueDate = workspace.EffectiveDueDate
-				project.StartDate = workspace.StartDate
-				project.UpdatedAt = workspace.UpdatedAt
-				projects = append(projects, project)
+				projects = append(projects, WorkspaceToProject(workspace))
 			}
 		}
 		if len(projects) != int(workspacesResponse.Count) {
@@ -112,10 +153,11 @@ func (mavenlink *MavenlinkApi) GetProjects() ([]*communicator.Project, error) {
 
 // GetProject is used to retrieve a single workspace from Mavenlink
 func (mavenlink *MavenlinkApi) GetProject(keyOrId string) (*communicator.Project, error) {
+	env := mavenlink.config()
 	var workspacesResponse *communicator.MavenlinkWorkspacesResponse
 	var project *communicator.Project
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return project, errors.New("Failed to parse environment URL")
 	}
@@ -123,12 +165,10 @@ func (mavenlink *MavenlinkApi) GetProject(keyOrId string) (*communicator.Project
 	parameters := url.Values{}
 	parameters.Add("only", fmt.Sprint(keyOrId))
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &workspacesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &workspacesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return project, apiErr
 	}
 	if workspacesResponse == nil || workspacesResponse.Workspaces == nil {
@@ -136,32 +176,19 @@ func (mavenlink *MavenlinkApi) GetProject(keyOrId string) (*communicator.Project
 	}
 	for key, workspace := range workspacesResponse.Workspaces {
 		if fmt.Sprint(key) == keyOrId {
-			project := new(communicator.Project)
-			project.Id = workspace.Id
-			project.Title = workspace.Title
-			project.Description = workspace.Description
-			project.AccessLevel = workspace.AccessLevel
-			project.AccountId = workspace.AccountId
-			project.Archived = workspace.Archived
-			project.Currency = workspace.Currency
-			project.CurrencySymbol = workspace.CurrencySymbol
-			project.CreatedAt = workspace.CreatedAt
-			project.DueDate = workspace.DueDate
-			project.EffectiveDueDate = workspace.EffectiveDueDate
-			project.StartDate = workspace.StartDate
-			project.UpdatedAt = workspace.UpdatedAt
-			return project, nil
+			return WorkspaceToProject(workspace), nil
 		}
 	}
-	return project, nil
+	return project, &NotFoundError{Resource: "Project", Id: keyOrId}
 }
 
 // GetTasksFromProjectId is used to retrieve all the stories from a workspace in Mavenlink
 func (mavenlink *MavenlinkApi) GetTasksFromProjectId(keyOrId string) ([]*communicator.Task, error) {
+	env := mavenlink.config()
 	var storiesResponse *communicator.MavenlinkStoriesResponse
 	var tasks []*communicator.Task
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return tasks, errors.New("Failed to parse environment URL")
 	}
@@ -170,12 +197,10 @@ func (mavenlink *MavenlinkApi) GetTasksFromProjectId(keyOrId string) ([]*communi
 	parameters.Add("workspace_id", fmt.Sprint(keyOrId))
 	parameters.Add("parents_only", "true")
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &storiesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &storiesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return tasks, apiErr
 	}
 	if storiesResponse == nil || storiesResponse.Stories == nil {
@@ -183,22 +208,7 @@ func (mavenlink *MavenlinkApi) GetTasksFromProjectId(keyOrId string) ([]*communi
 	}
 	for _, story := range storiesResponse.Stories {
 		if len(story.ParentId) < 1 {
-			task := new(communicator.Task)
-			task.Id = story.Id
-			task.Title = story.Title
-			task.Description = story.Description
-			task.StoryType = story.StoryType
-			task.Priority = story.Priority
-			task.Archived = story.Archived
-			task.WorkspaceId = story.WorkspaceId
-			task.CreatorId = story.CreatorId
-			task.ParentId = story.ParentId
-			task.CreatedAt = story.CreatedAt
-			task.DueDate = story.DueDate
-			task.State = story.State
-			task.StartDate = story.StartDate
-			task.UpdatedAt = story.UpdatedAt
-			tasks = append(tasks, task)
+			tasks = append(tasks, StoryToTask(story))
 		}
 	}
 	return tasks, nil
@@ -206,10 +216,11 @@ func (mavenlink *MavenlinkApi) GetTasksFromProjectId(keyOrId string) ([]*communi
 
 // GetSubTasksFromProjectId is used to retrieve all the stories from a workspace in Mavenlink
 func (mavenlink *MavenlinkApi) GetSubTasksFromProjectId(workspace string, task string) ([]*communicator.Task, error) {
+	env := mavenlink.config()
 	var storiesResponse *communicator.MavenlinkStoriesResponse
 	var tasks []*communicator.Task
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return tasks, errors.New("Failed to parse environment URL")
 	}
@@ -218,12 +229,10 @@ func (mavenlink *MavenlinkApi) GetSubTasksFromProjectId(workspace string, task s
 	parameters.Add("workspace_id", workspace)
 	parameters.Add("with_parent_id", task)
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &storiesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &storiesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return tasks, apiErr
 	}
 	if storiesResponse == nil || storiesResponse.Stories == nil {
@@ -231,22 +240,7 @@ func (mavenlink *MavenlinkApi) GetSubTasksFromProjectId(workspace string, task s
 	}
 	for _, story := range storiesResponse.Stories {
 		if len(story.ParentId) > 0 && story.ParentId == task {
-			task := new(communicator.Task)
-			task.Id = story.Id
-			task.Title = story.Title
-			task.Description = story.Description
-			task.StoryType = story.StoryType
-			task.Priority = story.Priority
-			task.Archived = story.Archived
-			task.WorkspaceId = story.WorkspaceId
-			task.CreatorId = story.CreatorId
-			task.ParentId = story.ParentId
-			task.CreatedAt = story.CreatedAt
-			task.DueDate = story.DueDate
-			task.State = story.State
-			task.StartDate = story.StartDate
-			task.UpdatedAt = story.UpdatedAt
-			tasks = append(tasks, task)
+			tasks = append(tasks, StoryToTask(story))
 		}
 	}
 	return tasks, nil
@@ -255,11 +249,12 @@ func (mavenlink *MavenlinkApi) GetSubTasksFromProjectId(workspace string, task s
 // GetIssueTasksFromProjectId is used to retrieve all the stories from a workspace in Mavenlink
 func (mavenlink *MavenlinkApi) GetIssueTasksFromProjectId(workspace string,
 	subTask string) ([]*communicator.Task, error) {
+	env := mavenlink.config()
 
 	var storiesResponse *communicator.MavenlinkStoriesResponse
 	var tasks []*communicator.Task
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return tasks, errors.New("Failed to parse environment URL")
 	}
@@ -269,12 +264,10 @@ func (mavenlink *MavenlinkApi) GetIssueTasksFromProjectId(workspace string,
 	parameters.Add("with_parent_id", subTask)
 	parameters.Add("include", "assignees")
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &storiesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &storiesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return tasks, apiErr
 	}
 	if storiesResponse == nil || storiesResponse.Stories == nil {
@@ -282,24 +275,14 @@ func (mavenlink *MavenlinkApi) GetIssueTasksFromProjectId(workspace string,
 	}
 	for _, story := range storiesResponse.Stories {
 		if len(story.ParentId) > 0 && story.ParentId == subTask {
-			task := new(communicator.Task)
-			task.Id = story.Id
-			task.Title = story.Title
-			task.Description = story.Description
-			task.StoryType = story.StoryType
-			task.Priority = story.Priority
-			task.Archived = story.Archived
-			task.WorkspaceId = story.WorkspaceId
-			task.CreatorId = story.CreatorId
-			task.ParentId = story.ParentId
-			task.CreatedAt = story.CreatedAt
-			task.DueDate = story.DueDate
-			task.State = story.State
-			task.StartDate = story.StartDate
-			task.UpdatedAt = story.UpdatedAt
+			task := StoryToTask(story)
 			if story.AssigneeIds != nil {
 				for _, assignee := range story.AssigneeIds {
-					task.User = mavenlink.GetUserFromProjectId(workspace, assignee)
+					user, userErr := mavenlink.GetUserFromProjectId(workspace, assignee)
+					if userErr != nil && !IsNotFound(userErr) {
+						return tasks, userErr
+					}
+					task.User = user
 				}
 			}
 
@@ -311,11 +294,12 @@ func (mavenlink *MavenlinkApi) GetIssueTasksFromProjectId(workspace string,
 
 func (mavenlink *MavenlinkApi) GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string,
 	issueTaskKeyOrId string) ([]*communicator.Timeentry, error) {
+	env := mavenlink.config()
 
 	var timeentriesResponse *communicator.MavenlinkTimeEntriesResponse
 	var timeentries []*communicator.Timeentry
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return timeentries, errors.New("Failed to parse environment URL")
 	}
@@ -323,12 +307,10 @@ func (mavenlink *MavenlinkApi) GetTimeEntriesFromProjectIdAndIssueTaskId(project
 	parameters := url.Values{}
 	parameters.Add("workspace_id", projectKeyOrId)
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &timeentriesResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &timeentriesResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return timeentries, apiErr
 	}
 	if timeentriesResponse == nil || timeentriesResponse.TimeEntries == nil {
@@ -336,16 +318,12 @@ func (mavenlink *MavenlinkApi) GetTimeEntriesFromProjectIdAndIssueTaskId(project
 	}
 	for _, timeentry := range timeentriesResponse.TimeEntries {
 		if strings.EqualFold(issueTaskKeyOrId, timeentry.StoryId) {
-			timeentryWithUser := new(communicator.Timeentry)
-			timeentryWithUser.Id = timeentry.Id
-			timeentryWithUser.DatePerformed = timeentry.DatePerformed
-			timeentryWithUser.TimeInMinutes = timeentry.TimeInMinutes
-			timeentryWithUser.Notes = timeentry.Notes
-			timeentryWithUser.WorkspaceId = timeentry.WorkspaceId
-			timeentryWithUser.StoryId = timeentry.StoryId
-			timeentryWithUser.CreatedAt = timeentry.CreatedAt
-			timeentryWithUser.UpdatedAt = timeentry.UpdatedAt
-			timeentryWithUser.User = mavenlink.GetUserFromProjectId(projectKeyOrId, timeentry.UserId)
+			timeentryWithUser := TimeEntryToTimeentry(timeentry)
+			user, userErr := mavenlink.GetUserFromProjectId(projectKeyOrId, timeentry.UserId)
+			if userErr != nil && !IsNotFound(userErr) {
+				return timeentries, userErr
+			}
+			timeentryWithUser.User = user
 			timeentries = append(timeentries, timeentryWithUser)
 		}
 	}
@@ -353,10 +331,11 @@ func (mavenlink *MavenlinkApi) GetTimeEntriesFromProjectIdAndIssueTaskId(project
 }
 
 func (mavenlink *MavenlinkApi) GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error) {
+	env := mavenlink.config()
 	var usersResponse *communicator.MavenlinkUsersResponse
 	var users []*communicator.User
 	var Url *url.URL
-	Url, UrlErr := url.Parse(mavenlink.env.Url)
+	Url, UrlErr := url.Parse(env.Url)
 	if UrlErr != nil {
 		return users, errors.New("Failed to parse environment URL")
 	}
@@ -364,45 +343,85 @@ func (mavenlink *MavenlinkApi) GetUsersFromProjectId(projectKeyOrId string) ([]*
 	parameters := url.Values{}
 	parameters.Add("participant_in", projectKeyOrId)
 	Url.RawQuery = parameters.Encode()
-	token := mavenlink.env.Token
-	apiErr := InsecureRequest(Url.String(), "GET", nil, token, &usersResponse)
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &usersResponse)
 	if apiErr != nil {
-		if mavenlink.env.Debug == true {
-			log.Logf("Error(API - %s) : %s\n", Url.String(), apiErr)
-		}
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
 		return users, apiErr
 	}
 	if usersResponse == nil || usersResponse.Users == nil {
 		return users, errors.New("Failed to retrieve response from users endpoint")
 	}
 	for _, user := range usersResponse.Users {
-		formattedUser := new(communicator.User)
-		formattedUser.Id = user.Id
-		formattedUser.FullName = user.FullName
-		formattedUser.EmailAddress = user.EmailAddress
-		formattedUser.Headline = user.Headline
-		formattedUser.AccountId = user.AccountId
-		users = append(users, formattedUser)
+		users = append(users, MavenlinkUserToUser(user))
 	}
 	return users, nil
 }
 
-func (mavenlink *MavenlinkApi) GetUserFromProjectId(projectKeyOrId string, userId string) *communicator.User {
+// GetUserFromProjectId is used to retrieve a single participant of a workspace from Mavenlink
+func (mavenlink *MavenlinkApi) GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error) {
 	var theUser *communicator.User
 	users, usersErr := mavenlink.GetUsersFromProjectId(projectKeyOrId)
 	if usersErr != nil {
-		return theUser
+		return theUser, usersErr
 	}
 	for _, user := range users {
 		if user.Id == userId {
-			formattedUser := new(communicator.User)
-			formattedUser.Id = user.Id
-			formattedUser.FullName = user.FullName
-			formattedUser.EmailAddress = user.EmailAddress
-			formattedUser.Headline = user.Headline
-			formattedUser.AccountId = user.AccountId
-			theUser = formattedUser
+			theUser = user
 		}
 	}
-	return theUser
+	if theUser == nil {
+		return theUser, &NotFoundError{Resource: "User", Id: userId}
+	}
+	return theUser, nil
+}
+
+// GetUserById is used to retrieve a single user from Mavenlink without requiring a workspace
+func (mavenlink *MavenlinkApi) GetUserById(userId string) (*communicator.User, error) {
+	env := mavenlink.config()
+	var usersResponse *communicator.MavenlinkUsersResponse
+	var theUser *communicator.User
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return theUser, errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["users"]
+	parameters := url.Values{}
+	parameters.Add("only", userId)
+	Url.RawQuery = parameters.Encode()
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &usersResponse)
+	if apiErr != nil {
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
+		return theUser, apiErr
+	}
+	if usersResponse == nil || usersResponse.Users == nil {
+		return theUser, errors.New("Failed to retrieve response from users endpoint")
+	}
+	user, ok := usersResponse.Users[userId]
+	if !ok {
+		return theUser, &NotFoundError{Resource: "User", Id: userId}
+	}
+	return MavenlinkUserToUser(user), nil
+}
+
+// Ping fetches the user the token belongs to, the cheapest request Mavenlink
+// answers, to verify that Mavenlink is reachable and accepts the token
+func (mavenlink *MavenlinkApi) Ping() error {
+	env := mavenlink.config()
+	var usersResponse *communicator.MavenlinkUsersResponse
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["me"]
+	token := env.Token
+	apiErr := InsecureRequestContext(mavenlink.context(), Url.String(), "GET", nil, token, &usersResponse)
+	if apiErr != nil {
+		LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": apiErr})
+		return apiErr
+	}
+	return nil
 }
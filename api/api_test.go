@@ -0,0 +1,454 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Retries are not delayed in tests
+func init() {
+	retryBackoff = time.Millisecond
+	maxRetryDelay = time.Millisecond
+}
+
+// newTestApi returns an API calling a fake Mavenlink server, which the test(param: t) must close
+func newTestApi(t *testing.T) (*MavenlinkApi, *mavenlinktest.Server) {
+	server := mavenlinktest.NewServer()
+	mavenlink := new(MavenlinkApi)
+	if err := mavenlink.SetEnv(server.Config()); err != nil {
+		t.Fatal(err)
+	}
+	return mavenlink, server
+}
+
+func statusOf(err error) int {
+	if statusErr, ok := errors.Cause(err).(*StatusError); ok {
+		return statusErr.StatusCode
+	}
+	return 0
+}
+
+func TestGetProjects(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	projects, err := mavenlink.GetProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make(map[string]string)
+	for _, project := range projects {
+		titles[project.Id] = project.Title
+	}
+	if len(titles) != 3 || titles["1001"] != "Website Redesign" || titles["1002"] != "Mobile App" {
+		t.Errorf("unexpected projects %v", titles)
+	}
+}
+
+func TestGetProject(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	project, err := mavenlink.GetProject("1002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if project.Title != "Mobile App" || project.CurrencySymbol != "$" || project.AccessLevel != "invitation" {
+		t.Errorf("unexpected project %v", project)
+	}
+	if _, err := mavenlink.GetProject("9999"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestGetTasksFromProjectId(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	tasks, err := mavenlink.GetTasksFromProjectId("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.Id)
+		if len(task.ParentId) > 0 {
+			t.Errorf("task %s has a parent", task.Id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) != 3 || ids[0] != "3001" || ids[1] != "3004" || ids[2] != "3005" {
+		t.Errorf("unexpected tasks %v", ids)
+	}
+	requests := server.Requests(mavenlinktest.Stories)
+	if len(requests) != 1 || requests[0].Query.Get("parents_only") != "true" {
+		t.Errorf("expected a single request of parent stories, got %v", requests)
+	}
+	if requests := server.Requests(mavenlinktest.StoryDependencies); len(requests) != 0 {
+		t.Errorf("expected the dependencies to be left out, got %v", requests)
+	}
+}
+
+func TestGetSubTasksFromProjectId(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	tasks, err := mavenlink.GetSubTasksFromProjectId("1001", "3001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Id != "3002" {
+		t.Errorf("unexpected sub tasks %v", tasks)
+	}
+}
+
+func TestGetUsersFromProjectId(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	users, err := mavenlink.GetUsersFromProjectId("1002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]string)
+	for _, user := range users {
+		names[user.Id] = user.FullName
+	}
+	if len(names) != 2 || names["2002"] != "Grace Hopper" || names["2003"] != "Alan Turing" {
+		t.Errorf("unexpected participants %v", names)
+	}
+	if _, err := mavenlink.GetUserFromProjectId("1002", "2001"); !IsNotFound(err) {
+		t.Errorf("expected a not found error for a user outside the project, got %v", err)
+	}
+}
+
+func TestGetUserById(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	user, err := mavenlink.GetUserById("2001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.FullName != "Ada Lovelace" {
+		t.Errorf("unexpected user %v", user)
+	}
+	if _, err := mavenlink.GetUserById("2999"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestStreamProjectsPaginates(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	var ids []string
+	err := mavenlink.StreamProjects(2, func(project *communicator.Project) error {
+		ids = append(ids, project.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("expected every project, got %v", ids)
+	}
+	requests := server.Requests(mavenlinktest.Workspaces)
+	if len(requests) != 2 {
+		t.Fatalf("expected a request per page, got %d", len(requests))
+	}
+	for index, request := range requests {
+		if request.Query.Get("per_page") != "2" || request.Query.Get("page") != []string{"1", "2"}[index] {
+			t.Errorf("unexpected page request %v", request.Query)
+		}
+	}
+}
+
+func TestGetTaskTreeFromProjectIdPaginates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixtures")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// More sub stories and time entries than fit a page of the largest size
+	stories := []map[string]interface{}{{"id": "1", "title": "Root", "workspace_id": "10", "assignee_ids": []string{"7"}}}
+	var timeentries []map[string]interface{}
+	for index := 2; index <= 250; index++ {
+		id := fmt.Sprint(index)
+		stories = append(stories, map[string]interface{}{"id": id, "title": "Child", "workspace_id": "10", "parent_id": "1"})
+		timeentries = append(timeentries, map[string]interface{}{"id": id, "workspace_id": "10", "story_id": id, "time_in_minutes": 2})
+	}
+	fixtures := map[string]interface{}{
+		"stories":      stories,
+		"time_entries": timeentries,
+		"users":        []map[string]interface{}{{"id": "7", "full_name": "Ada Lovelace"}},
+	}
+	for resource, records := range fixtures {
+		raw, _ := json.Marshal(records)
+		if err := ioutil.WriteFile(filepath.Join(dir, resource+".json"), raw, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	server, err := mavenlinktest.NewServerFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	mavenlink := new(MavenlinkApi)
+	mavenlink.SetEnv(server.Config())
+	tree, err := mavenlink.GetTaskTreeFromProjectId("10", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 1 || len(tree[0].Children) != 249 || tree[0].TotalLoggedMinutes != 498 {
+		t.Fatalf("expected every story and time entry in the tree, got %d roots", len(tree))
+	}
+	if len(tree[0].Assignees) != 1 || tree[0].Assignees[0].FullName != "Ada Lovelace" {
+		t.Errorf("expected the included assignee, got %v", tree[0].Assignees)
+	}
+	for _, endpoint := range []string{mavenlinktest.Stories, mavenlinktest.TimeEntries} {
+		if requests := server.Requests(endpoint); len(requests) != 2 {
+			t.Errorf("expected two pages of %s, got %d requests", endpoint, len(requests))
+		}
+	}
+}
+
+func TestBuildTaskTreeReportsCycles(t *testing.T) {
+	stories := []*communicator.MavenlinkStory{
+		{Id: "1", Title: "Root"},
+		{Id: "2", ParentId: "3"},
+		{Id: "3", ParentId: "2"},
+		{Id: "4", ParentId: "3"},
+	}
+	_, err := BuildTaskTree(stories, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "2, 3, 4") {
+		t.Errorf("expected the stories of the cycle to be reported, got %v", err)
+	}
+}
+
+func TestStreamTasksIncludesAssignees(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	assignees := make(map[string]string)
+	err := mavenlink.StreamTasks("1001", 0, func(task *communicator.Task) error {
+		if task.User != nil {
+			assignees[task.Id] = task.User.Id
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignees["3003"] != "2002" || len(assignees["3005"]) > 0 {
+		t.Errorf("unexpected assignees %v", assignees)
+	}
+}
+
+func TestGetTasksByIds(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	tasks, notFound, err := mavenlink.GetTasksByIds([]string{"3001", "3102", "3001", "3999"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks["3102"] == nil || tasks["3102"].WorkspaceId != "1002" {
+		t.Errorf("unexpected tasks %v", tasks)
+	}
+	if len(notFound) != 1 || notFound[0] != "3999" {
+		t.Errorf("unexpected missing ids %v", notFound)
+	}
+	if requests := server.Requests(mavenlinktest.Stories); len(requests) != 1 {
+		t.Errorf("expected a single request, got %d", len(requests))
+	}
+}
+
+func TestStatusErrors(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	for _, status := range []int{http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusInternalServerError} {
+		server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: status}, maxRetries+1)
+		if _, err := mavenlink.GetProjects(); statusOf(err) != status {
+			t.Errorf("expected a %d status error, got %v", status, err)
+		}
+		server.Reset()
+	}
+	if _, err := mavenlink.GetProjects(); err != nil {
+		t.Errorf("expected the faults to be spent, got %v", err)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	tests := []struct {
+		status   int
+		requests int
+	}{
+		{http.StatusTooManyRequests, 2},
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusInternalServerError, 2},
+		// Requests Mavenlink refused are not retried
+		{http.StatusUnauthorized, 1},
+		{http.StatusNotFound, 1},
+	}
+	for _, test := range tests {
+		server.Reset()
+		server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: test.status}, 1)
+		_, err := mavenlink.GetProjects()
+		if test.requests > 1 && err != nil {
+			t.Errorf("expected the %d failure to be retried, got %v", test.status, err)
+		}
+		if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != test.requests {
+			t.Errorf("expected %d requests after a %d failure, got %d", test.requests, test.status, len(requests))
+		}
+	}
+}
+
+func TestRequestsStopWithTheirContext(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := mavenlink.WithContext(cancelled).GetProjects(); err == nil {
+		t.Error("expected the call of a cancelled context to fail")
+	}
+	if requests := server.Requests(""); len(requests) != 0 {
+		t.Errorf("expected no request for a cancelled context, got %v", requests)
+	}
+
+	// The wait before a retry ends with the context
+	defer func(delay time.Duration) { maxRetryDelay = delay }(maxRetryDelay)
+	maxRetryDelay = time.Minute
+	server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: http.StatusTooManyRequests}, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	if _, err := mavenlink.WithContext(ctx).GetProjects(); statusOf(err) != http.StatusTooManyRequests {
+		t.Errorf("expected the rate limit to be reported, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the retry to be abandoned with the context, waited %s", elapsed)
+	}
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 1 {
+		t.Errorf("expected a single request, got %d", len(requests))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	rateLimited := &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Millisecond / 2}
+	if delay, ok := retryDelay("POST", http.StatusTooManyRequests, rateLimited, 0); !ok || delay != time.Millisecond/2 {
+		t.Errorf("expected the delay Mavenlink asks for, got %v %v", delay, ok)
+	}
+	rateLimited.RetryAfter = time.Hour
+	if delay, _ := retryDelay("GET", http.StatusTooManyRequests, rateLimited, 0); delay != maxRetryDelay {
+		t.Errorf("expected the longest delay, got %v", delay)
+	}
+	failed := &StatusError{StatusCode: http.StatusBadGateway}
+	if _, ok := retryDelay("POST", http.StatusBadGateway, failed, 0); ok {
+		t.Error("expected a failed write not to be retried")
+	}
+	if _, ok := retryDelay("GET", http.StatusBadGateway, failed, maxRetries); ok {
+		t.Error("expected the retries to be limited")
+	}
+	if retryAfter("120") != 2*time.Minute || retryAfter("") != 0 {
+		t.Error("expected the Retry-After seconds to be parsed")
+	}
+}
+
+func TestInvalidToken(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	configuration := server.Config()
+	configuration.Token = "expired"
+	mavenlink.SetEnv(configuration)
+	if _, err := mavenlink.GetUserById("2001"); statusOf(err) != http.StatusUnauthorized {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	server.Fail(mavenlinktest.Stories, mavenlinktest.Fault{Malformed: true}, -1)
+	if _, err := mavenlink.GetTasksFromProjectId("1001"); err == nil || statusOf(err) != 0 {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+	err := mavenlink.StreamTasks("1001", 0, func(*communicator.Task) error { return nil })
+	if err == nil {
+		t.Error("expected the stream to fail")
+	}
+}
+
+func TestStreamFailsMidway(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	emitted := 0
+	err := mavenlink.StreamProjects(1, func(*communicator.Project) error {
+		// The pages following the first one fail
+		if emitted++; emitted == 1 {
+			server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: http.StatusInternalServerError}, -1)
+		}
+		return nil
+	})
+	if statusOf(err) != http.StatusInternalServerError || emitted != 1 {
+		t.Errorf("expected the stream to fail after one project, got %v after %d", err, emitted)
+	}
+}
+
+// staticTokenSource hands out its token until refreshed, counting the refreshes
+type staticTokenSource struct {
+	token     string
+	refreshed int
+}
+
+func (source *staticTokenSource) Token() (string, error) {
+	return source.token, nil
+}
+
+func (source *staticTokenSource) Refresh() (string, error) {
+	source.refreshed++
+	source.token = mavenlinktest.Token
+	return source.token, nil
+}
+
+func TestTokenSourceRefreshesRejectedToken(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	configuration := server.Config()
+	configuration.Token = ""
+	mavenlink.SetEnv(configuration)
+	source := &staticTokenSource{token: "expired"}
+	SetTokenSource(source)
+	defer SetTokenSource(nil)
+	if _, err := mavenlink.GetProject("1001"); err != nil {
+		t.Fatal(err)
+	}
+	if source.refreshed != 1 || len(server.Requests(mavenlinktest.Workspaces)) != 2 {
+		t.Errorf("expected a single retry with a refreshed token, got %d refreshes", source.refreshed)
+	}
+}
+
+func TestRequestsCarryTheIdOfTheirContext(t *testing.T) {
+	mavenlink, server := newTestApi(t)
+	defer server.Close()
+	bound := mavenlink.WithContext(LOG.WithRequestID(context.Background(), "rpc-request"))
+	if _, err := bound.GetProjects(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mavenlink.GetProjects(); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.Requests(mavenlinktest.Workspaces)
+	if len(requests) != 2 || requests[0].Header.Get(LOG.RequestIDKey) != "rpc-request" {
+		t.Fatalf("expected the ID of the context to be sent, got %v", requests)
+	}
+	if id := requests[1].Header.Get(LOG.RequestIDKey); len(id) < 1 || id == "rpc-request" {
+		t.Errorf("expected a new ID without one in the context, got %q", id)
+	}
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"fmt"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/url"
+	"strings"
+)
+
+// Number of IDs passed to Mavenlink's only= parameter in a single request
+const onlyChunkSize = 100
+
+// GetProjectsByIds is used to retrieve several workspaces from Mavenlink by ID. The
+// projects found are keyed by ID and the IDs which Mavenlink did not return are listed
+func (mavenlink *MavenlinkApi) GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error) {
+	env := mavenlink.config()
+	projects := make(map[string]*communicator.Project)
+	fetchErr := mavenlink.forEachChunk(endpoint["workspaces"], ids, nil, func(chunkUrl string) error {
+		var workspacesResponse *communicator.MavenlinkWorkspacesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), chunkUrl, "GET", nil, env.Token, &workspacesResponse)
+		if apiErr != nil {
+			return apiErr
+		}
+		if workspacesResponse == nil {
+			return errors.New("Failed to retrieve response from workspaces endpoint")
+		}
+		for key, workspace := range workspacesResponse.Workspaces {
+			projects[key] = WorkspaceToProject(workspace)
+		}
+		return nil
+	})
+	if fetchErr != nil {
+		return nil, nil, fetchErr
+	}
+	return projects, missingIds(ids, func(id string) bool { return projects[id] != nil }), nil
+}
+
+// GetTasksByIds is used to retrieve several stories from Mavenlink by ID. The tasks
+// found are keyed by ID and the IDs which Mavenlink did not return are listed
+func (mavenlink *MavenlinkApi) GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error) {
+	env := mavenlink.config()
+	tasks := make(map[string]*communicator.Task)
+	parameters := url.Values{}
+	parameters.Add("include", "assignees")
+	fetchErr := mavenlink.forEachChunk(endpoint["stories"], ids, parameters, func(chunkUrl string) error {
+		var storiesResponse *communicator.MavenlinkStoriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), chunkUrl, "GET", nil, env.Token, &storiesResponse)
+		if apiErr != nil {
+			return apiErr
+		}
+		if storiesResponse == nil {
+			return errors.New("Failed to retrieve response from stories endpoint")
+		}
+		for key, story := range storiesResponse.Stories {
+			task := StoryToTask(story)
+			for _, assignee := range story.AssigneeIds {
+				if user, ok := storiesResponse.Users[assignee]; ok {
+					task.User = MavenlinkUserToUser(user)
+				}
+			}
+			tasks[key] = task
+		}
+		return nil
+	})
+	if fetchErr != nil {
+		return nil, nil, fetchErr
+	}
+	return tasks, missingIds(ids, func(id string) bool { return tasks[id] != nil }), nil
+}
+
+// GetUsersByIds is used to retrieve several users from Mavenlink by ID. The users
+// found are keyed by ID and the IDs which Mavenlink did not return are listed
+func (mavenlink *MavenlinkApi) GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error) {
+	env := mavenlink.config()
+	users := make(map[string]*communicator.User)
+	fetchErr := mavenlink.forEachChunk(endpoint["users"], ids, nil, func(chunkUrl string) error {
+		var usersResponse *communicator.MavenlinkUsersResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), chunkUrl, "GET", nil, env.Token, &usersResponse)
+		if apiErr != nil {
+			return apiErr
+		}
+		if usersResponse == nil {
+			return errors.New("Failed to retrieve response from users endpoint")
+		}
+		for key, user := range usersResponse.Users {
+			users[key] = MavenlinkUserToUser(user)
+		}
+		return nil
+	})
+	if fetchErr != nil {
+		return nil, nil, fetchErr
+	}
+	return users, missingIds(ids, func(id string) bool { return users[id] != nil }), nil
+}
+
+// GetTimeEntriesByIds is used to retrieve several time entries from Mavenlink by ID. The
+// time entries found are keyed by ID and the IDs which Mavenlink did not return are listed
+func (mavenlink *MavenlinkApi) GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error) {
+	env := mavenlink.config()
+	timeentries := make(map[string]*communicator.Timeentry)
+	parameters := url.Values{}
+	parameters.Add("include", "user")
+	fetchErr := mavenlink.forEachChunk(endpoint["time_entries"], ids, parameters, func(chunkUrl string) error {
+		var timeentriesResponse *communicator.MavenlinkTimeEntriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), chunkUrl, "GET", nil, env.Token, &timeentriesResponse)
+		if apiErr != nil {
+			return apiErr
+		}
+		if timeentriesResponse == nil {
+			return errors.New("Failed to retrieve response from time entries endpoint")
+		}
+		for key, timeentry := range timeentriesResponse.TimeEntries {
+			timeentryWithUser := TimeEntryToTimeentry(timeentry)
+			if user, ok := timeentriesResponse.Users[timeentry.UserId]; ok {
+				timeentryWithUser.User = MavenlinkUserToUser(user)
+			}
+			timeentries[key] = timeentryWithUser
+		}
+		return nil
+	})
+	if fetchErr != nil {
+		return nil, nil, fetchErr
+	}
+	return timeentries, missingIds(ids, func(id string) bool { return timeentries[id] != nil }), nil
+}
+
+// forEachChunk splits the unique IDs(param: ids) into chunks and passes the URL
+// of the endpoint(param: path) restricted to each chunk with the only= parameter
+// to the callback(param: fetch)
+func (mavenlink *MavenlinkApi) forEachChunk(path string, ids []string, parameters url.Values,
+	fetch func(chunkUrl string) error) error {
+	env := mavenlink.config()
+
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return errors.New("Failed to parse environment URL")
+	}
+	Url.Path += path
+	if parameters == nil {
+		parameters = url.Values{}
+	}
+	unique := uniqueIds(ids)
+	for start := 0; start < len(unique); start += onlyChunkSize {
+		end := start + onlyChunkSize
+		if end > len(unique) {
+			end = len(unique)
+		}
+		parameters.Set("only", strings.Join(unique[start:end], ","))
+		parameters.Set("per_page", fmt.Sprint(end-start))
+		Url.RawQuery = parameters.Encode()
+		if fetchErr := fetch(Url.String()); fetchErr != nil {
+			LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": fetchErr})
+			return fetchErr
+		}
+	}
+	return nil
+}
+
+// uniqueIds removes blank and repeated IDs while keeping their order
+func uniqueIds(ids []string) []string {
+	var unique []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if len(id) < 1 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// missingIds lists the unique IDs for which the check(param: found) fails
+func missingIds(ids []string, found func(id string) bool) []string {
+	var missing []string
+	for _, id := range uniqueIds(ids) {
+		if !found(id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// HTTP modes of the requests made to Mavenlink. Requests are made live unless
+// their responses are recorded to, or replayed from, cassette files
+const (
+	LiveMode   = ""
+	RecordMode = "record"
+	ReplayMode = "replay"
+)
+
+// Directory of the cassette files unless set otherwise
+const DefaultCassetteDir = "cassettes"
+
+// Value replacing the secrets scrubbed from the cassettes
+const scrubbed = "SCRUBBED"
+
+// Query parameters holding secrets, left out of the recorded URLs and of the
+// keys of the cassettes so that recordings replay whatever the credentials
+var secretParameters = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+	"client_secret": true,
+	"code":          true,
+}
+
+// secretFields matches the JSON fields of the bodies holding secrets
+var secretFields = regexp.MustCompile(`"(access_token|refresh_token|token|client_secret)"(\s*:\s*)"[^"]*"`)
+
+// cassette is a recorded request and the response Mavenlink gave to it
+type cassette struct {
+	Request struct {
+		Method string `json:"method"`
+		Url    string `json:"url"`
+		Body   string `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status int         `json:"status"`
+		Header http.Header `json:"header,omitempty"`
+		Body   string      `json:"body"`
+	} `json:"response"`
+}
+
+// cassetteTransport records the responses returned by the transport(param: next), or replays
+// them without making any request, as a cassette file per request in the directory(param: dir)
+type cassetteTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+}
+
+// NewCassetteTransport returns a transport recording the responses of the transport(param: next)
+// to the directory(param: dir), or replaying them, according to the mode(param: mode). The
+// transport(param: next) is returned as is in live mode
+func NewCassetteTransport(mode string, dir string, next http.RoundTripper) (http.RoundTripper, error) {
+	if len(dir) < 1 {
+		dir = DefaultCassetteDir
+	}
+	switch mode {
+	case LiveMode:
+		return next, nil
+	case RecordMode:
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Wrap(err, "Failed to create the cassette directory")
+		}
+	case ReplayMode:
+		if _, err := os.Stat(dir); err != nil {
+			return nil, errors.Wrap(err, "Failed to open the cassette directory")
+		}
+	default:
+		return nil, errors.Errorf("Unknown HTTP mode %q", mode)
+	}
+	return &cassetteTransport{mode: mode, dir: dir, next: next}, nil
+}
+
+func (transport *cassetteTransport) RoundTrip(httpReq *http.Request) (*http.Response, error) {
+	var body []byte
+	if httpReq.Body != nil {
+		read, err := ioutil.ReadAll(httpReq.Body)
+		httpReq.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = read
+		httpReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	recorded := &cassette{}
+	recorded.Request.Method = httpReq.Method
+	recorded.Request.Url = scrubUrl(httpReq.URL)
+	recorded.Request.Body = scrubBody(string(body))
+	path := filepath.Join(transport.dir, cassetteName(recorded))
+	if transport.mode == ReplayMode {
+		return replayCassette(path, recorded, httpReq)
+	}
+	httpResp, err := transport.next.RoundTrip(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	recorded.Response.Status = httpResp.StatusCode
+	recorded.Response.Header = recordedHeader(httpResp.Header)
+	recorded.Response.Body = scrubBody(string(respBody))
+	if err := writeCassette(path, recorded); err != nil {
+		return nil, err
+	}
+	httpResp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+	return httpResp, nil
+}
+
+// replayCassette returns the response recorded in the cassette file(param: path) for the request(param: httpReq)
+func replayCassette(path string, recorded *cassette, httpReq *http.Request) (*http.Response, error) {
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.Errorf("No recorded response for %s %s", recorded.Request.Method, recorded.Request.Url)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, recorded); err != nil {
+		return nil, errors.Wrapf(err, "Invalid cassette %s", path)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Response.Status, http.StatusText(recorded.Response.Status)),
+		StatusCode:    recorded.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Response.Header,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Response.Body)),
+		ContentLength: int64(len(recorded.Response.Body)),
+		Request:       httpReq,
+	}, nil
+}
+
+// writeCassette saves the cassette(param: recorded) to the file(param: path), replacing it at once
+// so that concurrent replays never read a partial recording
+func writeCassette(path string, recorded *cassette) error {
+	raw, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Concurrent recordings of the same request each write a file of their own
+	temp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "Failed to record the response")
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(raw); err != nil {
+		temp.Close()
+		return errors.Wrap(err, "Failed to record the response")
+	}
+	if err := temp.Close(); err != nil {
+		return errors.Wrap(err, "Failed to record the response")
+	}
+	if err := os.Chmod(temp.Name(), 0644); err != nil {
+		return errors.Wrap(err, "Failed to record the response")
+	}
+	return errors.Wrap(os.Rename(temp.Name(), path), "Failed to record the response")
+}
+
+// cassetteName names the cassette of a request after its method, scrubbed path and query and
+// body, the endpoint being kept readable, e.g. GET-workspaces-1a2b3c4d5e6f7a8b.json. The host
+// is left out so that responses recorded from Mavenlink replay whatever the configured URL
+func cassetteName(recorded *cassette) string {
+	endpoint := "request"
+	requestUri := recorded.Request.Url
+	if parsed, err := url.Parse(recorded.Request.Url); err == nil {
+		endpoint = strings.TrimSuffix(filepath.Base(parsed.Path), ".json")
+		requestUri = parsed.RequestURI()
+	}
+	sum := sha256.Sum256([]byte(recorded.Request.Method + " " + requestUri + "\n" + recorded.Request.Body))
+	return fmt.Sprintf("%s-%s-%s.json", recorded.Request.Method, endpoint, hex.EncodeToString(sum[:8]))
+}
+
+// scrubUrl returns the URL(param: Url) without its secret parameters, the others being
+// sorted so that the same request always has the same cassette
+func scrubUrl(Url *url.URL) string {
+	scrubbedUrl := *Url
+	parameters := url.Values{}
+	for name, values := range Url.Query() {
+		if !secretParameters[name] {
+			parameters[name] = values
+		}
+	}
+	scrubbedUrl.RawQuery = parameters.Encode()
+	scrubbedUrl.User = nil
+	return scrubbedUrl.String()
+}
+
+// scrubBody replaces the values of the secret JSON fields of the body(param: body)
+func scrubBody(body string) string {
+	return secretFields.ReplaceAllString(body, `"$1"$2"`+scrubbed+`"`)
+}
+
+// recordedHeader returns the headers of a response worth replaying, leaving cookies and dates out
+func recordedHeader(header http.Header) http.Header {
+	recorded := http.Header{}
+	for name, values := range header {
+		if name != "Set-Cookie" && name != "Date" {
+			recorded[name] = values
+		}
+	}
+	return recorded
+}
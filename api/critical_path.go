@@ -0,0 +1,242 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"sort"
+	"time"
+)
+
+// Date format used by Mavenlink for start and due dates
+const dateLayout = "2006-01-02"
+
+// Dependency types supported by Mavenlink story dependencies
+const (
+	finishToStart  = "finish_to_start"
+	startToStart   = "start_to_start"
+	finishToFinish = "finish_to_finish"
+	startToFinish  = "start_to_finish"
+)
+
+// GetCriticalPathFromProjectId is used to compute the critical path and slack
+// of the top level stories of a workspace in Mavenlink
+func (mavenlink *MavenlinkApi) GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error) {
+	tasks, tasksErr := mavenlink.GetTasksFromProjectId(projectKeyOrId)
+	if tasksErr != nil {
+		return nil, tasksErr
+	}
+	dependencies, dependenciesErr := mavenlink.GetTaskDependenciesFromProjectId(projectKeyOrId)
+	if dependenciesErr != nil {
+		return nil, dependenciesErr
+	}
+	tasks = WithDependencies(tasks, dependencies)
+	criticalPath, pathErr := CriticalPathFromTasks(tasks)
+	if pathErr != nil {
+		return nil, pathErr
+	}
+	criticalPath.WorkspaceId = projectKeyOrId
+	return criticalPath, nil
+}
+
+// CriticalPathFromTasks performs a forward and backward pass over the
+// tasks(param: tasks) using their start dates, due dates and predecessors.
+// Offsets and durations are expressed in days from the earliest start date
+// found amongst the tasks. Dependencies on tasks outside of the list are
+// ignored
+func CriticalPathFromTasks(tasks []*communicator.Task) (*communicator.CriticalPath, error) {
+	criticalPath := new(communicator.CriticalPath)
+	if len(tasks) < 1 {
+		return criticalPath, nil
+	}
+
+	// find the date every offset is measured from
+	var origin time.Time
+	for _, task := range tasks {
+		if start, ok := parseDate(task.StartDate); ok && (origin.IsZero() || start.Before(origin)) {
+			origin = start
+		}
+		if due, ok := parseDate(task.DueDate); ok && (origin.IsZero() || due.Before(origin)) {
+			origin = due
+		}
+	}
+
+	// create a node per task with its scheduled offset and duration
+	nodes := make(map[string]*communicator.CriticalPathTask)
+	scheduled := make(map[string]int32)
+	for _, task := range tasks {
+		node := new(communicator.CriticalPathTask)
+		node.TaskId = task.Id
+		node.Title = task.Title
+		node.StartDate = task.StartDate
+		node.DueDate = task.DueDate
+		start, hasStart := parseDate(task.StartDate)
+		due, hasDue := parseDate(task.DueDate)
+		switch {
+		case hasStart && hasDue && !due.Before(start):
+			scheduled[task.Id] = daysBetween(origin, start)
+			node.Duration = daysBetween(start, due) + 1
+		case hasStart:
+			scheduled[task.Id] = daysBetween(origin, start)
+			node.Duration = 1
+		case hasDue:
+			scheduled[task.Id] = daysBetween(origin, due)
+			node.Duration = 1
+		}
+		nodes[task.Id] = node
+	}
+
+	// order the tasks so that every predecessor comes before its successors
+	order, orderErr := topologicalOrder(tasks, nodes)
+	if orderErr != nil {
+		return nil, orderErr
+	}
+
+	// forward pass : earliest start and finish
+	for _, task := range order {
+		node := nodes[task.Id]
+		node.EarliestStart = scheduled[task.Id]
+		for _, dependency := range task.Predecessors {
+			predecessor, ok := nodes[dependency.PredecessorId]
+			if !ok {
+				continue
+			}
+			var earliest int32
+			switch dependency.DependencyType {
+			case startToStart:
+				earliest = predecessor.EarliestStart + dependency.Lag
+			case finishToFinish:
+				earliest = predecessor.EarliestFinish + dependency.Lag - node.Duration
+			case startToFinish:
+				earliest = predecessor.EarliestStart + dependency.Lag - node.Duration
+			default:
+				earliest = predecessor.EarliestFinish + dependency.Lag
+			}
+			if earliest > node.EarliestStart {
+				node.EarliestStart = earliest
+			}
+		}
+		node.EarliestFinish = node.EarliestStart + node.Duration
+		if node.EarliestFinish > criticalPath.Duration {
+			criticalPath.Duration = node.EarliestFinish
+		}
+	}
+
+	// backward pass : latest start and finish
+	for index := len(order) - 1; index >= 0; index-- {
+		task := order[index]
+		node := nodes[task.Id]
+		node.LatestFinish = criticalPath.Duration
+		for _, dependency := range task.Successors {
+			successor, ok := nodes[dependency.SuccessorId]
+			if !ok {
+				continue
+			}
+			var latest int32
+			switch dependency.DependencyType {
+			case startToStart:
+				latest = successor.LatestStart - dependency.Lag + node.Duration
+			case finishToFinish:
+				latest = successor.LatestFinish - dependency.Lag
+			case startToFinish:
+				latest = successor.LatestFinish - dependency.Lag + node.Duration
+			default:
+				latest = successor.LatestStart - dependency.Lag
+			}
+			if latest < node.LatestFinish {
+				node.LatestFinish = latest
+			}
+		}
+		node.LatestStart = node.LatestFinish - node.Duration
+		node.Slack = node.LatestStart - node.EarliestStart
+		node.Critical = node.Slack == 0
+	}
+
+	for _, task := range order {
+		node := nodes[task.Id]
+		criticalPath.Tasks = append(criticalPath.Tasks, node)
+		if node.Critical {
+			criticalPath.TaskIds = append(criticalPath.TaskIds, node.TaskId)
+		}
+	}
+	if !origin.IsZero() {
+		criticalPath.StartDate = origin.Format(dateLayout)
+		if criticalPath.Duration > 0 {
+			finish := origin.AddDate(0, 0, int(criticalPath.Duration)-1)
+			criticalPath.FinishDate = finish.Format(dateLayout)
+		}
+	}
+	return criticalPath, nil
+}
+
+// topologicalOrder sorts the tasks so that predecessors always precede their
+// successors, keeping tasks without a relation ordered by their scheduled dates
+func topologicalOrder(tasks []*communicator.Task,
+	nodes map[string]*communicator.CriticalPathTask) ([]*communicator.Task, error) {
+
+	sorted := make([]*communicator.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].StartDate != sorted[j].StartDate {
+			return sorted[i].StartDate < sorted[j].StartDate
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+
+	pending := make(map[string]int)
+	for _, task := range sorted {
+		for _, dependency := range task.Predecessors {
+			if _, ok := nodes[dependency.PredecessorId]; ok {
+				pending[task.Id]++
+			}
+		}
+	}
+	byId := make(map[string]*communicator.Task)
+	for _, task := range sorted {
+		byId[task.Id] = task
+	}
+
+	var order []*communicator.Task
+	var queue []*communicator.Task
+	for _, task := range sorted {
+		if pending[task.Id] == 0 {
+			queue = append(queue, task)
+		}
+	}
+	for len(queue) > 0 {
+		task := queue[0]
+		queue = queue[1:]
+		order = append(order, task)
+		for _, dependency := range task.Successors {
+			successor, ok := byId[dependency.SuccessorId]
+			if !ok {
+				continue
+			}
+			pending[successor.Id]--
+			if pending[successor.Id] == 0 {
+				queue = append(queue, successor)
+			}
+		}
+	}
+	if len(order) != len(sorted) {
+		return nil, errors.New("Circular dependency detected between tasks. Failed to compute the critical path!")
+	}
+	return order, nil
+}
+
+// parseDate converts a Mavenlink date into a time, reporting whether the
+// date(param: value) was present and valid
+func parseDate(value string) (time.Time, bool) {
+	if len(value) < len(dateLayout) {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(dateLayout, value[:len(dateLayout)])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// daysBetween returns the number of whole days from(param: from) until(param: to)
+func daysBetween(from time.Time, to time.Time) int32 {
+	return int32(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/url"
+)
+
+// GetTaskDependenciesFromProjectId is used to retrieve all the story dependencies from a workspace in Mavenlink
+func (mavenlink *MavenlinkApi) GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error) {
+	env := mavenlink.config()
+	var dependencies []*communicator.TaskDependency
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return dependencies, errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["story_dependencies"]
+	parameters := url.Values{}
+	parameters.Add("workspace_id", projectKeyOrId)
+	Url.RawQuery = parameters.Encode()
+	pageErr := mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var dependenciesResponse *communicator.MavenlinkStoryDependenciesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &dependenciesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if dependenciesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from story dependencies endpoint")
+		}
+		for _, result := range dependenciesResponse.Results {
+			if storyDependency, ok := dependenciesResponse.StoryDependencies[result.Id]; ok {
+				dependencies = append(dependencies, StoryDependencyToTaskDependency(storyDependency))
+			}
+		}
+		return dependenciesResponse.Meta, nil
+	})
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	return dependencies, nil
+}
+
+// WithDependencies returns copies of the tasks(param: tasks) with their predecessors and
+// successors found amongst the dependencies(param: dependencies). The tasks themselves
+// are left untouched as they may be shared by a cache
+func WithDependencies(tasks []*communicator.Task, dependencies []*communicator.TaskDependency) []*communicator.Task {
+	var withDependencies []*communicator.Task
+	for _, task := range tasks {
+		copied := *task
+		copied.Predecessors = nil
+		copied.Successors = nil
+		for _, dependency := range dependencies {
+			if dependency.SuccessorId == task.Id {
+				copied.Predecessors = append(copied.Predecessors, dependency)
+			}
+			if dependency.PredecessorId == task.Id {
+				copied.Successors = append(copied.Successors, dependency)
+			}
+		}
+		withDependencies = append(withDependencies, &copied)
+	}
+	return withDependencies
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// NotFoundError is returned when Mavenlink responds successfully but does
+// not include the requested resource
+type NotFoundError struct {
+	Resource string
+	Id       string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s(ID: %s) not found", e.Resource, e.Id)
+}
+
+// StatusError is returned when Mavenlink responds with an error status
+type StatusError struct {
+	Status     string
+	StatusCode int
+	// RetryAfter is the delay Mavenlink asks to wait before retrying, zero when it does not say
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("Error : %s(Status: %d)", e.Status, e.StatusCode)
+}
+
+// IsNotFound reports whether the error(param: err) indicates that the requested
+// resource does not exist, either in Mavenlink's response or by its status
+func IsNotFound(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *NotFoundError:
+		return true
+	case *StatusError:
+		return cause.StatusCode == 404
+	}
+	return false
+}
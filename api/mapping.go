@@ -0,0 +1,80 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// WorkspaceToProject maps a Mavenlink workspace onto the Project exposed by this service
+func WorkspaceToProject(workspace *communicator.MavenlinkWorkspace) *communicator.Project {
+	project := new(communicator.Project)
+	project.Id = workspace.Id
+	project.Title = workspace.Title
+	project.Description = workspace.Description
+	project.AccessLevel = workspace.AccessLevel
+	project.AccountId = workspace.AccountId
+	project.Archived = workspace.Archived
+	project.Currency = workspace.Currency
+	project.CurrencySymbol = workspace.CurrencySymbol
+	project.CreatedAt = workspace.CreatedAt
+	project.DueDate = workspace.DueDate
+	project.EffectiveDueDate = workspace.EffectiveDueDate
+	project.StartDate = workspace.StartDate
+	project.UpdatedAt = workspace.UpdatedAt
+	return project
+}
+
+// StoryToTask maps a Mavenlink story onto the Task exposed by this service
+func StoryToTask(story *communicator.MavenlinkStory) *communicator.Task {
+	task := new(communicator.Task)
+	task.Id = story.Id
+	task.Title = story.Title
+	task.Description = story.Description
+	task.StoryType = story.StoryType
+	task.Priority = story.Priority
+	task.Archived = story.Archived
+	task.WorkspaceId = story.WorkspaceId
+	task.CreatorId = story.CreatorId
+	task.ParentId = story.ParentId
+	task.CreatedAt = story.CreatedAt
+	task.DueDate = story.DueDate
+	task.State = story.State
+	task.StartDate = story.StartDate
+	task.UpdatedAt = story.UpdatedAt
+	return task
+}
+
+// TimeEntryToTimeentry maps a Mavenlink time entry onto the Timeentry exposed by this service
+func TimeEntryToTimeentry(timeentry *communicator.MavenlinkTimeentry) *communicator.Timeentry {
+	formattedTimeentry := new(communicator.Timeentry)
+	formattedTimeentry.Id = timeentry.Id
+	formattedTimeentry.DatePerformed = timeentry.DatePerformed
+	formattedTimeentry.TimeInMinutes = timeentry.TimeInMinutes
+	formattedTimeentry.Notes = timeentry.Notes
+	formattedTimeentry.WorkspaceId = timeentry.WorkspaceId
+	formattedTimeentry.StoryId = timeentry.StoryId
+	formattedTimeentry.CreatedAt = timeentry.CreatedAt
+	formattedTimeentry.UpdatedAt = timeentry.UpdatedAt
+	return formattedTimeentry
+}
+
+// MavenlinkUserToUser maps a Mavenlink user onto the User exposed by this service
+func MavenlinkUserToUser(user *communicator.MavenlinkUser) *communicator.User {
+	formattedUser := new(communicator.User)
+	formattedUser.Id = user.Id
+	formattedUser.FullName = user.FullName
+	formattedUser.EmailAddress = user.EmailAddress
+	formattedUser.Headline = user.Headline
+	formattedUser.AccountId = user.AccountId
+	return formattedUser
+}
+
+// StoryDependencyToTaskDependency maps a Mavenlink story dependency onto the TaskDependency exposed by this service
+func StoryDependencyToTaskDependency(storyDependency *communicator.MavenlinkStoryDependency) *communicator.TaskDependency {
+	dependency := new(communicator.TaskDependency)
+	dependency.Id = storyDependency.Id
+	dependency.PredecessorId = storyDependency.SourceId
+	dependency.SuccessorId = storyDependency.TargetId
+	dependency.DependencyType = storyDependency.DependencyType
+	dependency.Lag = storyDependency.Lag
+	return dependency
+}
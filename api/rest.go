@@ -5,10 +5,13 @@ import (
 	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
-	"fmt"
-	"github.com/pkg/errors"
-	"log"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	"github.com/desertjinn/mavenlink-communicator/metrics"
+	"github.com/desertjinn/mavenlink-communicator/trace"
+	"golang.org/x/net/context"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,17 +25,102 @@ func BasicAuth(username, password string) string {
 	return base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
+// TokenSource provides the bearer token of the requests made without a token
+// of their own, renewing it before it expires
+type TokenSource interface {
+	Token() (string, error)
+	// Refresh renews the token after Mavenlink rejected it
+	Refresh() (string, error)
+}
+
+// tokenSource is used by the requests made without a token of their own when set
+var tokenSource TokenSource
+
+// SetTokenSource sets the source(param: source) of the token of the requests
+// made without a token of their own
+func SetTokenSource(source TokenSource) {
+	tokenSource = source
+}
+
+// Number of times a request is retried once Mavenlink rejects it for its rate limit or,
+// for reads, fails to answer it
+const maxRetries = 2
+
+// Delay before the first retry of a request, doubled for each following one, and the
+// longest delay waited, even when Mavenlink asks for longer
+var (
+	retryBackoff  = 250 * time.Millisecond
+	maxRetryDelay = 10 * time.Second
+)
+
+// Time allowed to each request made to Mavenlink unless set otherwise
+const defaultRequestTimeout = 30 * time.Second
+
+// requestTimeout holds the time allowed to each request, in nanoseconds
+var requestTimeout = int64(defaultRequestTimeout)
+
+// SetRequestTimeout sets the time allowed to each request made to Mavenlink(param: timeout),
+// restoring the default one when not positive. Requests already made keep their timeout
+func SetRequestTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	atomic.StoreInt64(&requestTimeout, int64(timeout))
+}
+
+// insecureTransport skips the verification of certificates. It is shared by every
+// request so that the connections to Mavenlink are kept alive between them
+var insecureTransport = &http.Transport{
+	Proxy:               http.ProxyFromEnvironment,
+	TLSClientConfig:     &tls.Config{InsecureSkipVerify: true},
+	TLSHandshakeTimeout: 10 * time.Second,
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 16,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// transport makes the requests, recording or replaying their responses when asked to
+var transport http.RoundTripper = insecureTransport
+
+// SetHTTPMode sets whether the requests made to Mavenlink are made live, or have their
+// responses recorded to or replayed from the cassette files of the directory(param: dir),
+// according to the mode(param: mode)
+func SetHTTPMode(mode string, dir string) error {
+	modeTransport, err := NewCassetteTransport(mode, dir, insecureTransport)
+	if err != nil {
+		return err
+	}
+	transport = modeTransport
+	return nil
+}
+
 // InsecureRequest makes an HTTP call to the provided endpoint(param: url)
 // using the prescribed HTTP request type(param: method). The response from
 // the endpoint(param: url) is then decoded by the json package into the
 // specified structure(param: target). The request is insecure due to the HTTP
-// transport layer being configured with the {InsecureSkipVerify: true} option
+// transport layer being configured with the {InsecureSkipVerify: true} option.
+// Without a token(param: token) the token source is used, the request being
+// retried once with a refreshed token when Mavenlink rejects it. Requests
+// rejected by the rate limit of Mavenlink, and reads it fails to answer, are
+// retried after a delay
 func InsecureRequest(url string, method string, body interface{}, token string, target interface{}) error {
-	// set the TLS option to skip insecure certificates
-	tr := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	// create a HTTP client with a timeout of 30 secs
-	client := &http.Client{Transport: tr, Timeout: time.Second * time.Duration(30)}
+	return InsecureRequestContext(context.Background(), url, method, body, token, target)
+}
 
+// InsecureRequestContext makes the same call as InsecureRequest on behalf of the
+// context(param: ctx), recording it as a client span of the trace the context carries
+func InsecureRequestContext(ctx context.Context, url string, method string, body interface{}, token string,
+	target interface{}) (err error) {
+	ctx, span := trace.Start(ctx, "HTTP "+method, trace.Client)
+	span.SetAttribute("http.method", method)
+	span.SetAttribute("http.url_template", metrics.Endpoint(url))
+	retries := 0
+	status := 0
+	defer func() {
+		span.SetAttribute("http.status_code", status)
+		span.SetAttribute("http.retry_count", retries)
+		span.End(err)
+	}()
 	// format JSON body
 	var rawBody bytes.Buffer
 	if body != nil {
@@ -40,41 +128,146 @@ func InsecureRequest(url string, method string, body interface{}, token string,
 			return err
 		}
 	}
+	if len(token) > 0 || tokenSource == nil {
+		status, retries, err = requestWithRetries(ctx, url, method, rawBody.Bytes(), token, target)
+		return err
+	}
+	token, tokenErr := tokenSource.Token()
+	if tokenErr != nil {
+		return tokenErr
+	}
+	status, retries, err = requestWithRetries(ctx, url, method, rawBody.Bytes(), token, target)
+	if status == http.StatusUnauthorized {
+		token, tokenErr = tokenSource.Refresh()
+		if tokenErr != nil {
+			return tokenErr
+		}
+		retries++
+		metrics.ObserveRetry(url)
+		var refreshedRetries int
+		status, refreshedRetries, err = requestWithRetries(ctx, url, method, rawBody.Bytes(), token, target)
+		retries += refreshedRetries
+	}
+	return err
+}
+
+// requestWithRetries performs the HTTP call, retrying it while it may succeed later, and
+// returns the status code of the last response with the number of retries made
+func requestWithRetries(ctx context.Context, url string, method string, rawBody []byte, token string,
+	target interface{}) (status int, retries int, err error) {
+	for {
+		status, err = request(ctx, url, method, rawBody, token, target)
+		delay, retry := retryDelay(method, status, err, retries)
+		if !retry || ctx.Err() != nil {
+			return status, retries, err
+		}
+		// the wait is abandoned as well once the context is done
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, retries, err
+		case <-timer.C:
+		}
+		retries++
+		metrics.ObserveRetry(url)
+	}
+}
+
+// retryDelay returns the delay before retrying a call made with the method(param: method)
+// which failed with the status code(param: status) and the error(param: err) after the
+// retries(param: retries) already made, and whether it is retried at all. Calls rejected
+// by the rate limit are retried whatever their method, other failures only for reads
+func retryDelay(method string, status int, err error, retries int) (time.Duration, bool) {
+	if err == nil || retries >= maxRetries {
+		return 0, false
+	}
+	delay := retryBackoff << uint(retries)
+	switch {
+	case status == http.StatusTooManyRequests:
+		if statusErr, ok := err.(*StatusError); ok && statusErr.RetryAfter > 0 {
+			delay = statusErr.RetryAfter
+		}
+	case method == "GET" && (status == 0 || status >= http.StatusInternalServerError):
+	default:
+		return 0, false
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay, true
+}
 
+// retryAfter returns the delay asked for by the Retry-After header(param: header),
+// given in seconds or as a date, zero when there is none
+func retryAfter(header string) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// request performs a single HTTP call authenticated with the token(param: token)
+// and returns the status code of the response, zero when none was received
+func request(ctx context.Context, url string, method string, rawBody []byte, token string, target interface{}) (int, error) {
+	// create a HTTP client with the current timeout, sharing the connections of the transport
+	client := &http.Client{Transport: transport, Timeout: time.Duration(atomic.LoadInt64(&requestTimeout))}
+
+	// share the ID of the request served, so that both sides log the call under it
+	requestID := LOG.RequestIDFrom(ctx)
+	if len(requestID) < 1 {
+		requestID = LOG.NewRequestID()
+	}
+	fields := LOG.Fields{"request_id": requestID, "method": method, "url": url}
 	// create a new HTTP request
-	httpReq, requestErr := http.NewRequest(method, url, &rawBody)
+	// the request is abandoned once the context is done, e.g. when the RPC is cancelled
+	httpReq, requestErr := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(rawBody))
 	if requestErr != nil {
-		log.Printf("HTTP Request Error : %s\n", requestErr)
-		return nil
+		fields["error"] = requestErr
+		LOG.Error("Mavenlink request could not be created", fields)
+		return 0, requestErr
 	}
 	// add custom headers
 	httpReq.Header.Add("Content-Type", "application/json")
 	// add authentication user-agent to header
 	httpReq.Header.Set("User-Agent", "mavenlink-communicator/1.0")
+	// identify the request in the logs of both sides
+	httpReq.Header.Set(LOG.RequestIDKey, requestID)
 	// add authentication token to header
 	httpReq.Header.Add("Authorization", "Bearer "+token)
-	log.Printf(":::::::::: Mavenlink Communicator HTTP request ::::::::::")
-	log.Printf("==>> %v <<==", httpReq)
+	// continue the trace of the request on the side of Mavenlink
+	if spanContext, ok := trace.SpanContextFrom(ctx); ok {
+		httpReq.Header.Set(trace.TraceparentKey, spanContext.Traceparent())
+	}
 	// use the HTTP client to perform the HTTP request
+	started := time.Now()
 	httpResp, requestErr := client.Do(httpReq)
+	elapsed := time.Since(started)
+	fields["latency_ms"] = float64(elapsed) / float64(time.Millisecond)
 	// check request has no error
 	if requestErr != nil {
-		log.Printf("HTTP Request Error : %s\n", requestErr)
-		return nil
-	}
-	// check response for status : NOT FOUND, UNAUTHORISED & FORBIDDEN
-	if 404 == httpResp.StatusCode ||
-		401 == httpResp.StatusCode ||
-		403 == httpResp.StatusCode ||
-		400 == httpResp.StatusCode ||
-		500 == httpResp.StatusCode {
-		formattedErr := fmt.Sprintf("Error : %s(Status: %d)", httpResp.Status, httpResp.StatusCode)
-		return errors.New(formattedErr)
+		metrics.ObserveUpstream(method, url, 0, elapsed)
+		fields["error"] = requestErr
+		LOG.Error("Mavenlink request failed", fields)
+		return 0, requestErr
+	}
+	defer httpResp.Body.Close()
+	fields["status"] = httpResp.StatusCode
+	metrics.ObserveUpstream(method, url, httpResp.StatusCode, elapsed)
+	// check response for error statuses, e.g. NOT FOUND, UNAUTHORISED & FORBIDDEN
+	if httpResp.StatusCode >= 400 {
+		LOG.Warn("Mavenlink request", fields)
+		return httpResp.StatusCode, &StatusError{Status: httpResp.Status, StatusCode: httpResp.StatusCode,
+			RetryAfter: retryAfter(httpResp.Header.Get("Retry-After"))}
 	}
+	LOG.Info("Mavenlink request", fields)
 	// check response for status : NO CONTENT
 	if 204 == httpResp.StatusCode {
-		return nil
+		return httpResp.StatusCode, nil
 	}
 	// decode response body to the intended target structure
-	return json.NewDecoder(httpResp.Body).Decode(target)
+	return httpResp.StatusCode, json.NewDecoder(httpResp.Body).Decode(target)
 }
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"fmt"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/url"
+)
+
+// Largest page size accepted by Mavenlink
+const maxPerPage = 200
+
+// StreamProjects is used to retrieve all the workspaces available in Mavenlink page
+// by page, handing each project to the callback(param: emit) as soon as its page arrives
+func (mavenlink *MavenlinkApi) StreamProjects(perPage int32, emit func(*communicator.Project) error) error {
+	env := mavenlink.config()
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["workspaces"]
+	return mavenlink.forEachPage(Url, perPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var workspacesResponse *communicator.MavenlinkWorkspacesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &workspacesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if workspacesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from workspaces endpoint")
+		}
+		for _, result := range workspacesResponse.Results {
+			workspace, ok := workspacesResponse.Workspaces[result.Id]
+			if !ok {
+				continue
+			}
+			if emitErr := emit(WorkspaceToProject(workspace)); emitErr != nil {
+				return nil, emitErr
+			}
+		}
+		return workspacesResponse.Meta, nil
+	})
+}
+
+// StreamTasks is used to retrieve all the stories of a workspace in Mavenlink page by
+// page, handing each task to the callback(param: emit) as soon as its page arrives
+func (mavenlink *MavenlinkApi) StreamTasks(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Task) error) error {
+	env := mavenlink.config()
+
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["stories"]
+	parameters := url.Values{}
+	parameters.Add("workspace_id", projectKeyOrId)
+	parameters.Add("include", "assignees")
+	Url.RawQuery = parameters.Encode()
+	return mavenlink.forEachPage(Url, perPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var storiesResponse *communicator.MavenlinkStoriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &storiesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if storiesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from stories endpoint")
+		}
+		for _, result := range storiesResponse.Results {
+			story, ok := storiesResponse.Stories[result.Id]
+			if !ok {
+				continue
+			}
+			task := StoryToTask(story)
+			for _, assignee := range story.AssigneeIds {
+				if user, ok := storiesResponse.Users[assignee]; ok {
+					task.User = MavenlinkUserToUser(user)
+				}
+			}
+			if emitErr := emit(task); emitErr != nil {
+				return nil, emitErr
+			}
+		}
+		return storiesResponse.Meta, nil
+	})
+}
+
+// StreamTimeEntries is used to retrieve time entries from Mavenlink page by page, handing
+// each entry to the callback(param: emit) as soon as its page arrives. Time entries of
+// every workspace are retrieved when no workspace(param: projectKeyOrId) is provided
+func (mavenlink *MavenlinkApi) StreamTimeEntries(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Timeentry) error) error {
+	env := mavenlink.config()
+
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["time_entries"]
+	parameters := url.Values{}
+	if len(projectKeyOrId) > 0 {
+		parameters.Add("workspace_id", projectKeyOrId)
+	}
+	parameters.Add("include", "user")
+	Url.RawQuery = parameters.Encode()
+	return mavenlink.forEachPage(Url, perPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var timeentriesResponse *communicator.MavenlinkTimeEntriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &timeentriesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if timeentriesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from time entries endpoint")
+		}
+		for _, result := range timeentriesResponse.Results {
+			timeentry, ok := timeentriesResponse.TimeEntries[result.Id]
+			if !ok {
+				continue
+			}
+			timeentryWithUser := TimeEntryToTimeentry(timeentry)
+			if user, ok := timeentriesResponse.Users[timeentry.UserId]; ok {
+				timeentryWithUser.User = MavenlinkUserToUser(user)
+			}
+			if emitErr := emit(timeentryWithUser); emitErr != nil {
+				return nil, emitErr
+			}
+		}
+		return timeentriesResponse.Meta, nil
+	})
+}
+
+// forEachPage requests the endpoint(param: Url) one page at a time, passing the URL
+// of each page to the callback(param: fetch) until the last page reported by
+// Mavenlink has been retrieved or the callback fails
+func (mavenlink *MavenlinkApi) forEachPage(Url *url.URL, perPage int32,
+	fetch func(pageUrl string) (*communicator.MavenlinkResponseMeta, error)) error {
+
+	if perPage < 1 || perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	parameters := Url.Query()
+	parameters.Set("per_page", fmt.Sprint(perPage))
+	for page := 1; ; page++ {
+		parameters.Set("page", fmt.Sprint(page))
+		Url.RawQuery = parameters.Encode()
+		meta, fetchErr := fetch(Url.String())
+		if fetchErr != nil {
+			LOG.Debug("Mavenlink API error", LOG.Fields{"url": Url.String(), "error": fetchErr})
+			return fetchErr
+		}
+		if meta == nil || int32(page) >= meta.PageCount {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/url"
+	"time"
+)
+
+// GetWorkspacesUpdatedAfter is used to retrieve page by page the workspaces updated in Mavenlink
+// after the provided time(param: updatedAfter), handing each page to the callback(param: emit).
+// A zero time retrieves all of them
+func (mavenlink *MavenlinkApi) GetWorkspacesUpdatedAfter(updatedAfter time.Time,
+	emit func([]*communicator.MavenlinkWorkspace) error) error {
+	env := mavenlink.config()
+
+	Url, UrlErr := mavenlink.updatedAfterUrl(endpoint["workspaces"], updatedAfter, "")
+	if UrlErr != nil {
+		return UrlErr
+	}
+	return mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var workspacesResponse *communicator.MavenlinkWorkspacesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &workspacesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if workspacesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from workspaces endpoint")
+		}
+		var workspaces []*communicator.MavenlinkWorkspace
+		for _, result := range workspacesResponse.Results {
+			if workspace, ok := workspacesResponse.Workspaces[result.Id]; ok {
+				workspaces = append(workspaces, workspace)
+			}
+		}
+		if emitErr := emit(workspaces); emitErr != nil {
+			return nil, emitErr
+		}
+		return workspacesResponse.Meta, nil
+	})
+}
+
+// GetStoriesUpdatedAfter is used to retrieve page by page the stories of every workspace updated in
+// Mavenlink after the provided time(param: updatedAfter), handing each page to the callback(param: emit).
+// A zero time retrieves all of them
+func (mavenlink *MavenlinkApi) GetStoriesUpdatedAfter(updatedAfter time.Time,
+	emit func([]*communicator.MavenlinkStory) error) error {
+	env := mavenlink.config()
+
+	Url, UrlErr := mavenlink.updatedAfterUrl(endpoint["stories"], updatedAfter, "assignees")
+	if UrlErr != nil {
+		return UrlErr
+	}
+	return mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var storiesResponse *communicator.MavenlinkStoriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &storiesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if storiesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from stories endpoint")
+		}
+		var stories []*communicator.MavenlinkStory
+		for _, result := range storiesResponse.Results {
+			if story, ok := storiesResponse.Stories[result.Id]; ok {
+				stories = append(stories, story)
+			}
+		}
+		if emitErr := emit(stories); emitErr != nil {
+			return nil, emitErr
+		}
+		return storiesResponse.Meta, nil
+	})
+}
+
+// GetUsersUpdatedAfter is used to retrieve page by page the users of the account updated in Mavenlink
+// after the provided time(param: updatedAfter), handing each page to the callback(param: emit).
+// A zero time retrieves all of them
+func (mavenlink *MavenlinkApi) GetUsersUpdatedAfter(updatedAfter time.Time,
+	emit func([]*communicator.MavenlinkUser) error) error {
+	env := mavenlink.config()
+
+	Url, UrlErr := mavenlink.updatedAfterUrl(endpoint["users"], updatedAfter, "")
+	if UrlErr != nil {
+		return UrlErr
+	}
+	return mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var usersResponse *communicator.MavenlinkUsersResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &usersResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if usersResponse == nil {
+			return nil, errors.New("Failed to retrieve response from users endpoint")
+		}
+		var users []*communicator.MavenlinkUser
+		for _, result := range usersResponse.Results {
+			if user, ok := usersResponse.Users[result.Id]; ok {
+				users = append(users, user)
+			}
+		}
+		if emitErr := emit(users); emitErr != nil {
+			return nil, emitErr
+		}
+		return usersResponse.Meta, nil
+	})
+}
+
+// GetTimeEntriesUpdatedAfter is used to retrieve page by page the time entries of every workspace updated in
+// Mavenlink after the provided time(param: updatedAfter), handing each page to the callback(param: emit).
+// A zero time retrieves all of them
+func (mavenlink *MavenlinkApi) GetTimeEntriesUpdatedAfter(updatedAfter time.Time,
+	emit func([]*communicator.MavenlinkTimeentry) error) error {
+	env := mavenlink.config()
+
+	Url, UrlErr := mavenlink.updatedAfterUrl(endpoint["time_entries"], updatedAfter, "")
+	if UrlErr != nil {
+		return UrlErr
+	}
+	return mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var timeentriesResponse *communicator.MavenlinkTimeEntriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &timeentriesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if timeentriesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from time entries endpoint")
+		}
+		var timeentries []*communicator.MavenlinkTimeentry
+		for _, result := range timeentriesResponse.Results {
+			if timeentry, ok := timeentriesResponse.TimeEntries[result.Id]; ok {
+				timeentries = append(timeentries, timeentry)
+			}
+		}
+		if emitErr := emit(timeentries); emitErr != nil {
+			return nil, emitErr
+		}
+		return timeentriesResponse.Meta, nil
+	})
+}
+
+// GetStoryDependenciesUpdatedAfter is used to retrieve page by page the story dependencies of every
+// workspace updated in Mavenlink after the provided time(param: updatedAfter), handing each page to
+// the callback(param: emit). A zero time retrieves all of them
+func (mavenlink *MavenlinkApi) GetStoryDependenciesUpdatedAfter(updatedAfter time.Time,
+	emit func([]*communicator.MavenlinkStoryDependency) error) error {
+	env := mavenlink.config()
+
+	Url, UrlErr := mavenlink.updatedAfterUrl(endpoint["story_dependencies"], updatedAfter, "")
+	if UrlErr != nil {
+		return UrlErr
+	}
+	return mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var dependenciesResponse *communicator.MavenlinkStoryDependenciesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &dependenciesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if dependenciesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from story dependencies endpoint")
+		}
+		var dependencies []*communicator.MavenlinkStoryDependency
+		for _, result := range dependenciesResponse.Results {
+			if dependency, ok := dependenciesResponse.StoryDependencies[result.Id]; ok {
+				dependencies = append(dependencies, dependency)
+			}
+		}
+		if emitErr := emit(dependencies); emitErr != nil {
+			return nil, emitErr
+		}
+		return dependenciesResponse.Meta, nil
+	})
+}
+
+// updatedAfterUrl builds the URL of the endpoint(param: path) filtered on the
+// update time(param: updatedAfter) when provided
+func (mavenlink *MavenlinkApi) updatedAfterUrl(path string, updatedAfter time.Time, include string) (*url.URL, error) {
+	env := mavenlink.config()
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return nil, errors.New("Failed to parse environment URL")
+	}
+	Url.Path += path
+	parameters := url.Values{}
+	if !updatedAfter.IsZero() {
+		parameters.Add("updated_after", updatedAfter.UTC().Format(time.RFC3339))
+	}
+	if len(include) > 0 {
+		parameters.Add("include", include)
+	}
+	Url.RawQuery = parameters.Encode()
+	return Url, nil
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// GetTaskTreeFromProjectId is used to retrieve every story of a workspace in
+// Mavenlink page by page with its assignees and nest them under their parent
+// stories. The tree has no fixed depth. When requested(param: includeLoggedTime)
+// the logged minutes of every story are retrieved and rolled up to its parents
+func (mavenlink *MavenlinkApi) GetTaskTreeFromProjectId(projectKeyOrId string,
+	includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+	env := mavenlink.config()
+
+	var tree []*communicator.TaskNode
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return tree, errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["stories"]
+	parameters := url.Values{}
+	parameters.Add("workspace_id", projectKeyOrId)
+	parameters.Add("include", "assignees")
+	Url.RawQuery = parameters.Encode()
+	var stories []*communicator.MavenlinkStory
+	// the assignees are included with the stories instead of being requested once per story
+	users := make(map[string]*communicator.User)
+	pageErr := mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var storiesResponse *communicator.MavenlinkStoriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &storiesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if storiesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from stories endpoint")
+		}
+		for _, result := range storiesResponse.Results {
+			if story, ok := storiesResponse.Stories[result.Id]; ok {
+				stories = append(stories, story)
+			}
+		}
+		for id, user := range storiesResponse.Users {
+			users[id] = MavenlinkUserToUser(user)
+		}
+		return storiesResponse.Meta, nil
+	})
+	if pageErr != nil {
+		return tree, pageErr
+	}
+
+	// sum up the minutes logged against each story
+	loggedMinutes := make(map[string]int32)
+	if includeLoggedTime {
+		timeentries, timeentriesErr := mavenlink.getTimeEntriesFromProjectId(projectKeyOrId)
+		if timeentriesErr != nil {
+			return tree, timeentriesErr
+		}
+		for _, timeentry := range timeentries {
+			loggedMinutes[timeentry.StoryId] += timeentry.TimeInMinutes
+		}
+	}
+	return BuildTaskTree(stories, users, loggedMinutes)
+}
+
+// BuildTaskTree nests the stories(param: stories) under their parent stories,
+// attaching the assignees found amongst the users(param: users) and the minutes
+// logged against each story(param: loggedMinutes) rolled up to its parents.
+// Stories whose parent is not in the list become roots of the tree. Stories
+// which are their own ancestors cannot be placed and are reported as an error
+func BuildTaskTree(stories []*communicator.MavenlinkStory, users map[string]*communicator.User,
+	loggedMinutes map[string]int32) ([]*communicator.TaskNode, error) {
+
+	var tree []*communicator.TaskNode
+	nodes := make(map[string]*communicator.TaskNode)
+	for _, story := range stories {
+		node := new(communicator.TaskNode)
+		node.Task = StoryToTask(story)
+		for _, assignee := range story.AssigneeIds {
+			if user, ok := users[assignee]; ok {
+				node.Assignees = append(node.Assignees, user)
+				node.Task.User = user
+			}
+		}
+		node.LoggedMinutes = loggedMinutes[story.Id]
+		nodes[story.Id] = node
+	}
+	for _, node := range nodes {
+		parent, ok := nodes[node.Task.ParentId]
+		if len(node.Task.ParentId) > 0 && ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			tree = append(tree, node)
+		}
+	}
+	if cycleErr := cycleError(tree, nodes); cycleErr != nil {
+		return nil, cycleErr
+	}
+	sortTaskNodes(tree)
+	for _, node := range tree {
+		rollUpLoggedMinutes(node)
+	}
+	return tree, nil
+}
+
+// getTimeEntriesFromProjectId is used to retrieve page by page all the time entries of a workspace in Mavenlink
+func (mavenlink *MavenlinkApi) getTimeEntriesFromProjectId(projectKeyOrId string) ([]*communicator.MavenlinkTimeentry, error) {
+	env := mavenlink.config()
+	var timeentries []*communicator.MavenlinkTimeentry
+	var Url *url.URL
+	Url, UrlErr := url.Parse(env.Url)
+	if UrlErr != nil {
+		return timeentries, errors.New("Failed to parse environment URL")
+	}
+	Url.Path += endpoint["time_entries"]
+	parameters := url.Values{}
+	parameters.Add("workspace_id", projectKeyOrId)
+	Url.RawQuery = parameters.Encode()
+	pageErr := mavenlink.forEachPage(Url, maxPerPage, func(pageUrl string) (*communicator.MavenlinkResponseMeta, error) {
+		var timeentriesResponse *communicator.MavenlinkTimeEntriesResponse
+		apiErr := InsecureRequestContext(mavenlink.context(), pageUrl, "GET", nil, env.Token, &timeentriesResponse)
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		if timeentriesResponse == nil {
+			return nil, errors.New("Failed to retrieve response from time entries endpoint")
+		}
+		for _, result := range timeentriesResponse.Results {
+			if timeentry, ok := timeentriesResponse.TimeEntries[result.Id]; ok {
+				timeentries = append(timeentries, timeentry)
+			}
+		}
+		return timeentriesResponse.Meta, nil
+	})
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	return timeentries, nil
+}
+
+// cycleError reports the stories of the nodes(param: nodes) left out of the tree(param: tree),
+// which are the stories of a parent cycle and their descendants, if any
+func cycleError(tree []*communicator.TaskNode, nodes map[string]*communicator.TaskNode) error {
+	placed := make(map[string]bool)
+	var place func([]*communicator.TaskNode)
+	place = func(placedNodes []*communicator.TaskNode) {
+		for _, node := range placedNodes {
+			placed[node.Task.Id] = true
+			place(node.Children)
+		}
+	}
+	place(tree)
+	var ids []string
+	for id := range nodes {
+		if !placed[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 1 {
+		return nil
+	}
+	sort.Strings(ids)
+	return errors.Errorf("Stories %s descend from a parent cycle and cannot be placed in the tree", strings.Join(ids, ", "))
+}
+
+// sortTaskNodes orders sibling nodes by start date, at every level of the tree
+func sortTaskNodes(nodes []*communicator.TaskNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Task.StartDate != nodes[j].Task.StartDate {
+			return nodes[i].Task.StartDate < nodes[j].Task.StartDate
+		}
+		return nodes[i].Task.Id < nodes[j].Task.Id
+	})
+	for _, node := range nodes {
+		sortTaskNodes(node.Children)
+	}
+}
+
+// rollUpLoggedMinutes sets the total logged minutes of a node to the minutes
+// logged against it and all of its descendants
+func rollUpLoggedMinutes(node *communicator.TaskNode) int32 {
+	node.TotalLoggedMinutes = node.LoggedMinutes
+	for _, child := range node.Children {
+		node.TotalLoggedMinutes += rollUpLoggedMinutes(child)
+	}
+	return node.TotalLoggedMinutes
+}
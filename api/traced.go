@@ -0,0 +1,185 @@
+package api
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-communicator/trace"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+// tracedMavenlink wraps a MavenlinkApiInterface, recording every call as a span
+// of the trace carried by its context. The wrapped API is bound to the context
+// of the span so that the requests it makes are recorded as its children
+type tracedMavenlink struct {
+	mavenlink MavenlinkApiInterface
+	ctx       context.Context
+}
+
+// Traced returns the API(param: mavenlink) recording its calls as spans of the
+// trace carried by the context(param: ctx), or continuing the one the caller
+// passed in the metadata of the request
+func Traced(mavenlink MavenlinkApiInterface, ctx context.Context) MavenlinkApiInterface {
+	return &tracedMavenlink{mavenlink: mavenlink, ctx: trace.Extract(ctx)}
+}
+
+// start starts the span of the method(param: method) and returns the wrapped API bound to it
+func (traced *tracedMavenlink) start(method string, attributes ...string) (MavenlinkApiInterface, *trace.Span) {
+	ctx, span := trace.Start(traced.ctx, "MavenlinkApi."+method, trace.Internal)
+	for index := 0; index+1 < len(attributes); index += 2 {
+		span.SetAttribute(attributes[index], attributes[index+1])
+	}
+	return WithContext(traced.mavenlink, ctx), span
+}
+
+// WithContext returns the API recording its calls as spans of the trace carried by the context(param: ctx)
+func (traced *tracedMavenlink) WithContext(ctx context.Context) MavenlinkApiInterface {
+	return Traced(traced.mavenlink, ctx)
+}
+
+func (traced *tracedMavenlink) SetEnv(configuration *communicator.EnvironmentConfiguration) error {
+	return traced.mavenlink.SetEnv(configuration)
+}
+
+func (traced *tracedMavenlink) FormatErrors(err error, message string) *communicator.Error {
+	return traced.mavenlink.FormatErrors(err, message)
+}
+
+func (traced *tracedMavenlink) GetProjects() ([]*communicator.Project, error) {
+	mavenlink, span := traced.start("GetProjects")
+	projects, err := mavenlink.GetProjects()
+	span.End(err)
+	return projects, err
+}
+
+func (traced *tracedMavenlink) GetProject(keyOrId string) (*communicator.Project, error) {
+	mavenlink, span := traced.start("GetProject", "mavenlink.project", keyOrId)
+	project, err := mavenlink.GetProject(keyOrId)
+	span.End(err)
+	return project, err
+}
+
+func (traced *tracedMavenlink) GetTasksFromProjectId(keyOrId string) ([]*communicator.Task, error) {
+	mavenlink, span := traced.start("GetTasksFromProjectId", "mavenlink.project", keyOrId)
+	tasks, err := mavenlink.GetTasksFromProjectId(keyOrId)
+	span.End(err)
+	return tasks, err
+}
+
+func (traced *tracedMavenlink) GetSubTasksFromProjectId(workspace string, task string) ([]*communicator.Task, error) {
+	mavenlink, span := traced.start("GetSubTasksFromProjectId", "mavenlink.project", workspace, "mavenlink.task", task)
+	tasks, err := mavenlink.GetSubTasksFromProjectId(workspace, task)
+	span.End(err)
+	return tasks, err
+}
+
+func (traced *tracedMavenlink) GetIssueTasksFromProjectId(keyOrId string, subTask string) ([]*communicator.Task, error) {
+	mavenlink, span := traced.start("GetIssueTasksFromProjectId", "mavenlink.project", keyOrId, "mavenlink.task", subTask)
+	tasks, err := mavenlink.GetIssueTasksFromProjectId(keyOrId, subTask)
+	span.End(err)
+	return tasks, err
+}
+
+func (traced *tracedMavenlink) GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string,
+	issueTaskKeyOrId string) ([]*communicator.Timeentry, error) {
+	mavenlink, span := traced.start("GetTimeEntriesFromProjectIdAndIssueTaskId",
+		"mavenlink.project", projectKeyOrId, "mavenlink.task", issueTaskKeyOrId)
+	timeentries, err := mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId, issueTaskKeyOrId)
+	span.End(err)
+	return timeentries, err
+}
+
+func (traced *tracedMavenlink) GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error) {
+	mavenlink, span := traced.start("GetUsersFromProjectId", "mavenlink.project", projectKeyOrId)
+	users, err := mavenlink.GetUsersFromProjectId(projectKeyOrId)
+	span.End(err)
+	return users, err
+}
+
+func (traced *tracedMavenlink) GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error) {
+	mavenlink, span := traced.start("GetUserFromProjectId", "mavenlink.project", projectKeyOrId, "mavenlink.user", userId)
+	user, err := mavenlink.GetUserFromProjectId(projectKeyOrId, userId)
+	span.End(err)
+	return user, err
+}
+
+func (traced *tracedMavenlink) GetUserById(userId string) (*communicator.User, error) {
+	mavenlink, span := traced.start("GetUserById", "mavenlink.user", userId)
+	user, err := mavenlink.GetUserById(userId)
+	span.End(err)
+	return user, err
+}
+
+func (traced *tracedMavenlink) GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error) {
+	mavenlink, span := traced.start("GetTaskDependenciesFromProjectId", "mavenlink.project", projectKeyOrId)
+	dependencies, err := mavenlink.GetTaskDependenciesFromProjectId(projectKeyOrId)
+	span.End(err)
+	return dependencies, err
+}
+
+func (traced *tracedMavenlink) GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error) {
+	mavenlink, span := traced.start("GetCriticalPathFromProjectId", "mavenlink.project", projectKeyOrId)
+	path, err := mavenlink.GetCriticalPathFromProjectId(projectKeyOrId)
+	span.End(err)
+	return path, err
+}
+
+func (traced *tracedMavenlink) GetTaskTreeFromProjectId(projectKeyOrId string, includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+	mavenlink, span := traced.start("GetTaskTreeFromProjectId", "mavenlink.project", projectKeyOrId)
+	span.SetAttribute("mavenlink.include_logged_time", includeLoggedTime)
+	tree, err := mavenlink.GetTaskTreeFromProjectId(projectKeyOrId, includeLoggedTime)
+	span.End(err)
+	return tree, err
+}
+
+func (traced *tracedMavenlink) StreamProjects(perPage int32, emit func(*communicator.Project) error) error {
+	mavenlink, span := traced.start("StreamProjects")
+	span.SetAttribute("mavenlink.per_page", perPage)
+	err := mavenlink.StreamProjects(perPage, emit)
+	span.End(err)
+	return err
+}
+
+func (traced *tracedMavenlink) StreamTasks(projectKeyOrId string, perPage int32, emit func(*communicator.Task) error) error {
+	mavenlink, span := traced.start("StreamTasks", "mavenlink.project", projectKeyOrId)
+	span.SetAttribute("mavenlink.per_page", perPage)
+	err := mavenlink.StreamTasks(projectKeyOrId, perPage, emit)
+	span.End(err)
+	return err
+}
+
+func (traced *tracedMavenlink) StreamTimeEntries(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Timeentry) error) error {
+	mavenlink, span := traced.start("StreamTimeEntries", "mavenlink.project", projectKeyOrId)
+	span.SetAttribute("mavenlink.per_page", perPage)
+	err := mavenlink.StreamTimeEntries(projectKeyOrId, perPage, emit)
+	span.End(err)
+	return err
+}
+
+func (traced *tracedMavenlink) GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error) {
+	mavenlink, span := traced.start("GetProjectsByIds", "mavenlink.ids", strings.Join(ids, ","))
+	found, missing, err := mavenlink.GetProjectsByIds(ids)
+	span.End(err)
+	return found, missing, err
+}
+
+func (traced *tracedMavenlink) GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error) {
+	mavenlink, span := traced.start("GetTasksByIds", "mavenlink.ids", strings.Join(ids, ","))
+	found, missing, err := mavenlink.GetTasksByIds(ids)
+	span.End(err)
+	return found, missing, err
+}
+
+func (traced *tracedMavenlink) GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error) {
+	mavenlink, span := traced.start("GetUsersByIds", "mavenlink.ids", strings.Join(ids, ","))
+	found, missing, err := mavenlink.GetUsersByIds(ids)
+	span.End(err)
+	return found, missing, err
+}
+
+func (traced *tracedMavenlink) GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error) {
+	mavenlink, span := traced.start("GetTimeEntriesByIds", "mavenlink.ids", strings.Join(ids, ","))
+	found, missing, err := mavenlink.GetTimeEntriesByIds(ids)
+	span.End(err)
+	return found, missing, err
+}
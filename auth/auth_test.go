@@ -0,0 +1,347 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/cache"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro/metadata"
+	"github.com/micro/go-micro/server"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "jwt-secret"
+
+// sign returns an HS256 JWT holding the claims(param: claims), signed with the secret(param: secret)
+func sign(claims map[string]interface{}, secret string) string {
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newTestAuthenticator(t *testing.T, policy string) *Authenticator {
+	configuration := &communicator.EnvironmentConfiguration{
+		AuthJwtSecret:   testSecret,
+		AuthJwtIssuer:   "issuer",
+		AuthJwtAudience: "mavenlink-communicator",
+		AuthApiKeys:     map[string]string{"reporting": "reporting-key", "admin": "admin-key"},
+	}
+	if len(policy) > 0 {
+		dir, err := ioutil.TempDir("", "auth")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		configuration.AuthPolicyPath = filepath.Join(dir, "policy.json")
+		if err := ioutil.WriteFile(configuration.AuthPolicyPath, []byte(policy), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	authenticator, err := New(configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authenticator
+}
+
+func contextWith(key string, value string) context.Context {
+	return metadata.NewContext(context.Background(), metadata.Metadata{key: value})
+}
+
+func TestAuthenticateJWTs(t *testing.T) {
+	authenticator := newTestAuthenticator(t, "")
+	now := time.Now().Unix()
+	valid := map[string]interface{}{"sub": "reporting", "iss": "issuer", "aud": "mavenlink-communicator", "exp": now + 60}
+	with := func(key string, value interface{}) map[string]interface{} {
+		claims := make(map[string]interface{})
+		for name, claim := range valid {
+			claims[name] = claim
+		}
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+	tests := []struct {
+		name  string
+		token string
+		ok    bool
+	}{
+		{"valid", sign(valid, testSecret), true},
+		{"audience among several", sign(with("aud", []string{"other", "mavenlink-communicator"}), testSecret), true},
+		{"expired", sign(with("exp", now-1), testSecret), false},
+		{"not valid yet", sign(with("nbf", now+60), testSecret), false},
+		{"another issuer", sign(with("iss", "another"), testSecret), false},
+		{"another audience", sign(with("aud", "another"), testSecret), false},
+		{"other audiences", sign(with("aud", []string{"other", "another"}), testSecret), false},
+		{"without subject", sign(with("sub", nil), testSecret), false},
+		{"another secret", sign(valid, "another-secret"), false},
+		{"malformed", "not.a-jwt", false},
+	}
+	for _, test := range tests {
+		caller, err := authenticator.authenticate(contextWith(AuthorizationKey, "Bearer "+test.token))
+		if test.ok && (err != nil || caller.Name != "reporting") {
+			t.Errorf("%s: expected the caller to be authenticated, got %v", test.name, err)
+		}
+		if !test.ok && errors.Cause(err) != ErrInvalidCredentials {
+			t.Errorf("%s: expected the credentials to be refused, got %v", test.name, err)
+		}
+	}
+}
+
+func TestAuthenticateAPIKeys(t *testing.T) {
+	authenticator := newTestAuthenticator(t, "")
+	caller, err := authenticator.authenticate(contextWith("x-api-key", "admin-key"))
+	if err != nil || caller.Name != "admin" {
+		t.Errorf("expected the key to authenticate admin, got %v %v", caller, err)
+	}
+	if _, err := authenticator.authenticate(contextWith(APIKeyKey, "unknown-key")); err != ErrInvalidCredentials {
+		t.Errorf("expected an unknown key to be refused, got %v", err)
+	}
+	if _, err := authenticator.authenticate(context.Background()); err != ErrMissingCredentials {
+		t.Errorf("expected a request without credentials to be refused, got %v", err)
+	}
+}
+
+func TestGrantOf(t *testing.T) {
+	policy := &Policy{Rules: []Rule{
+		{Callers: []string{"reporting"}, RPCs: []string{"MavenlinkCommunicator.GetAllProjects"}, Workspaces: []string{"1001"}},
+		{Callers: []string{"reporting", "billing"}, RPCs: []string{"GetTaskTree"}, Workspaces: []string{"1002"}},
+		{Callers: []string{"*"}, RPCs: []string{"Health"}},
+		{Callers: []string{"admin"}, RPCs: []string{"*"}, Workspaces: []string{"*"}},
+	}}
+	reporting := policy.grantOf(&Caller{Name: "reporting"})
+	for _, rpc := range []string{"GetAllProjects", "MavenlinkCommunicator.GetTaskTree", "Health"} {
+		if !reporting.canCall(rpc) {
+			t.Errorf("expected reporting to be granted %s", rpc)
+		}
+	}
+	if reporting.canCall("GetTasksByProjectId") || reporting.allWorkspaces {
+		t.Error("expected reporting to be granted the RPCs of its rules only")
+	}
+	if !reporting.canSee("1001") || !reporting.canSee("1002") || reporting.canSee("1003") {
+		t.Error("expected reporting to see the workspaces of its rules only")
+	}
+	admin := policy.grantOf(&Caller{Name: "admin"})
+	if !admin.canCall("GetTasksByProjectId") || !admin.canSee("1003") {
+		t.Error("expected admin to be granted everything")
+	}
+	unknown := policy.grantOf(&Caller{Name: "unknown"})
+	if unknown.canCall("GetAllProjects") || !unknown.canCall("Health") || unknown.canSee("1001") {
+		t.Error("expected a caller named by no rule to be granted the wildcard rules only")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	granted := &grant{workspaces: map[string]bool{"1001": true}}
+	response := &communicator.Response{
+		Projects:        []*communicator.Project{{Id: "1001"}, {Id: "1002"}},
+		Tasks:           []*communicator.Task{{Id: "3001", WorkspaceId: "1001"}, {Id: "3101", WorkspaceId: "1002"}},
+		Timeentries:     []*communicator.Timeentry{{Id: "4001", WorkspaceId: "1002"}},
+		ProjectsById:    map[string]*communicator.Project{"1001": {Id: "1001"}, "1002": {Id: "1002"}},
+		TasksById:       map[string]*communicator.Task{"3101": {Id: "3101", WorkspaceId: "1002"}},
+		TimeentriesById: map[string]*communicator.Timeentry{"4001": {Id: "4001", WorkspaceId: "1001"}},
+	}
+	granted.filter(response)
+	if len(response.Projects) != 1 || response.Projects[0].Id != "1001" {
+		t.Errorf("unexpected projects %v", response.Projects)
+	}
+	if len(response.Tasks) != 1 || response.Tasks[0].Id != "3001" || len(response.Timeentries) != 0 {
+		t.Errorf("unexpected tasks %v and time entries %v", response.Tasks, response.Timeentries)
+	}
+	if len(response.ProjectsById) != 1 || len(response.TasksById) != 0 || len(response.TimeentriesById) != 1 {
+		t.Errorf("unexpected resources by ID %v %v %v", response.ProjectsById, response.TasksById, response.TimeentriesById)
+	}
+	sort.Strings(response.NotFound)
+	if len(response.NotFound) != 2 || response.NotFound[0] != "1002" || response.NotFound[1] != "3101" {
+		t.Errorf("expected the hidden resources to be reported as not found, got %v", response.NotFound)
+	}
+}
+
+// fakeStream records the messages sent and receives the request(param: request)
+type fakeStream struct {
+	server.Stream
+	request *communicator.Request
+	sent    []interface{}
+}
+
+func (stream *fakeStream) Recv(message interface{}) error {
+	*message.(*communicator.Request) = *stream.request
+	return nil
+}
+
+func (stream *fakeStream) Send(message interface{}) error {
+	stream.sent = append(stream.sent, message)
+	return nil
+}
+
+func TestVisibleStream(t *testing.T) {
+	granted := &grant{workspaces: map[string]bool{"1001": true}}
+	stream := &fakeStream{request: &communicator.Request{}}
+	visible := &visibleStream{Stream: stream, service: "mavenlink-communicator", grant: granted}
+	if err := visible.Recv(&communicator.Request{}); err != nil {
+		t.Errorf("expected a stream over every workspace to be received, got %v", err)
+	}
+	visible.Send(&communicator.Project{Id: "1001"})
+	visible.Send(&communicator.Project{Id: "1002"})
+	visible.Send(&communicator.Task{Id: "3101", WorkspaceId: "1002"})
+	visible.Send(&communicator.Timeentry{Id: "4001", WorkspaceId: "1001"})
+	if len(stream.sent) != 2 {
+		t.Errorf("expected the resources of hidden workspaces to be skipped, got %v", stream.sent)
+	}
+	stream.request = &communicator.Request{Workspace: "1002"}
+	if err := visible.Recv(&communicator.Request{}); err == nil {
+		t.Error("expected a stream over a hidden workspace to be refused")
+	}
+}
+
+// fakeRequest is a unary request for the method(param: method) of the service
+type fakeRequest struct {
+	method  string
+	request interface{}
+}
+
+func (req *fakeRequest) Service() string      { return "mavenlink-communicator" }
+func (req *fakeRequest) Method() string       { return req.method }
+func (req *fakeRequest) ContentType() string  { return "application/protobuf" }
+func (req *fakeRequest) Request() interface{} { return req.request }
+func (req *fakeRequest) Stream() bool         { return false }
+
+func TestWrapper(t *testing.T) {
+	authenticator := newTestAuthenticator(t, `{"rules": [
+		{"callers": ["reporting"], "rpcs": ["GetAllProjects", "GetProjectById"], "workspaces": ["1001"]}
+	]}`)
+	handler := authenticator.Wrapper(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		if caller, ok := CallerFrom(ctx); !ok || caller.Name != "reporting" {
+			t.Errorf("expected the caller in the context, got %v", caller)
+		}
+		rsp.(*communicator.Response).Projects = []*communicator.Project{{Id: "1001"}, {Id: "1002"}}
+		return nil
+	})
+	ctx := contextWith(APIKeyKey, "reporting-key")
+	res := &communicator.Response{}
+	if err := handler(ctx, &fakeRequest{method: "MavenlinkCommunicator.GetAllProjects", request: &communicator.Request{}}, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Projects) != 1 {
+		t.Errorf("expected the projects of hidden workspaces to be removed, got %v", res.Projects)
+	}
+	if err := handler(ctx, &fakeRequest{method: "MavenlinkCommunicator.GetTaskTree", request: &communicator.Request{}}, res); err == nil {
+		t.Error("expected an RPC the policy does not grant to be refused")
+	}
+	if err := handler(ctx, &fakeRequest{method: "MavenlinkCommunicator.GetProjectById",
+		request: &communicator.Request{Workspace: "1002"}}, res); err == nil {
+		t.Error("expected a hidden workspace to be refused")
+	}
+	if err := handler(context.Background(), &fakeRequest{method: "MavenlinkCommunicator.GetAllProjects"}, res); err == nil {
+		t.Error("expected a request without credentials to be refused")
+	}
+}
+
+func TestWrapperLeavesTheCacheIntact(t *testing.T) {
+	fake := mavenlinktest.NewServer()
+	defer fake.Close()
+	env := fake.Config()
+	mavenlink := new(API.MavenlinkApi)
+	if err := mavenlink.SetEnv(env); err != nil {
+		t.Fatal(err)
+	}
+	cached := cache.New(mavenlink, env)
+	authenticator := newTestAuthenticator(t, `{"rules": [
+		{"callers": ["reporting"], "rpcs": ["GetTasksByIds"], "workspaces": ["1001"]},
+		{"callers": ["admin"], "rpcs": ["*"], "workspaces": ["*"]}
+	]}`)
+	// The handler hands the cached map and IDs out as they are
+	handler := authenticator.Wrapper(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		tasks, notFound, err := cached.GetTasksByIds(req.Request().(*communicator.Request).Ids)
+		rsp.(*communicator.Response).TasksById = tasks
+		rsp.(*communicator.Response).NotFound = notFound
+		return err
+	})
+	call := func(key string) *communicator.Response {
+		res := &communicator.Response{}
+		req := &fakeRequest{method: "MavenlinkCommunicator.GetTasksByIds",
+			request: &communicator.Request{Ids: []string{"3001", "3101", "9999"}}}
+		if err := handler(contextWith(APIKeyKey, key), req, res); err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+
+	restricted := call("reporting-key")
+	sort.Strings(restricted.NotFound)
+	if len(restricted.TasksById) != 1 || !reflect.DeepEqual(restricted.NotFound, []string{"3101", "9999"}) {
+		t.Errorf("expected the task of the hidden workspace to be reported as not found, got %v and %v",
+			restricted.TasksById, restricted.NotFound)
+	}
+	unrestricted := call("admin-key")
+	if len(unrestricted.TasksById) != 2 || !reflect.DeepEqual(unrestricted.NotFound, []string{"9999"}) {
+		t.Errorf("expected the cached tasks to be left untouched, got %v and %v", unrestricted.TasksById, unrestricted.NotFound)
+	}
+	if requests := fake.Requests(mavenlinktest.Stories); len(requests) != 1 {
+		t.Errorf("expected the second caller to be served by the cache, got %d requests", len(requests))
+	}
+
+	// Callers filtering the same cached map at once do not write to it
+	var group sync.WaitGroup
+	for index := 0; index < 8; index++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			call("reporting-key")
+		}()
+	}
+	group.Wait()
+	if unrestricted = call("admin-key"); len(unrestricted.TasksById) != 2 {
+		t.Errorf("expected the cached tasks to be left untouched, got %v", unrestricted.TasksById)
+	}
+}
+
+func TestWrapperRestrictsUserLookups(t *testing.T) {
+	authenticator := newTestAuthenticator(t, `{"rules": [
+		{"callers": ["reporting"], "rpcs": ["GetUser", "GetUsers", "GetUsersByIds"], "workspaces": ["1001"]},
+		{"callers": ["admin"], "rpcs": ["*"], "workspaces": ["*"]}
+	]}`)
+	handler := authenticator.Wrapper(func(ctx context.Context, req server.Request, rsp interface{}) error {
+		return nil
+	})
+	cases := []struct {
+		key     string
+		method  string
+		request *communicator.Request
+		allowed bool
+	}{
+		{"reporting-key", "GetUsersByIds", &communicator.Request{Ids: []string{"2001"}}, false},
+		{"reporting-key", "GetUser", &communicator.Request{KeyOrId: "2001"}, false},
+		{"reporting-key", "GetUsers", &communicator.Request{}, false},
+		{"reporting-key", "GetUser", &communicator.Request{Workspace: "1001", KeyOrId: "2001"}, true},
+		{"reporting-key", "GetUsers", &communicator.Request{Workspace: "1001"}, true},
+		{"admin-key", "GetUsersByIds", &communicator.Request{Ids: []string{"2001"}}, true},
+		{"admin-key", "GetUser", &communicator.Request{KeyOrId: "2001"}, true},
+	}
+	for _, test := range cases {
+		req := &fakeRequest{method: "MavenlinkCommunicator." + test.method, request: test.request}
+		err := handler(contextWith(APIKeyKey, test.key), req, &communicator.Response{})
+		if (err == nil) != test.allowed {
+			t.Errorf("%s %s %v: expected allowed to be %t, got %v", test.key, test.method, test.request, test.allowed, err)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/micro/go-micro/metadata"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"strings"
+	"time"
+)
+
+// Metadata keys callers pass their credentials in, either a JWT as a bearer
+// token or an API key
+const (
+	AuthorizationKey = "Authorization"
+	APIKeyKey        = "X-Api-Key"
+)
+
+// Errors returned for requests that cannot be authenticated
+var (
+	ErrMissingCredentials = errors.New("Missing credentials")
+	ErrInvalidCredentials = errors.New("Invalid credentials")
+)
+
+// Caller is the authenticated identity of a request
+type Caller struct {
+	// Name is the subject of the JWT or the name the API key is configured under
+	Name string
+}
+
+// Key of the caller of a request in its context
+type callerKey struct{}
+
+// CallerFrom returns the caller authenticated for the context(param: ctx), if any
+func CallerFrom(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*Caller)
+	return caller, ok
+}
+
+// authenticate returns the caller whose credentials are carried by the metadata of the context(param: ctx)
+func (authenticator *Authenticator) authenticate(ctx context.Context) (*Caller, error) {
+	bearer, apiKey := credentials(ctx)
+	if len(apiKey) > 0 {
+		for name, key := range authenticator.apiKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+				return &Caller{Name: name}, nil
+			}
+		}
+		return nil, ErrInvalidCredentials
+	}
+	if len(bearer) > 0 {
+		if len(authenticator.jwtSecret) < 1 {
+			return nil, errors.Wrap(ErrInvalidCredentials, "JWTs are not accepted")
+		}
+		return authenticator.verifyJWT(bearer)
+	}
+	return nil, ErrMissingCredentials
+}
+
+// claims holds the registered claims of a JWT checked by the authenticator
+type claims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt int64           `json:"exp"`
+	NotBefore int64           `json:"nbf"`
+}
+
+// verifyJWT checks the HS256 signature and the claims of a token(param: token)
+func (authenticator *Authenticator) verifyJWT(token string) (*Caller, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.Wrap(ErrInvalidCredentials, "malformed JWT")
+	}
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil || header.Algorithm != "HS256" {
+		return nil, errors.Wrap(ErrInvalidCredentials, "unsupported JWT algorithm")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidCredentials, "malformed JWT signature")
+	}
+	mac := hmac.New(sha256.New, authenticator.jwtSecret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.Wrap(ErrInvalidCredentials, "invalid JWT signature")
+	}
+	var tokenClaims claims
+	if err := decodeSegment(parts[1], &tokenClaims); err != nil {
+		return nil, errors.Wrap(ErrInvalidCredentials, "malformed JWT claims")
+	}
+	now := time.Now().Unix()
+	if tokenClaims.ExpiresAt > 0 && now >= tokenClaims.ExpiresAt {
+		return nil, errors.Wrap(ErrInvalidCredentials, "expired JWT")
+	}
+	if tokenClaims.NotBefore > 0 && now < tokenClaims.NotBefore {
+		return nil, errors.Wrap(ErrInvalidCredentials, "JWT not valid yet")
+	}
+	if len(authenticator.issuer) > 0 && tokenClaims.Issuer != authenticator.issuer {
+		return nil, errors.Wrap(ErrInvalidCredentials, "unexpected JWT issuer")
+	}
+	if len(authenticator.audience) > 0 && !hasAudience(tokenClaims.Audience, authenticator.audience) {
+		return nil, errors.Wrap(ErrInvalidCredentials, "unexpected JWT audience")
+	}
+	if len(tokenClaims.Subject) < 1 {
+		return nil, errors.Wrap(ErrInvalidCredentials, "JWT without subject")
+	}
+	return &Caller{Name: tokenClaims.Subject}, nil
+}
+
+func decodeSegment(segment string, target interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, target)
+}
+
+// hasAudience tells whether the audience claim(param: claim), a string or a list of
+// strings, holds the audience(param: audience)
+func hasAudience(claim json.RawMessage, audience string) bool {
+	var single string
+	if json.Unmarshal(claim, &single) == nil {
+		return single == audience
+	}
+	var several []string
+	if json.Unmarshal(claim, &several) == nil {
+		for _, candidate := range several {
+			if candidate == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// credentials returns the bearer token and the API key found in the metadata of the context(param: ctx).
+// Transports differ in the case of the keys they carry so keys are matched case insensitively
+func credentials(ctx context.Context) (bearer string, apiKey string) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	for key, value := range md {
+		if strings.EqualFold(key, AuthorizationKey) {
+			if len(value) > 7 && strings.EqualFold(value[:7], "Bearer ") {
+				bearer = strings.TrimSpace(value[7:])
+			}
+		} else if strings.EqualFold(key, APIKeyKey) {
+			apiKey = value
+		}
+	}
+	return bearer, apiKey
+}
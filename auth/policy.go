@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"strings"
+)
+
+// Wildcard matching every caller, RPC or workspace in a rule
+const wildcard = "*"
+
+// Policy grants callers the RPCs they may call and the workspaces they may see.
+// Permissions are the union of the rules naming the caller, so a caller named
+// by no rule may call nothing. A policy is read from a JSON file like
+//
+//	{"rules": [
+//		{"callers": ["reporting"], "rpcs": ["GetAllProjects", "GetTaskTree"], "workspaces": ["1234"]},
+//		{"callers": ["admin"], "rpcs": ["*"], "workspaces": ["*"]}
+//	]}
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule grants its callers the RPCs and the workspaces it lists. RPCs are named
+// with or without the service's handler prefix, e.g. "MavenlinkCommunicator.GetTaskTree"
+type Rule struct {
+	Callers    []string `json:"callers"`
+	RPCs       []string `json:"rpcs"`
+	Workspaces []string `json:"workspaces"`
+}
+
+// grant is the set of permissions of a single caller
+type grant struct {
+	allRPCs       bool
+	rpcs          map[string]bool
+	allWorkspaces bool
+	workspaces    map[string]bool
+}
+
+// LoadPolicy reads the policy from the JSON file at the path(param: path)
+func LoadPolicy(path string) (*Policy, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the authorization policy")
+	}
+	policy := new(Policy)
+	if err := json.Unmarshal(raw, policy); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the authorization policy")
+	}
+	return policy, nil
+}
+
+// grantOf returns the permissions of the caller(param: caller)
+func (policy *Policy) grantOf(caller *Caller) *grant {
+	granted := &grant{rpcs: make(map[string]bool), workspaces: make(map[string]bool)}
+	for _, rule := range policy.Rules {
+		if !contains(rule.Callers, caller.Name) {
+			continue
+		}
+		for _, rpc := range rule.RPCs {
+			if rpc == wildcard {
+				granted.allRPCs = true
+			}
+			granted.rpcs[rpcName(rpc)] = true
+		}
+		for _, workspace := range rule.Workspaces {
+			if workspace == wildcard {
+				granted.allWorkspaces = true
+			}
+			granted.workspaces[workspace] = true
+		}
+	}
+	return granted
+}
+
+// allGranted is the grant of every caller when no policy is configured
+var allGranted = &grant{allRPCs: true, allWorkspaces: true}
+
+func (granted *grant) canCall(method string) bool {
+	return granted.allRPCs || granted.rpcs[rpcName(method)]
+}
+
+func (granted *grant) canSee(workspace string) bool {
+	return granted.allWorkspaces || granted.workspaces[workspace]
+}
+
+// rpcName strips the handler prefix from a method(param: method)
+func rpcName(method string) string {
+	if index := strings.LastIndex(method, "."); index >= 0 {
+		return method[index+1:]
+	}
+	return method
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value || candidate == wildcard {
+			return true
+		}
+	}
+	return false
+}
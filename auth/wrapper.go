@@ -0,0 +1,190 @@
+package auth
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	microErrors "github.com/micro/go-micro/errors"
+	"github.com/micro/go-micro/server"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// RPCs looking users up across the account unless given a workspace, which callers
+// restricted to some workspaces may only call with one of them
+var userRPCs = map[string]bool{"GetUser": true, "GetUsers": true, "GetUsersByIds": true}
+
+// Authenticator authenticates the callers of the service and enforces the
+// authorization policy on their requests
+type Authenticator struct {
+	jwtSecret []byte
+	issuer    string
+	audience  string
+	apiKeys   map[string]string
+	policy    *Policy
+}
+
+// New creates an authenticator accepting the JWTs and API keys of the configuration(param: configuration),
+// restricting callers with the policy file it names. Without a policy every authenticated caller may call everything
+func New(configuration *communicator.EnvironmentConfiguration) (*Authenticator, error) {
+	if len(configuration.AuthJwtSecret) < 1 && len(configuration.AuthApiKeys) < 1 {
+		return nil, errors.New("Authentication requires a JWT secret or API keys")
+	}
+	authenticator := &Authenticator{
+		jwtSecret: []byte(configuration.AuthJwtSecret),
+		issuer:    configuration.AuthJwtIssuer,
+		audience:  configuration.AuthJwtAudience,
+		apiKeys:   configuration.AuthApiKeys,
+	}
+	if len(configuration.AuthPolicyPath) > 0 {
+		policy, err := LoadPolicy(configuration.AuthPolicyPath)
+		if err != nil {
+			return nil, err
+		}
+		authenticator.policy = policy
+	}
+	return authenticator, nil
+}
+
+// Wrapper implements the server.HandlerWrapper rejecting the requests of unknown
+// callers and of callers the policy does not allow, and removing from responses
+// the resources of the workspaces the caller may not see
+func (authenticator *Authenticator) Wrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		caller, err := authenticator.authenticate(ctx)
+		if err != nil {
+			return microErrors.Unauthorized(req.Service(), "%s", err.Error())
+		}
+		granted := allGranted
+		if authenticator.policy != nil {
+			granted = authenticator.policy.grantOf(caller)
+		}
+		if !granted.canCall(req.Method()) {
+			return microErrors.Forbidden(req.Service(), "%s may not call %s", caller.Name, req.Method())
+		}
+		ctx = context.WithValue(ctx, callerKey{}, caller)
+
+		// The request of a stream is only received by the handler
+		if req.Stream() {
+			if stream, ok := rsp.(server.Stream); ok && !granted.allWorkspaces {
+				rsp = &visibleStream{Stream: stream, service: req.Service(), grant: granted}
+			}
+			return fn(ctx, req, rsp)
+		}
+		if request, ok := req.Request().(*communicator.Request); ok {
+			if len(request.Workspace) > 0 && !granted.canSee(request.Workspace) {
+				return microErrors.Forbidden(req.Service(), "%s may not see workspace %s", caller.Name, request.Workspace)
+			}
+			// Users belong to no workspace, only the participants of a workspace the caller sees may be looked up
+			if len(request.Workspace) < 1 && !granted.allWorkspaces && userRPCs[rpcName(req.Method())] {
+				return microErrors.Forbidden(req.Service(), "%s may only look users up in the workspaces it may see", caller.Name)
+			}
+		}
+		if err := fn(ctx, req, rsp); err != nil {
+			return err
+		}
+		if response, ok := rsp.(*communicator.Response); ok && !granted.allWorkspaces {
+			granted.filter(response)
+		}
+		return nil
+	}
+}
+
+// filter removes the resources of the workspaces the grant does not cover from
+// the response(param: response). Resources requested by ID are reported as not found.
+// The lists and maps of the response may be shared with the cache, so they are
+// replaced rather than modified
+func (granted *grant) filter(response *communicator.Response) {
+	var projects []*communicator.Project
+	for _, project := range response.Projects {
+		if granted.canSee(project.Id) {
+			projects = append(projects, project)
+		}
+	}
+	response.Projects = projects
+	var tasks []*communicator.Task
+	for _, task := range response.Tasks {
+		if granted.canSee(task.WorkspaceId) {
+			tasks = append(tasks, task)
+		}
+	}
+	response.Tasks = tasks
+	var timeentries []*communicator.Timeentry
+	for _, timeentry := range response.Timeentries {
+		if granted.canSee(timeentry.WorkspaceId) {
+			timeentries = append(timeentries, timeentry)
+		}
+	}
+	response.Timeentries = timeentries
+	notFound := append([]string(nil), response.NotFound...)
+	if response.ProjectsById != nil {
+		projectsById := make(map[string]*communicator.Project)
+		for id, project := range response.ProjectsById {
+			if granted.canSee(project.Id) {
+				projectsById[id] = project
+			} else {
+				notFound = append(notFound, id)
+			}
+		}
+		response.ProjectsById = projectsById
+	}
+	if response.TasksById != nil {
+		tasksById := make(map[string]*communicator.Task)
+		for id, task := range response.TasksById {
+			if granted.canSee(task.WorkspaceId) {
+				tasksById[id] = task
+			} else {
+				notFound = append(notFound, id)
+			}
+		}
+		response.TasksById = tasksById
+	}
+	if response.TimeentriesById != nil {
+		timeentriesById := make(map[string]*communicator.Timeentry)
+		for id, timeentry := range response.TimeentriesById {
+			if granted.canSee(timeentry.WorkspaceId) {
+				timeentriesById[id] = timeentry
+			} else {
+				notFound = append(notFound, id)
+			}
+		}
+		response.TimeentriesById = timeentriesById
+	}
+	response.NotFound = notFound
+}
+
+// visibleStream rejects streams over workspaces the grant does not cover and
+// skips the resources of such workspaces in streams over every workspace
+type visibleStream struct {
+	server.Stream
+	service string
+	grant   *grant
+}
+
+func (stream *visibleStream) Recv(message interface{}) error {
+	if err := stream.Stream.Recv(message); err != nil {
+		return err
+	}
+	if request, ok := message.(*communicator.Request); ok && len(request.Workspace) > 0 {
+		if !stream.grant.canSee(request.Workspace) {
+			return microErrors.Forbidden(stream.service, "workspace %s may not be seen", request.Workspace)
+		}
+	}
+	return nil
+}
+
+func (stream *visibleStream) Send(message interface{}) error {
+	switch resource := message.(type) {
+	case *communicator.Project:
+		if !stream.grant.canSee(resource.Id) {
+			return nil
+		}
+	case *communicator.Task:
+		if !stream.grant.canSee(resource.WorkspaceId) {
+			return nil
+		}
+	case *communicator.Timeentry:
+		if !stream.grant.canSee(resource.WorkspaceId) {
+			return nil
+		}
+	}
+	return stream.Stream.Send(message)
+}
@@ -0,0 +1,195 @@
+package cache
+
+import (
+	"container/list"
+	"golang.org/x/sync/singleflight"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats provides the usage counters of a Store
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+// Totals adds up the hits and misses of several stores, keeping them when the stores are dropped
+type Totals struct {
+	hits   uint64
+	misses uint64
+}
+
+// Hits returns the number of lookups answered by the stores
+func (totals *Totals) Hits() uint64 {
+	return atomic.LoadUint64(&totals.hits)
+}
+
+// Misses returns the number of lookups the stores could not answer
+func (totals *Totals) Misses() uint64 {
+	return atomic.LoadUint64(&totals.misses)
+}
+
+// Store is an in-memory key/value cache where every value expires after its
+// own TTL. Once the store holds its maximum number of entries the least
+// recently used entry is evicted. Concurrent loads of the same key are
+// deduplicated so that only one of them reaches the loader
+type Store struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	group   singleflight.Group
+	hits    uint64
+	misses  uint64
+	totals  *Totals
+}
+
+type entry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// NewStore creates a store holding at most the provided number(param: size) of entries
+func NewStore(size int) *Store {
+	return &Store{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// CountInto adds the hits and misses of the store to the totals(param: totals) as well,
+// which must be set before the store is used
+func (store *Store) CountInto(totals *Totals) {
+	store.totals = totals
+}
+
+// Get returns the value stored against the key(param: key) if it has not expired
+func (store *Store) Get(key string) (interface{}, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	element, ok := store.entries[key]
+	if !ok {
+		store.miss()
+		return nil, false
+	}
+	cached := element.Value.(*entry)
+	if time.Now().After(cached.expires) {
+		store.order.Remove(element)
+		delete(store.entries, key)
+		store.miss()
+		return nil, false
+	}
+	store.order.MoveToFront(element)
+	store.hits++
+	if store.totals != nil {
+		atomic.AddUint64(&store.totals.hits, 1)
+	}
+	return cached.value, true
+}
+
+// miss counts a lookup the store could not answer, the caller holding the mutex
+func (store *Store) miss() {
+	store.misses++
+	if store.totals != nil {
+		atomic.AddUint64(&store.totals.misses, 1)
+	}
+}
+
+// Set stores the value(param: value) against the key(param: key) for the duration(param: ttl)
+func (store *Store) Set(key string, value interface{}, ttl time.Duration) {
+	if ttl <= 0 || store.size < 1 {
+		return
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, ok := store.entries[key]; ok {
+		cached := element.Value.(*entry)
+		cached.value = value
+		cached.expires = time.Now().Add(ttl)
+		store.order.MoveToFront(element)
+		return
+	}
+	store.entries[key] = store.order.PushFront(&entry{key: key, value: value, expires: time.Now().Add(ttl)})
+	for store.order.Len() > store.size {
+		oldest := store.order.Back()
+		store.order.Remove(oldest)
+		delete(store.entries, oldest.Value.(*entry).key)
+	}
+}
+
+// Fetch returns the value stored against the key(param: key), calling the
+// loader(param: load) and storing its value for the duration(param: ttl) when
+// the key is missing or has expired. Failed loads are not stored. Concurrent callers
+// of the same key share the loaded value, which like every stored value must not be modified
+func (store *Store) Fetch(key string, ttl time.Duration, load func() (interface{}, error)) (interface{}, error) {
+	if value, ok := store.Get(key); ok {
+		return value, nil
+	}
+	value, err, _ := store.group.Do(key, func() (interface{}, error) {
+		value, err := load()
+		if err == nil {
+			store.Set(key, value, ttl)
+		}
+		return value, err
+	})
+	return value, err
+}
+
+// Delete removes the entry stored against the key(param: key)
+func (store *Store) Delete(key string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if element, ok := store.entries[key]; ok {
+		store.order.Remove(element)
+		delete(store.entries, key)
+	}
+}
+
+// DeleteMatching removes every entry whose key is accepted by the filter(param: match)
+// and returns the number of removed entries
+func (store *Store) DeleteMatching(match func(key string) bool) int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	deleted := 0
+	for key, element := range store.entries {
+		if match(key) {
+			store.order.Remove(element)
+			delete(store.entries, key)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Values returns the values of the entries which have not expired
+func (store *Store) Values() []interface{} {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	now := time.Now()
+	var values []interface{}
+	for _, element := range store.entries {
+		if cached := element.Value.(*entry); now.Before(cached.expires) {
+			values = append(values, cached.value)
+		}
+	}
+	return values
+}
+
+// Purge removes every entry from the store
+func (store *Store) Purge() {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.entries = make(map[string]*list.Element)
+	store.order.Init()
+}
+
+// Stats returns the usage counters of the store
+func (store *Store) Stats() Stats {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return Stats{Hits: store.hits, Misses: store.misses, Entries: len(store.entries)}
+}
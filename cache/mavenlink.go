@@ -0,0 +1,376 @@
+package cache
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"golang.org/x/net/context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Resources cached with their own TTL
+const (
+	projects    = "projects"
+	tasks       = "tasks"
+	users       = "users"
+	timeentries = "timeentries"
+)
+
+// Defaults used when the configuration leaves a cache setting empty
+const (
+	defaultSize           = 1000
+	defaultProjectsTtl    = 5 * time.Minute
+	defaultTasksTtl       = time.Minute
+	defaultUsersTtl       = 15 * time.Minute
+	defaultTimeentriesTtl = time.Minute
+)
+
+// MavenlinkCache wraps a MavenlinkApiInterface, keeping the results of its
+// read methods in memory. Cached values are shared between callers and must
+// not be modified. Streams and failed calls are never cached
+type MavenlinkCache struct {
+	mavenlink API.MavenlinkApiInterface
+	store     *Store
+	// ttl holds the map of the TTL of every resource, shared by the copies of the cache
+	ttl *atomic.Value
+}
+
+// batchResult holds the two values returned by the batch lookups
+type batchResult struct {
+	found   interface{}
+	missing []string
+}
+
+// New creates a cache in front of the Mavenlink API(param: mavenlink) sized
+// and timed using the provided configuration(param: configuration)
+func New(mavenlink API.MavenlinkApiInterface, configuration *communicator.EnvironmentConfiguration) *MavenlinkCache {
+	cache := &MavenlinkCache{mavenlink: mavenlink}
+	cache.configure(configuration)
+	return cache
+}
+
+func (cache *MavenlinkCache) configure(configuration *communicator.EnvironmentConfiguration) {
+	size := defaultSize
+	if configuration != nil && configuration.CacheSize > 0 {
+		size = int(configuration.CacheSize)
+	}
+	cache.store = NewStore(size)
+	cache.ttl = &atomic.Value{}
+	cache.setTtl(configuration)
+}
+
+// setTtl sets the TTL of every resource from the configuration(param: configuration)
+func (cache *MavenlinkCache) setTtl(configuration *communicator.EnvironmentConfiguration) {
+	ttl := map[string]time.Duration{
+		projects:    defaultProjectsTtl,
+		tasks:       defaultTasksTtl,
+		users:       defaultUsersTtl,
+		timeentries: defaultTimeentriesTtl,
+	}
+	if configuration != nil {
+		if configuration.CacheProjectsTtl > 0 {
+			ttl[projects] = time.Duration(configuration.CacheProjectsTtl) * time.Second
+		}
+		if configuration.CacheTasksTtl > 0 {
+			ttl[tasks] = time.Duration(configuration.CacheTasksTtl) * time.Second
+		}
+		if configuration.CacheUsersTtl > 0 {
+			ttl[users] = time.Duration(configuration.CacheUsersTtl) * time.Second
+		}
+		if configuration.CacheTimeentriesTtl > 0 {
+			ttl[timeentries] = time.Duration(configuration.CacheTimeentriesTtl) * time.Second
+		}
+	}
+	cache.ttl.Store(ttl)
+}
+
+// ttlOf returns the current TTL of the resource(param: resource)
+func (cache *MavenlinkCache) ttlOf(resource string) time.Duration {
+	return cache.ttl.Load().(map[string]time.Duration)[resource]
+}
+
+// Uncached returns the wrapped Mavenlink API so that callers can skip the cache
+func (cache *MavenlinkCache) Uncached() API.MavenlinkApiInterface {
+	return cache.mavenlink
+}
+
+// WithContext returns the cache bound to the context(param: ctx), sharing its entries,
+// so that the calls made to Mavenlink on a miss are made on behalf of the context
+func (cache *MavenlinkCache) WithContext(ctx context.Context) API.MavenlinkApiInterface {
+	return &MavenlinkCache{mavenlink: API.WithContext(cache.mavenlink, ctx), store: cache.store, ttl: cache.ttl}
+}
+
+// Stats returns the usage counters of the cache
+func (cache *MavenlinkCache) Stats() Stats {
+	return cache.store.Stats()
+}
+
+// CountInto adds the hits and misses of the cache to the totals(param: totals) as well,
+// which must be set before the cache is used
+func (cache *MavenlinkCache) CountInto(totals *Totals) {
+	cache.store.CountInto(totals)
+}
+
+// InvalidateProject drops the cached results which may include the workspace(param: id)
+func (cache *MavenlinkCache) InvalidateProject(id string) {
+	cache.invalidate(projects)
+}
+
+// InvalidateTask drops the cached results which may include the story(param: id) of the workspace(param: workspaceId)
+func (cache *MavenlinkCache) InvalidateTask(workspaceId string, id string) {
+	cache.invalidate(tasks, workspaceId, id)
+	// Task trees including logged time are cached with the time entries
+	cache.invalidate(timeentries, workspaceId)
+}
+
+// InvalidateTimeEntry drops the cached results which may include the time entry(param: id) of the workspace(param: workspaceId)
+func (cache *MavenlinkCache) InvalidateTimeEntry(workspaceId string, id string) {
+	cache.invalidate(timeentries, workspaceId, id)
+}
+
+// InvalidateUser drops the cached results which may include the user(param: id)
+func (cache *MavenlinkCache) InvalidateUser(id string) {
+	// Participants are cached by workspace, so any of them may include the user
+	cache.invalidate(users)
+	// Tasks and time entries embed their users
+	cache.invalidate(tasks)
+	cache.invalidate(timeentries)
+}
+
+// invalidate drops the cached results of a resource(param: resource) called with
+// any of the provided arguments(param: parts), or all of them when none is provided
+func (cache *MavenlinkCache) invalidate(resource string, parts ...string) {
+	cache.store.DeleteMatching(func(key string) bool {
+		keyParts := strings.Split(key, "\x00")
+		if keyParts[0] != resource {
+			return false
+		}
+		if len(parts) < 1 {
+			return true
+		}
+		for _, keyPart := range keyParts[2:] {
+			for _, part := range parts {
+				if len(part) > 0 && keyPart == part {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// fetch returns the cached result of the call identified by its method and
+// arguments(param: parts), loading it when missing
+func (cache *MavenlinkCache) fetch(resource string, load func() (interface{}, error), parts ...string) (interface{}, error) {
+	key := resource + "\x00" + strings.Join(parts, "\x00")
+	return cache.store.Fetch(key, cache.ttlOf(resource), load)
+}
+
+// SetEnv reconfigures the wrapped API and the TTLs, dropping the cached results
+// which may come from another account or URL. The size of the cache is kept
+func (cache *MavenlinkCache) SetEnv(configuration *communicator.EnvironmentConfiguration) error {
+	if err := cache.mavenlink.SetEnv(configuration); err != nil {
+		return err
+	}
+	cache.setTtl(configuration)
+	cache.store.Purge()
+	return nil
+}
+
+func (cache *MavenlinkCache) FormatErrors(err error, message string) *communicator.Error {
+	return cache.mavenlink.FormatErrors(err, message)
+}
+
+func (cache *MavenlinkCache) GetProjects() ([]*communicator.Project, error) {
+	value, err := cache.fetch(projects, func() (interface{}, error) {
+		return cache.mavenlink.GetProjects()
+	}, "GetProjects")
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Project), nil
+}
+
+func (cache *MavenlinkCache) GetProject(keyOrId string) (*communicator.Project, error) {
+	value, err := cache.fetch(projects, func() (interface{}, error) {
+		return cache.mavenlink.GetProject(keyOrId)
+	}, "GetProject", keyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*communicator.Project), nil
+}
+
+func (cache *MavenlinkCache) GetTasksFromProjectId(keyOrId string) ([]*communicator.Task, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		return cache.mavenlink.GetTasksFromProjectId(keyOrId)
+	}, "GetTasksFromProjectId", keyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Task), nil
+}
+
+func (cache *MavenlinkCache) GetSubTasksFromProjectId(workspace string, task string) ([]*communicator.Task, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		return cache.mavenlink.GetSubTasksFromProjectId(workspace, task)
+	}, "GetSubTasksFromProjectId", workspace, task)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Task), nil
+}
+
+func (cache *MavenlinkCache) GetIssueTasksFromProjectId(keyOrId string, subTask string) ([]*communicator.Task, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		return cache.mavenlink.GetIssueTasksFromProjectId(keyOrId, subTask)
+	}, "GetIssueTasksFromProjectId", keyOrId, subTask)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Task), nil
+}
+
+func (cache *MavenlinkCache) GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string,
+	issueTaskKeyOrId string) ([]*communicator.Timeentry, error) {
+
+	value, err := cache.fetch(timeentries, func() (interface{}, error) {
+		return cache.mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId, issueTaskKeyOrId)
+	}, "GetTimeEntriesFromProjectIdAndIssueTaskId", projectKeyOrId, issueTaskKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Timeentry), nil
+}
+
+func (cache *MavenlinkCache) GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error) {
+	value, err := cache.fetch(users, func() (interface{}, error) {
+		return cache.mavenlink.GetUsersFromProjectId(projectKeyOrId)
+	}, "GetUsersFromProjectId", projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.User), nil
+}
+
+func (cache *MavenlinkCache) GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error) {
+	value, err := cache.fetch(users, func() (interface{}, error) {
+		return cache.mavenlink.GetUserFromProjectId(projectKeyOrId, userId)
+	}, "GetUserFromProjectId", projectKeyOrId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*communicator.User), nil
+}
+
+func (cache *MavenlinkCache) GetUserById(userId string) (*communicator.User, error) {
+	value, err := cache.fetch(users, func() (interface{}, error) {
+		return cache.mavenlink.GetUserById(userId)
+	}, "GetUserById", userId)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*communicator.User), nil
+}
+
+func (cache *MavenlinkCache) GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		return cache.mavenlink.GetTaskDependenciesFromProjectId(projectKeyOrId)
+	}, "GetTaskDependenciesFromProjectId", projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.TaskDependency), nil
+}
+
+func (cache *MavenlinkCache) GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		return cache.mavenlink.GetCriticalPathFromProjectId(projectKeyOrId)
+	}, "GetCriticalPathFromProjectId", projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	return value.(*communicator.CriticalPath), nil
+}
+
+func (cache *MavenlinkCache) GetTaskTreeFromProjectId(projectKeyOrId string,
+	includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+
+	resource := tasks
+	if includeLoggedTime {
+		resource = timeentries
+	}
+	value, err := cache.fetch(resource, func() (interface{}, error) {
+		return cache.mavenlink.GetTaskTreeFromProjectId(projectKeyOrId, includeLoggedTime)
+	}, "GetTaskTreeFromProjectId", projectKeyOrId, strconv.FormatBool(includeLoggedTime))
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.TaskNode), nil
+}
+
+func (cache *MavenlinkCache) StreamProjects(perPage int32, emit func(*communicator.Project) error) error {
+	return cache.mavenlink.StreamProjects(perPage, emit)
+}
+
+func (cache *MavenlinkCache) StreamTasks(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Task) error) error {
+
+	return cache.mavenlink.StreamTasks(projectKeyOrId, perPage, emit)
+}
+
+func (cache *MavenlinkCache) StreamTimeEntries(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Timeentry) error) error {
+
+	return cache.mavenlink.StreamTimeEntries(projectKeyOrId, perPage, emit)
+}
+
+func (cache *MavenlinkCache) GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error) {
+	value, err := cache.fetch(projects, func() (interface{}, error) {
+		found, missing, err := cache.mavenlink.GetProjectsByIds(ids)
+		return batchResult{found, missing}, err
+	}, append([]string{"GetProjectsByIds"}, ids...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := value.(batchResult)
+	return result.found.(map[string]*communicator.Project), result.missing, nil
+}
+
+func (cache *MavenlinkCache) GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error) {
+	value, err := cache.fetch(tasks, func() (interface{}, error) {
+		found, missing, err := cache.mavenlink.GetTasksByIds(ids)
+		return batchResult{found, missing}, err
+	}, append([]string{"GetTasksByIds"}, ids...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := value.(batchResult)
+	return result.found.(map[string]*communicator.Task), result.missing, nil
+}
+
+func (cache *MavenlinkCache) GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error) {
+	value, err := cache.fetch(users, func() (interface{}, error) {
+		found, missing, err := cache.mavenlink.GetUsersByIds(ids)
+		return batchResult{found, missing}, err
+	}, append([]string{"GetUsersByIds"}, ids...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := value.(batchResult)
+	return result.found.(map[string]*communicator.User), result.missing, nil
+}
+
+func (cache *MavenlinkCache) GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error) {
+	value, err := cache.fetch(timeentries, func() (interface{}, error) {
+		found, missing, err := cache.mavenlink.GetTimeEntriesByIds(ids)
+		return batchResult{found, missing}, err
+	}, append([]string{"GetTimeEntriesByIds"}, ids...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	result := value.(batchResult)
+	return result.found.(map[string]*communicator.Timeentry), result.missing, nil
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro/client"
+	"golang.org/x/net/context"
+)
+
+// backend retrieves the data shown by the commands, either from Mavenlink or
+// from a running instance of the service
+type backend interface {
+	Projects() ([]*communicator.Project, error)
+	TaskTree(project string, includeLoggedTime bool) ([]*communicator.TaskNode, error)
+	TimeEntries(project string, task string) ([]*communicator.Timeentry, error)
+	Users(project string) ([]*communicator.User, error)
+}
+
+// directBackend talks to Mavenlink with the api package
+type directBackend struct {
+	mavenlink API.MavenlinkApiInterface
+}
+
+// newDirectBackend creates a backend calling Mavenlink with the configuration(param: configuration)
+func newDirectBackend(configuration *communicator.EnvironmentConfiguration) *directBackend {
+	mavenlink := &API.MavenlinkApi{}
+	mavenlink.SetEnv(configuration)
+	return &directBackend{mavenlink}
+}
+
+func (b *directBackend) Projects() ([]*communicator.Project, error) {
+	return b.mavenlink.GetProjects()
+}
+
+func (b *directBackend) TaskTree(project string, includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+	return b.mavenlink.GetTaskTreeFromProjectId(project, includeLoggedTime)
+}
+
+func (b *directBackend) TimeEntries(project string, task string) ([]*communicator.Timeentry, error) {
+	return b.mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(project, task)
+}
+
+func (b *directBackend) Users(project string) ([]*communicator.User, error) {
+	return b.mavenlink.GetUsersFromProjectId(project)
+}
+
+// rpcBackend calls a running instance of the service over RPC
+type rpcBackend struct {
+	service communicator.MavenlinkCommunicatorClient
+}
+
+// newRPCBackend creates a backend calling the service registered under a name(param: serviceName)
+func newRPCBackend(serviceName string) *rpcBackend {
+	return &rpcBackend{communicator.NewMavenlinkCommunicatorClient(serviceName, client.DefaultClient)}
+}
+
+func (b *rpcBackend) Projects() ([]*communicator.Project, error) {
+	res, err := b.service.GetAllProjects(context.Background(), &communicator.Request{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Projects, nil
+}
+
+func (b *rpcBackend) TaskTree(project string, includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+	res, err := b.service.GetTaskTree(context.Background(), &communicator.Request{
+		Workspace:         project,
+		IncludeLoggedTime: includeLoggedTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.TaskTree, nil
+}
+
+func (b *rpcBackend) TimeEntries(project string, task string) ([]*communicator.Timeentry, error) {
+	res, err := b.service.GetTimeentries(context.Background(), &communicator.Request{Workspace: project, Task: task})
+	if err != nil {
+		return nil, err
+	}
+	return res.Timeentries, nil
+}
+
+func (b *rpcBackend) Users(project string) ([]*communicator.User, error) {
+	res, err := b.service.GetUsers(context.Background(), &communicator.Request{Workspace: project})
+	if err != nil {
+		return nil, err
+	}
+	return res.Users, nil
+}
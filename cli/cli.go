@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// command is a subcommand of the CLI, invoked as "<resource> <action> [flags]"
+type command struct {
+	resource    string
+	action      string
+	description string
+	// flags registers the flags of the command on the set(param: flags)
+	flags func(flags *flag.FlagSet, options *options)
+	run   func(backend backend, options *options) (*result, error)
+}
+
+// options collects the flags of a command
+type options struct {
+	project string
+	task    string
+	logged  bool
+	rpc     bool
+	service string
+	output  string
+}
+
+var commands = []*command{
+	{
+		resource:    "projects",
+		action:      "list",
+		description: "List the projects available to the token",
+		run:         listProjects,
+	},
+	{
+		resource:    "tasks",
+		action:      "tree",
+		description: "Show the tasks of a project as a tree",
+		flags: func(flags *flag.FlagSet, options *options) {
+			flags.StringVar(&options.project, "project", "", "ID of the project (required)")
+			flags.BoolVar(&options.logged, "logged", false, "include the time logged on every task")
+		},
+		run: taskTree,
+	},
+	{
+		resource:    "time",
+		action:      "log",
+		description: "Show the time logged on a project or one of its tasks",
+		flags: func(flags *flag.FlagSet, options *options) {
+			flags.StringVar(&options.project, "project", "", "ID of the project (required)")
+			flags.StringVar(&options.task, "task", "", "ID of a task to restrict the entries to")
+		},
+		run: timeLog,
+	},
+	{
+		resource:    "users",
+		action:      "list",
+		description: "List the users participating in a project",
+		flags: func(flags *flag.FlagSet, options *options) {
+			flags.StringVar(&options.project, "project", "", "ID of the project (required)")
+		},
+		run: listUsers,
+	},
+}
+
+// IsCommand tells whether the argument(param: name) starts a CLI command rather than
+// the flags of the server
+func IsCommand(name string) bool {
+	for _, command := range commands {
+		if command.resource == name {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+// Run runs the command given by the arguments(param: args), calling Mavenlink with
+// the configuration returned by load(param: load) or the service registered as serviceName
+// when asked to, and writes its result to stdout. The configuration is only loaded when
+// Mavenlink is called, so -rpc commands run without a token
+func Run(args []string, load func() (*communicator.EnvironmentConfiguration, error), serviceName string, stdout io.Writer) error {
+	if len(args) < 2 || args[0] == "help" {
+		usage(stdout)
+		return nil
+	}
+	command := find(args[0], args[1])
+	if command == nil {
+		usage(stdout)
+		return fmt.Errorf("unknown command %q", strings.Join(args[:2], " "))
+	}
+	options := &options{}
+	flags := flag.NewFlagSet(command.resource+" "+command.action, flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	flags.BoolVar(&options.rpc, "rpc", false, "call a running instance of the service instead of Mavenlink")
+	flags.StringVar(&options.service, "service", serviceName, "name of the service called with -rpc")
+	flags.StringVar(&options.output, "output", tableFormat, "output format: table, json or csv")
+	if command.flags != nil {
+		command.flags(flags, options)
+	}
+	if err := flags.Parse(args[2:]); err != nil {
+		return err
+	}
+	if flags.Lookup("project") != nil && len(options.project) < 1 {
+		return fmt.Errorf("%s %s: the -project flag is required", command.resource, command.action)
+	}
+	if options.output != tableFormat && options.output != jsonFormat && options.output != csvFormat {
+		return fmt.Errorf("unknown output format %q, expected one of %s, %s or %s", options.output, tableFormat, jsonFormat, csvFormat)
+	}
+
+	var backend backend
+	if options.rpc {
+		backend = newRPCBackend(options.service)
+	} else {
+		configuration, err := load()
+		if err != nil {
+			return err
+		}
+		backend = newDirectBackend(configuration)
+	}
+	result, err := command.run(backend, options)
+	if err != nil {
+		return err
+	}
+	return write(stdout, options.output, result)
+}
+
+func find(resource string, action string) *command {
+	for _, command := range commands {
+		if command.resource == resource && command.action == action {
+			return command
+		}
+	}
+	return nil
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: mavenlink-communicator <resource> <action> [flags]")
+	fmt.Fprintln(w, "Runs the service when no command is given.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-16s %s\n", command.resource+" "+command.action, command.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Every command accepts -rpc, -service and -output, run it with -h for its flags.")
+}
+
+func listProjects(backend backend, options *options) (*result, error) {
+	projects, err := backend.Projects()
+	if err != nil {
+		return nil, err
+	}
+	result := &result{columns: []string{"ID", "TITLE", "START", "DUE", "ARCHIVED"}, value: projects}
+	for _, project := range projects {
+		result.add(project.Id, project.Title, project.StartDate, project.DueDate, strconv.FormatBool(project.Archived))
+	}
+	return result, nil
+}
+
+func taskTree(backend backend, options *options) (*result, error) {
+	tree, err := backend.TaskTree(options.project, options.logged)
+	if err != nil {
+		return nil, err
+	}
+	result := &result{columns: []string{"ID", "TITLE", "STATE", "ASSIGNEES"}, value: tree}
+	if options.logged {
+		result.columns = append(result.columns, "LOGGED", "TOTAL LOGGED")
+	}
+	// Titles are indented by their depth in the table, the CSV keeps the parent instead
+	if options.output == csvFormat {
+		result.columns = append(result.columns, "PARENT")
+	}
+	var add func(nodes []*communicator.TaskNode, depth int)
+	add = func(nodes []*communicator.TaskNode, depth int) {
+		for _, node := range nodes {
+			title := node.Task.Title
+			if options.output != csvFormat {
+				title = strings.Repeat("  ", depth) + title
+			}
+			var assignees []string
+			for _, user := range node.Assignees {
+				assignees = append(assignees, user.FullName)
+			}
+			row := []string{node.Task.Id, title, node.Task.State, strings.Join(assignees, ", ")}
+			if options.logged {
+				row = append(row, minutes(node.LoggedMinutes), minutes(node.TotalLoggedMinutes))
+			}
+			if options.output == csvFormat {
+				row = append(row, node.Task.ParentId)
+			}
+			result.add(row...)
+			add(node.Children, depth+1)
+		}
+	}
+	add(tree, 0)
+	return result, nil
+}
+
+func timeLog(backend backend, options *options) (*result, error) {
+	timeentries, err := backend.TimeEntries(options.project, options.task)
+	if err != nil {
+		return nil, err
+	}
+	result := &result{columns: []string{"ID", "DATE", "TASK", "USER", "TIME", "NOTES"}, value: timeentries}
+	var total int32
+	for _, timeentry := range timeentries {
+		var user string
+		if timeentry.User != nil {
+			user = timeentry.User.FullName
+		}
+		result.add(timeentry.Id, timeentry.DatePerformed, timeentry.StoryId, user,
+			minutes(timeentry.TimeInMinutes), timeentry.Notes)
+		total += timeentry.TimeInMinutes
+	}
+	if options.output == tableFormat {
+		result.add("", "", "", "TOTAL", minutes(total), "")
+	}
+	return result, nil
+}
+
+func listUsers(backend backend, options *options) (*result, error) {
+	users, err := backend.Users(options.project)
+	if err != nil {
+		return nil, err
+	}
+	result := &result{columns: []string{"ID", "NAME", "EMAIL", "HEADLINE"}, value: users}
+	for _, user := range users {
+		result.add(user.Id, user.FullName, user.EmailAddress, user.Headline)
+	}
+	return result, nil
+}
+
+// minutes formats a duration in minutes(param: value) as hours and minutes
+func minutes(value int32) string {
+	return fmt.Sprintf("%dh%02dm", value/60, value%60)
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"strings"
+	"testing"
+)
+
+func TestRunLoadsTheConfigurationToCallMavenlink(t *testing.T) {
+	server := mavenlinktest.NewServer()
+	defer server.Close()
+	loads := 0
+	load := func() (*communicator.EnvironmentConfiguration, error) {
+		loads++
+		return server.Config(), nil
+	}
+
+	var out bytes.Buffer
+	if err := Run([]string{"projects", "list", "-output", "csv"}, load, "test", &out); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if loads != 1 {
+		t.Errorf("expected the configuration to be loaded once, was loaded %d times", loads)
+	}
+	if !strings.Contains(out.String(), "1001") {
+		t.Errorf("expected the projects of the fake server, got %q", out.String())
+	}
+}
+
+func TestRunDoesNotLoadTheConfigurationUnlessNeeded(t *testing.T) {
+	invalid := errors.New("token is required")
+	load := func() (*communicator.EnvironmentConfiguration, error) {
+		return nil, invalid
+	}
+	var out bytes.Buffer
+	if err := Run([]string{"help"}, load, "test", &out); err != nil {
+		t.Errorf("expected help without a configuration, got %v", err)
+	}
+	if err := Run([]string{"users", "list"}, load, "test", &out); err == nil || err == invalid {
+		t.Errorf("expected the missing -project flag to be reported before loading, got %v", err)
+	}
+	if err := Run([]string{"projects", "list"}, load, "test", &out); err != invalid {
+		t.Errorf("expected the error of the configuration, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Formats the results of a command can be written in
+const (
+	tableFormat = "table"
+	jsonFormat  = "json"
+	csvFormat   = "csv"
+)
+
+// result is the outcome of a command, as rows for the table and CSV formats
+// and as the retrieved value for the JSON format
+type result struct {
+	columns []string
+	rows    [][]string
+	value   interface{}
+}
+
+func (r *result) add(row ...string) {
+	r.rows = append(r.rows, row)
+}
+
+// write writes the result(param: r) in the format(param: format) to the writer(param: w)
+func write(w io.Writer, format string, r *result) error {
+	switch format {
+	case tableFormat:
+		table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, strings.Join(r.columns, "\t"))
+		for _, row := range r.rows {
+			fmt.Fprintln(table, strings.Join(row, "\t"))
+		}
+		return table.Flush()
+	case jsonFormat:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r.value)
+	case csvFormat:
+		records := csv.NewWriter(w)
+		records.Write(r.columns)
+		records.WriteAll(r.rows)
+		return records.Error()
+	}
+	return fmt.Errorf("unknown output format %q, expected one of %s, %s or %s", format, tableFormat, jsonFormat, csvFormat)
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/kelseyhightower/envconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Prefix of the environment variables overriding the settings, e.g. MAVENLINK-COMMUNICATOR_TOKEN
+const Prefix = "mavenlink-communicator"
+
+// PathVariable names the environment variable giving the path of the configuration file
+const PathVariable = "MAVENLINK_COMMUNICATOR_CONFIG"
+
+// Suffix of the settings and environment variables naming a file holding the value of a secret
+const fileSuffix = "_file"
+
+// Settings which may be read from a file, so that secrets mounted as files need not be
+// copied into the configuration file or the environment
+var secrets = []string{"token", "webhook_secret", "oauth_client_secret", "oauth_encryption_key", "oauth_admin_key",
+	"auth_jwt_secret"}
+
+// Path returns the path of the configuration file, empty when none is given
+func Path() string {
+	return os.Getenv(PathVariable)
+}
+
+// Load fills the configuration(param: configuration) from the configuration file when one
+// is given, then from the environment variables, which take precedence, and from the files
+// holding its secrets. The configuration is normalized and validated, an error describing
+// every invalid setting being returned
+func Load(configuration *communicator.EnvironmentConfiguration) error {
+	var secretFiles map[string]string
+	if path := Path(); len(path) > 0 {
+		var err error
+		if secretFiles, err = loadFile(path, configuration); err != nil {
+			return err
+		}
+	}
+	if err := envconfig.Process(Prefix, configuration); err != nil {
+		return err
+	}
+	if err := readSecrets(configuration, secretFiles); err != nil {
+		return err
+	}
+	Normalize(configuration)
+	return Validate(configuration)
+}
+
+// loadFile decodes the YAML, TOML or JSON file at the path(param: path), as told by its
+// extension, into the configuration(param: configuration). The settings are named as in
+// the protobuf definition. The files named by the secret settings are returned by secret
+func loadFile(path string, configuration *communicator.EnvironmentConfiguration) (map[string]string, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the configuration file")
+	}
+	settings := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &settings)
+	case ".toml":
+		err = toml.Unmarshal(raw, &settings)
+	case ".json":
+		err = json.Unmarshal(raw, &settings)
+	default:
+		return nil, errors.Errorf("Unsupported configuration file %s, expected a .yaml, .yml, .toml or .json file", path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse the configuration file %s", path)
+	}
+	secretFiles := make(map[string]string)
+	for _, secret := range secrets {
+		if file, ok := settings[secret+fileSuffix]; ok {
+			secretFiles[secret] = fmt.Sprint(file)
+			delete(settings, secret+fileSuffix)
+		}
+	}
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse the configuration file %s", path)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(configuration); err != nil {
+		return nil, errors.Wrapf(err, "Invalid configuration file %s", path)
+	}
+	return secretFiles, nil
+}
+
+// readSecrets reads the secrets of the configuration(param: configuration) from the files named
+// by the environment variables suffixed with _FILE, or by the configuration file(param: files)
+// unless the environment sets the secret itself. Trailing line breaks are dropped from the secrets
+func readSecrets(configuration *communicator.EnvironmentConfiguration, files map[string]string) error {
+	value := reflect.ValueOf(configuration).Elem()
+	for _, secret := range secrets {
+		field, ok := fieldOf(value.Type(), secret)
+		if !ok {
+			continue
+		}
+		path := files[secret]
+		if len(os.Getenv(strings.ToUpper(Prefix+"_"+field.Name))) > 0 {
+			path = ""
+		}
+		if fromEnv := os.Getenv(strings.ToUpper(Prefix + "_" + field.Name + fileSuffix)); len(fromEnv) > 0 {
+			path = fromEnv
+		}
+		if len(path) < 1 {
+			continue
+		}
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to read the %s", secret)
+		}
+		value.FieldByIndex(field.Index).SetString(strings.TrimRight(string(raw), "\r\n"))
+	}
+	return nil
+}
+
+// fieldOf returns the field of the type(param: configurationType) of the setting(param: name)
+func fieldOf(configurationType reflect.Type, name string) (reflect.StructField, bool) {
+	for index := 0; index < configurationType.NumField(); index++ {
+		field := configurationType.Field(index)
+		if strings.Split(field.Tag.Get("json"), ",")[0] == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,198 @@
+package config
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// validConfiguration returns a configuration the service can start with
+func validConfiguration() *communicator.EnvironmentConfiguration {
+	return &communicator.EnvironmentConfiguration{Url: "https://api.mavenlink.com/api/v1/", Token: "token"}
+}
+
+// writeFile writes the content(param: content) to the file named name(param: name) in the
+// directory(param: directory) and returns its path
+func writeFile(t *testing.T, directory string, name string, content string) string {
+	path := filepath.Join(directory, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// setenv sets the environment variable(param: name) for the rest of the test(param: t),
+// the returned function restoring it
+func setenv(t *testing.T, name string, value string) func() {
+	previous, ok := os.LookupEnv(name)
+	if err := os.Setenv(name, value); err != nil {
+		t.Fatal(err)
+	}
+	return func() {
+		if ok {
+			os.Setenv(name, previous)
+		} else {
+			os.Unsetenv(name)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(validConfiguration()); err != nil {
+		t.Fatalf("expected the configuration to be valid, got %v", err)
+	}
+	cases := []struct {
+		name    string
+		change  func(configuration *communicator.EnvironmentConfiguration)
+		problem string
+	}{
+		{"no url", func(c *communicator.EnvironmentConfiguration) { c.Url = "" }, "url is required"},
+		{"relative url", func(c *communicator.EnvironmentConfiguration) { c.Url = "api/v1/" }, "url must be an absolute"},
+		{"no token", func(c *communicator.EnvironmentConfiguration) { c.Token = "" }, "token is required"},
+		{"negative ttl", func(c *communicator.EnvironmentConfiguration) { c.CacheTasksTtl = -1 }, "cache_tasks_ttl must not be negative"},
+		{"bad address", func(c *communicator.EnvironmentConfiguration) { c.GatewayAddress = "8080" }, "gateway_address must be a host:port"},
+		{"oauth without client", func(c *communicator.EnvironmentConfiguration) {
+			c.OauthEnabled = true
+			c.OauthRedirectUrl = "https://example.com/callback"
+		}, "oauth_client_id is required"},
+		{"auth without credentials", func(c *communicator.EnvironmentConfiguration) { c.AuthEnabled = true }, "auth_jwt_secret or auth_api_keys is required"},
+		{"auth with the gateway", func(c *communicator.EnvironmentConfiguration) {
+			c.AuthEnabled = true
+			c.AuthApiKeys = map[string]string{"key": "caller"}
+			c.GatewayEnabled = true
+		}, "cannot be combined with auth_enabled"},
+		{"log level", func(c *communicator.EnvironmentConfiguration) { c.LogLevel = "verbose" }, "log_level must be one of"},
+		{"http mode", func(c *communicator.EnvironmentConfiguration) { c.HttpMode = "rewind" }, "http_mode must be"},
+		{"memory with the mirror", func(c *communicator.EnvironmentConfiguration) {
+			c.MemoryEnabled = true
+			c.MirrorEnabled = true
+		}, "cannot be combined with memory_enabled"},
+	}
+	for _, test := range cases {
+		configuration := validConfiguration()
+		test.change(configuration)
+		err := Validate(configuration)
+		if err == nil || !strings.Contains(err.Error(), test.problem) {
+			t.Errorf("%s: expected %q, got %v", test.name, test.problem, err)
+		}
+	}
+
+	// Every problem is reported at once
+	err := Validate(&communicator.EnvironmentConfiguration{RequestTimeout: -1})
+	if validation, ok := err.(*ValidationError); !ok || len(validation.Problems) != 3 {
+		t.Errorf("expected the url, token and request_timeout problems, got %v", err)
+	}
+	// The token is not required when it is not used
+	configuration := validConfiguration()
+	configuration.Token = ""
+	configuration.TenantRequired = true
+	if err := Validate(configuration); err != nil {
+		t.Errorf("expected no token to be needed when callers pass theirs, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	secret := writeFile(t, directory, "token", "from-file\n")
+	path := writeFile(t, directory, "config.yaml", "url: https://example.com/api/v1\n"+
+		"request_timeout: 5\ntoken_file: "+secret+"\n")
+	defer setenv(t, PathVariable, path)()
+	defer setenv(t, "MAVENLINK-COMMUNICATOR_DEBUG", "true")()
+
+	configuration := &communicator.EnvironmentConfiguration{}
+	if err := Load(configuration); err != nil {
+		t.Fatal(err)
+	}
+	if configuration.Url != "https://example.com/api/v1/" || configuration.RequestTimeout != 5 {
+		t.Errorf("expected the settings of the file, normalized, got %v", configuration)
+	}
+	if configuration.Token != "from-file" {
+		t.Errorf("expected the token of its file, got %q", configuration.Token)
+	}
+	if configuration.Debug != true {
+		t.Error("expected the environment to set debug")
+	}
+
+	// The environment takes precedence over the secret files of the configuration file
+	defer setenv(t, "MAVENLINK-COMMUNICATOR_TOKEN", "from-env")()
+	configuration = &communicator.EnvironmentConfiguration{}
+	if err := Load(configuration); err != nil {
+		t.Fatal(err)
+	}
+	if configuration.Token != "from-env" {
+		t.Errorf("expected the token of the environment, got %q", configuration.Token)
+	}
+
+	writeFile(t, directory, "config.yaml", "url: example.com\n")
+	if err := Load(&communicator.EnvironmentConfiguration{}); err == nil {
+		t.Error("expected the invalid configuration to be rejected")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	current := validConfiguration()
+	current.GatewayAddress = ":8080"
+	fresh := validConfiguration()
+	fresh.Token = "rotated"
+	fresh.CacheTasksTtl = 30
+	fresh.GatewayAddress = ":9090"
+	fresh.MirrorEnabled = true
+
+	merged, restart := Merge(current, fresh)
+	if merged.Token != "rotated" || merged.CacheTasksTtl != 30 {
+		t.Errorf("expected the reloadable settings to be taken, got %v", merged)
+	}
+	if merged.GatewayAddress != ":8080" || merged.MirrorEnabled == true {
+		t.Errorf("expected the other settings to be kept, got %v", merged)
+	}
+	if !reflect.DeepEqual(restart, []string{"gateway_address", "mirror_enabled"}) {
+		t.Errorf("expected the settings needing a restart, got %v", restart)
+	}
+	if current.Token != "token" {
+		t.Error("expected the current configuration to be left untouched")
+	}
+}
+
+func TestReload(t *testing.T) {
+	directory, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+	path := writeFile(t, directory, "config.json", `{"url": "https://example.com/", "token": "first"}`)
+	defer setenv(t, PathVariable, path)()
+	current := &communicator.EnvironmentConfiguration{}
+	if err := Load(current); err != nil {
+		t.Fatal(err)
+	}
+	var applied []*communicator.EnvironmentConfiguration
+	reloader := NewReloader(current, func(configuration *communicator.EnvironmentConfiguration) {
+		applied = append(applied, configuration)
+	})
+
+	if err := reloader.Reload(); err != nil || len(applied) != 0 {
+		t.Fatalf("expected an unchanged configuration not to be applied, got %v and %d", err, len(applied))
+	}
+	writeFile(t, directory, "config.json", `{"url": "https://example.com/", "token": "second", "gateway_enabled": true}`)
+	if err := reloader.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Token != "second" || applied[0].GatewayEnabled == true {
+		t.Fatalf("expected the new token to be applied without the gateway, got %v", applied)
+	}
+	writeFile(t, directory, "config.json", `{"url": "https://example.com/", "token": ""}`)
+	if err := reloader.Reload(); err == nil || len(applied) != 1 {
+		t.Errorf("expected the invalid configuration to be kept out, got %v", err)
+	}
+	if current.Token != "first" {
+		t.Error("expected the configuration given to the reloader to be left untouched")
+	}
+}
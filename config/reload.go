@@ -0,0 +1,131 @@
+package config
+
+import (
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/golang/protobuf/proto"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Settings taking effect when the configuration is reloaded. The others are
+// read once at startup and only change after a restart
+var reloadable = map[string]bool{
+	"debug":                 true,
+	"url":                   true,
+	"token":                 true,
+	"request_timeout":       true,
+	"cache_projects_ttl":    true,
+	"cache_tasks_ttl":       true,
+	"cache_users_ttl":       true,
+	"cache_timeentries_ttl": true,
+	"mirror_staleness":      true,
+	"tenant_tokens":         true,
+	"tenant_required":       true,
+	"log_level":             true,
+}
+
+// Merge returns a copy of the configuration(param: current) taking the reloadable settings of the
+// configuration(param: fresh), with the names of the other settings which changed and need a restart
+func Merge(current *communicator.EnvironmentConfiguration, fresh *communicator.EnvironmentConfiguration) (
+	*communicator.EnvironmentConfiguration, []string) {
+
+	merged := proto.Clone(current).(*communicator.EnvironmentConfiguration)
+	var restart []string
+	mergedValue := reflect.ValueOf(merged).Elem()
+	freshValue := reflect.ValueOf(fresh).Elem()
+	for index := 0; index < mergedValue.NumField(); index++ {
+		name := strings.Split(mergedValue.Type().Field(index).Tag.Get("json"), ",")[0]
+		if len(name) < 1 || name == "-" {
+			continue
+		}
+		if reflect.DeepEqual(mergedValue.Field(index).Interface(), freshValue.Field(index).Interface()) {
+			continue
+		}
+		if reloadable[name] {
+			mergedValue.Field(index).Set(freshValue.Field(index))
+		} else {
+			restart = append(restart, name)
+		}
+	}
+	sort.Strings(restart)
+	return merged, restart
+}
+
+// Reloader reloads the configuration when the process receives SIGHUP and, when
+// a watch interval is configured, whenever its file or its secret files change
+type Reloader struct {
+	mutex    sync.Mutex
+	current  *communicator.EnvironmentConfiguration
+	apply    func(configuration *communicator.EnvironmentConfiguration)
+	interval time.Duration
+	pending  string
+}
+
+// NewReloader creates a reloader of the configuration(param: configuration) handing every
+// changed configuration to the function(param: apply). The configuration is never modified
+func NewReloader(configuration *communicator.EnvironmentConfiguration,
+	apply func(configuration *communicator.EnvironmentConfiguration)) *Reloader {
+
+	return &Reloader{
+		current:  configuration,
+		apply:    apply,
+		interval: time.Duration(configuration.ConfigWatchInterval) * time.Second,
+	}
+}
+
+// Reload loads the configuration again, applying its reloadable settings when they changed.
+// An invalid configuration is not applied, the current one being kept
+func (reloader *Reloader) Reload() error {
+	fresh := &communicator.EnvironmentConfiguration{}
+	if err := Load(fresh); err != nil {
+		return err
+	}
+	reloader.mutex.Lock()
+	defer reloader.mutex.Unlock()
+	merged, restart := Merge(reloader.current, fresh)
+	if pending := strings.Join(restart, ", "); pending != reloader.pending {
+		reloader.pending = pending
+		if len(pending) > 0 {
+			LOG.Warn("Configuration changes need a restart", LOG.Fields{"settings": pending})
+		}
+	}
+	if proto.Equal(merged, reloader.current) {
+		return nil
+	}
+	reloader.current = merged
+	reloader.apply(merged)
+	LOG.Info("Configuration reloaded", nil)
+	return nil
+}
+
+// Run reloads the configuration on SIGHUP, and on every watch interval when one is
+// configured, until the channel(param: stop) is closed
+func (reloader *Reloader) Run(stop <-chan struct{}) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+	var ticks <-chan time.Time
+	if reloader.interval > 0 {
+		ticker := time.NewTicker(reloader.interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hangups:
+		case <-ticks:
+		}
+		if err := reloader.Reload(); err != nil {
+			LOG.Error("Configuration not reloaded", LOG.Fields{"error": err})
+		}
+	}
+}
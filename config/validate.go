@@ -0,0 +1,168 @@
+package config
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-communicator/trace"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ValidationError lists every invalid setting of a configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (err *ValidationError) Error() string {
+	return "Invalid configuration:\n  - " + strings.Join(err.Problems, "\n  - ")
+}
+
+// Normalize cleans the settings of the configuration(param: configuration) up, making
+// sure the base URL ends with a slash since the endpoints are appended to its path
+func Normalize(configuration *communicator.EnvironmentConfiguration) {
+	configuration.Url = strings.TrimSpace(configuration.Url)
+	if len(configuration.Url) > 0 && !strings.HasSuffix(configuration.Url, "/") {
+		configuration.Url += "/"
+	}
+	configuration.Token = strings.TrimSpace(configuration.Token)
+	configuration.OauthUrl = strings.TrimSpace(configuration.OauthUrl)
+	configuration.LogLevel = strings.ToLower(strings.TrimSpace(configuration.LogLevel))
+	configuration.TraceExporter = strings.ToLower(strings.TrimSpace(configuration.TraceExporter))
+	configuration.HttpMode = strings.ToLower(strings.TrimSpace(configuration.HttpMode))
+}
+
+// Validate returns a ValidationError listing the invalid settings of the configuration(param: configuration),
+// or nil when the service can start with it
+func Validate(configuration *communicator.EnvironmentConfiguration) error {
+	validation := &ValidationError{}
+	validation.checkURL("url", configuration.Url, configuration.MemoryEnabled == false)
+	if len(configuration.Token) < 1 && configuration.OauthEnabled == false && configuration.TenantRequired == false &&
+		configuration.HttpMode != API.ReplayMode && configuration.MemoryEnabled == false {
+		validation.add("token is required unless OAuth is enabled, callers must pass their own tokens, " +
+			"responses are replayed or the in-memory backend is enabled")
+	}
+	validation.checkNotNegative("request_timeout", configuration.RequestTimeout)
+	validation.checkNotNegative("config_watch_interval", configuration.ConfigWatchInterval)
+	validation.checkNotNegative("cache_size", configuration.CacheSize)
+	validation.checkNotNegative("cache_projects_ttl", configuration.CacheProjectsTtl)
+	validation.checkNotNegative("cache_tasks_ttl", configuration.CacheTasksTtl)
+	validation.checkNotNegative("cache_users_ttl", configuration.CacheUsersTtl)
+	validation.checkNotNegative("cache_timeentries_ttl", configuration.CacheTimeentriesTtl)
+	validation.checkNotNegative("mirror_interval", configuration.MirrorInterval)
+	validation.checkNotNegative("mirror_staleness", configuration.MirrorStaleness)
+	validation.checkNotNegative("mirror_full_sync_interval", configuration.MirrorFullSyncInterval)
+	validation.checkAddress("webhook_address", configuration.WebhookAddress)
+	validation.checkAddress("gateway_address", configuration.GatewayAddress)
+	validation.checkAddress("graphql_address", configuration.GraphqlAddress)
+	validation.checkAddress("oauth_address", configuration.OauthAddress)
+	validation.checkAddress("metrics_address", configuration.MetricsAddress)
+	validation.checkAddress("health_address", configuration.HealthAddress)
+	validation.checkAddress("memory_address", configuration.MemoryAddress)
+	if configuration.OauthEnabled == true {
+		validation.checkURL("oauth_url", configuration.OauthUrl, false)
+		validation.checkRequired("oauth_client_id", configuration.OauthClientId)
+		validation.checkRequired("oauth_client_secret", configuration.OauthClientSecret)
+		validation.checkURL("oauth_redirect_url", configuration.OauthRedirectUrl, true)
+		validation.checkRequired("oauth_admin_key", configuration.OauthAdminKey)
+	}
+	if configuration.AuthEnabled == true {
+		if len(configuration.AuthJwtSecret) < 1 && len(configuration.AuthApiKeys) < 1 {
+			validation.add("auth_jwt_secret or auth_api_keys is required when auth is enabled")
+		}
+		if len(configuration.AuthPolicyPath) > 0 {
+			if _, err := os.Stat(configuration.AuthPolicyPath); err != nil {
+				validation.add("auth_policy_path cannot be read: " + err.Error())
+			}
+		}
+		// The gateway and the GraphQL endpoint reach the resources without going through the RPC wrappers
+		if configuration.GatewayEnabled == true || configuration.GraphqlEnabled == true {
+			validation.add("gateway_enabled and graphql_enabled are not authenticated and cannot be combined with auth_enabled")
+		}
+	}
+	if len(configuration.LogLevel) > 0 {
+		if _, err := LOG.ParseLevel(configuration.LogLevel); err != nil {
+			validation.add("log_level must be one of debug, info, warn or error")
+		}
+	}
+	switch configuration.TraceExporter {
+	case "", trace.StdoutExporterName:
+	case trace.OTLPExporterName:
+		validation.checkURL("trace_otlp_endpoint", configuration.TraceOtlpEndpoint, false)
+	default:
+		validation.add("trace_exporter must be " + trace.StdoutExporterName + " or " + trace.OTLPExporterName)
+	}
+	switch configuration.HttpMode {
+	case API.LiveMode, API.RecordMode:
+	case API.ReplayMode:
+		directory := configuration.CassetteDir
+		if len(directory) < 1 {
+			directory = API.DefaultCassetteDir
+		}
+		if _, err := os.Stat(directory); err != nil {
+			validation.add("cassette_dir cannot be read: " + err.Error())
+		}
+	default:
+		validation.add("http_mode must be " + API.RecordMode + " or " + API.ReplayMode)
+	}
+	if configuration.MemoryEnabled == true {
+		if configuration.MirrorEnabled == true || configuration.EventsEnabled == true {
+			validation.add("mirror_enabled and events_enabled sync from Mavenlink and cannot be combined with memory_enabled")
+		}
+		if len(configuration.MemorySeedDir) > 0 {
+			if _, err := os.Stat(configuration.MemorySeedDir); err != nil {
+				validation.add("memory_seed_dir cannot be read: " + err.Error())
+			}
+		}
+	}
+	if len(validation.Problems) > 0 {
+		return validation
+	}
+	return nil
+}
+
+func (validation *ValidationError) add(problem string) {
+	validation.Problems = append(validation.Problems, problem)
+}
+
+func (validation *ValidationError) checkRequired(name string, value string) {
+	if len(value) < 1 {
+		validation.add(name + " is required")
+	}
+}
+
+// checkURL checks that the value(param: value) of a setting is an absolute HTTP URL, when set unless required(param: required)
+func (validation *ValidationError) checkURL(name string, value string, required bool) {
+	if len(value) < 1 {
+		if required {
+			validation.add(name + " is required")
+		}
+		return
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) < 1 {
+		validation.add(name + " must be an absolute http or https URL, got " + value)
+		return
+	}
+	if len(parsed.RawQuery) > 0 || len(parsed.Fragment) > 0 {
+		validation.add(name + " must not have a query or a fragment")
+	}
+}
+
+func (validation *ValidationError) checkNotNegative(name string, value int32) {
+	if value < 0 {
+		validation.add(name + " must not be negative")
+	}
+}
+
+// checkAddress checks that the value(param: value) of a setting, when set, is a host:port address
+func (validation *ValidationError) checkAddress(name string, value string) {
+	if len(value) < 1 {
+		return
+	}
+	if _, _, err := net.SplitHostPort(value); err != nil {
+		validation.add(name + " must be a host:port address such as :8080, got " + value)
+	}
+}
@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	"github.com/desertjinn/mavenlink-communicator/mirror"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro"
+	"github.com/micro/go-micro/client"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// Topics the change events are published on
+const (
+	TaskCreatedTopic      = "costrategix.service.mavenlink.communicator.task.created"
+	TaskStateChangedTopic = "costrategix.service.mavenlink.communicator.task.state_changed"
+	TimeEntryLoggedTopic  = "costrategix.service.mavenlink.communicator.timeentry.logged"
+	ProjectArchivedTopic  = "costrategix.service.mavenlink.communicator.project.archived"
+	ResourceChangedTopic  = "costrategix.service.mavenlink.communicator.resource.changed"
+)
+
+// Time allowed to hand a single event to the broker
+const publishTimeout = 10 * time.Second
+
+// Number of events waiting to be published beyond which new events are dropped
+const queueSize = 10000
+
+// Publisher turns the records changed by the mirror's syncs into events
+// published on the broker of the go-micro client. Events are queued and
+// published in the background so that an unavailable broker does not hold
+// the syncs back
+type Publisher struct {
+	store            *mirror.Store
+	queue            chan queuedEvent
+	taskCreated      micro.Publisher
+	taskStateChanged micro.Publisher
+	timeEntryLogged  micro.Publisher
+	projectArchived  micro.Publisher
+}
+
+// queuedEvent is an event waiting to be published on the topic of its publisher
+type queuedEvent struct {
+	publisher micro.Publisher
+	event     interface{}
+}
+
+// NewPublisher creates a publisher sending events through the client(param: c),
+// looking the users referenced by the changed records up in the store(param: store)
+func NewPublisher(c client.Client, store *mirror.Store) *Publisher {
+	return &Publisher{
+		store:            store,
+		queue:            make(chan queuedEvent, queueSize),
+		taskCreated:      micro.NewPublisher(TaskCreatedTopic, c),
+		taskStateChanged: micro.NewPublisher(TaskStateChangedTopic, c),
+		timeEntryLogged:  micro.NewPublisher(TimeEntryLoggedTopic, c),
+		projectArchived:  micro.NewPublisher(ProjectArchivedTopic, c),
+	}
+}
+
+// Run publishes the queued events one at a time until the channel(param: stop) is closed.
+// Events the broker fails to take are logged and dropped
+func (publisher *Publisher) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case queued := <-publisher.queue:
+			if err := publish(queued.publisher, queued.event); err != nil {
+				LOG.Error("Event not published", LOG.Fields{"error": err})
+			}
+		}
+	}
+}
+
+// WorkspaceChanged publishes a ProjectArchived event when a workspace gets archived
+func (publisher *Publisher) WorkspaceChanged(previous *communicator.MavenlinkWorkspace,
+	current *communicator.MavenlinkWorkspace) error {
+
+	if !current.Archived || (previous != nil && previous.Archived) {
+		return nil
+	}
+	event := new(communicator.ProjectArchived)
+	event.Project = API.WorkspaceToProject(current)
+	event.DetectedAt = detectedAt()
+	return publisher.enqueue(publisher.projectArchived, event)
+}
+
+// StoryChanged publishes a TaskCreated event for new stories and a
+// TaskStateChanged event for the stories whose state changed
+func (publisher *Publisher) StoryChanged(previous *communicator.MavenlinkStory,
+	current *communicator.MavenlinkStory) error {
+
+	if previous != nil && previous.State == current.State {
+		return nil
+	}
+	task := API.StoryToTask(current)
+	for _, assignee := range current.AssigneeIds {
+		user, userErr := publisher.store.User(assignee)
+		if userErr != nil {
+			return userErr
+		}
+		if user != nil {
+			task.User = API.MavenlinkUserToUser(user)
+		}
+	}
+	if previous == nil {
+		event := new(communicator.TaskCreated)
+		event.Task = task
+		event.DetectedAt = detectedAt()
+		return publisher.enqueue(publisher.taskCreated, event)
+	}
+	event := new(communicator.TaskStateChanged)
+	event.Task = task
+	event.PreviousState = previous.State
+	event.State = current.State
+	event.DetectedAt = detectedAt()
+	return publisher.enqueue(publisher.taskStateChanged, event)
+}
+
+// TimeEntryChanged publishes a TimeEntryLogged event for new time entries
+func (publisher *Publisher) TimeEntryChanged(previous *communicator.MavenlinkTimeentry,
+	current *communicator.MavenlinkTimeentry) error {
+
+	if previous != nil {
+		return nil
+	}
+	timeentry := API.TimeEntryToTimeentry(current)
+	user, userErr := publisher.store.User(current.UserId)
+	if userErr != nil {
+		return userErr
+	}
+	if user != nil {
+		timeentry.User = API.MavenlinkUserToUser(user)
+	}
+	event := new(communicator.TimeEntryLogged)
+	event.TimeEntry = timeentry
+	event.DetectedAt = detectedAt()
+	return publisher.enqueue(publisher.timeEntryLogged, event)
+}
+
+// enqueue queues the event(param: event) for the publisher of its topic(param: topic), failing
+// without waiting when the queue is full
+func (publisher *Publisher) enqueue(topic micro.Publisher, event interface{}) error {
+	select {
+	case publisher.queue <- queuedEvent{publisher: topic, event: event}:
+		return nil
+	default:
+		return errors.New("Too many events waiting for the broker, dropping the event")
+	}
+}
+
+func publish(publisher micro.Publisher, event interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	return publisher.Publish(ctx, event)
+}
+
+func detectedAt() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"github.com/desertjinn/mavenlink-communicator/mirror"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro/client"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// blockingPublisher hands the events it is given to a channel, waiting until they are received
+type blockingPublisher struct {
+	published chan interface{}
+}
+
+func (publisher *blockingPublisher) Publish(ctx context.Context, msg interface{}, opts ...client.PublishOption) error {
+	select {
+	case publisher.published <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestChangesDoNotWaitForTheBroker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store, err := mirror.Open(filepath.Join(dir, "mirror.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	broker := &blockingPublisher{published: make(chan interface{})}
+	publisher := &Publisher{store: store, queue: make(chan queuedEvent, 1), taskCreated: broker, taskStateChanged: broker}
+
+	// Changes are queued while nothing publishes them, and dropped once the queue is full
+	created := &communicator.MavenlinkStory{Id: "1", State: "not started"}
+	if err := publisher.StoryChanged(nil, created); err != nil {
+		t.Fatal(err)
+	}
+	started := &communicator.MavenlinkStory{Id: "1", State: "started"}
+	if err := publisher.StoryChanged(created, started); err == nil {
+		t.Error("expected the event to be dropped from a full queue")
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go publisher.Run(stop)
+	select {
+	case event := <-broker.published:
+		if taskCreated, ok := event.(*communicator.TaskCreated); !ok || taskCreated.Task.Id != "1" {
+			t.Errorf("unexpected event %v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the queued event to be published")
+	}
+}
@@ -0,0 +1,245 @@
+package gateway
+
+import (
+	"encoding/json"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	microErrors "github.com/micro/go-micro/errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8080"
+
+// Page size used when the client does not ask for one, and the largest one accepted
+const (
+	defaultPerPage = 50
+	maxPerPage     = 200
+)
+
+// Gateway exposes the RPCs of the service as resource-style HTTP JSON routes
+type Gateway struct {
+	handler communicator.MavenlinkCommunicatorHandler
+	routes  []route
+	spec    []byte
+	specErr error
+	once    sync.Once
+}
+
+// route is served when the method(param: method) and every segment of the
+// path match its pattern. Segments starting with ":" match any value
+type route struct {
+	method     string
+	pattern    []string
+	operations []operation
+	serve      func(w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+// How a route returns the response of an RPC
+const (
+	singleKind = "single"
+	listKind   = "list"
+	batchKind  = "batch"
+	streamKind = "stream"
+)
+
+// operation describes the RPC(param: rpc) served by a route and the field of
+// its response(param: field) returned as the data of the route. The messages
+// of streams and of lists collected from streams are described by the RPC
+type operation struct {
+	rpc   string
+	kind  string
+	field string
+}
+
+// retryable is implemented by the errors of the RPCs Mavenlink asked to retry after a delay
+type retryable interface {
+	RetryAfter() time.Duration
+}
+
+// listMeta describes the page of a list returned by the gateway
+type listMeta struct {
+	Page      int `json:"page"`
+	PerPage   int `json:"per_page"`
+	Total     int `json:"total"`
+	PageCount int `json:"page_count"`
+}
+
+// New creates a gateway calling the RPC handlers(param: handler) of the
+// service in process, configured by the configuration(param: configuration)
+func New(handler communicator.MavenlinkCommunicatorHandler,
+	configuration *communicator.EnvironmentConfiguration) *Gateway {
+
+	gateway := &Gateway{handler: handler}
+	gateway.routes = gateway.routeTable()
+	return gateway
+}
+
+// ListenAndServe serves the gateway on the address of the configuration(param: configuration)
+// until the listener fails
+func ListenAndServe(handler communicator.MavenlinkCommunicatorHandler,
+	configuration *communicator.EnvironmentConfiguration) error {
+
+	address := configuration.GatewayAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	return http.ListenAndServe(address, New(handler, configuration))
+}
+
+func (gateway *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+	pathFound := false
+	for _, candidate := range gateway.routes {
+		params, ok := match(candidate.pattern, segments)
+		if !ok {
+			continue
+		}
+		pathFound = true
+		if candidate.method == r.Method {
+			candidate.serve(w, r, params)
+			return
+		}
+	}
+	if pathFound {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "No route for "+r.URL.Path)
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if len(segment) > 0 {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// match returns the values of the parameters of the pattern(param: pattern)
+// when it matches the segments of a path(param: segments)
+func match(pattern []string, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for index, part := range pattern {
+		if strings.HasPrefix(part, ":") {
+			params[part[1:]] = segments[index]
+		} else if part != segments[index] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// request builds the RPC request shared by every route from the query of the HTTP request(param: r)
+func request(r *http.Request) *communicator.Request {
+	query := r.URL.Query()
+	req := new(communicator.Request)
+	req.BypassCache, _ = strconv.ParseBool(query.Get("bypass_cache"))
+	req.IncludeLoggedTime, _ = strconv.ParseBool(query.Get("include_logged_time"))
+	req.IncludeDependencies, _ = strconv.ParseBool(query.Get("include_dependencies"))
+	if perPage, err := strconv.Atoi(query.Get("per_page")); err == nil && perPage > 0 {
+		req.PerPage = int32(perPage)
+	}
+	for _, ids := range query["ids"] {
+		for _, id := range strings.Split(ids, ",") {
+			if len(id) > 0 {
+				req.Ids = append(req.Ids, id)
+			}
+		}
+	}
+	return req
+}
+
+// page returns the page number and size requested with the page and per_page
+// query parameters of the HTTP request(param: r), falling back on the defaults
+func page(r *http.Request) (int, int) {
+	query := r.URL.Query()
+	number, err := strconv.Atoi(query.Get("page"))
+	if err != nil || number < 1 {
+		number = 1
+	}
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	return number, perPage
+}
+
+// writeList writes the requested page of a list with a total of items(param: total),
+// calling the callback(param: slice) to select the items of the page
+func writeList(w http.ResponseWriter, r *http.Request, total int, slice func(start int, end int) interface{}) {
+	number, perPage := page(r)
+	start := (number - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+	meta := listMeta{Page: number, PerPage: perPage, Total: total, PageCount: (total + perPage - 1) / perPage}
+	var data interface{} = []interface{}{}
+	if start < end {
+		data = slice(start, end)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "meta": meta})
+}
+
+// writeData writes a single resource, or the resources found by a batch lookup with the IDs not found
+func writeData(w http.ResponseWriter, data interface{}, notFound []string) {
+	body := map[string]interface{}{"data": data}
+	if notFound != nil {
+		body["not_found"] = notFound
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// writeRPCError writes the error(param: err) returned by an RPC handler with the status of its code,
+// telling callers when to retry the calls Mavenlink rejected for the time being
+func (gateway *Gateway) writeRPCError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	// Errors are decoded as RPC clients do, whatever type carries them
+	rpcErr := microErrors.Parse(err.Error())
+	if rpcErr.Code >= 400 && rpcErr.Code < 600 {
+		status = int(rpcErr.Code)
+	}
+	if status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable {
+		var delay time.Duration
+		if retryable, ok := err.(retryable); ok {
+			delay = retryable.RetryAfter()
+		}
+		seconds := int((delay + time.Second - 1) / time.Second)
+		if seconds < 1 && status == http.StatusTooManyRequests {
+			seconds = 1
+		}
+		if seconds > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		}
+	}
+	LOG.Debug("Gateway request failed", LOG.Fields{"url": r.URL.String(), "error": err})
+	writeError(w, status, rpcErr.Detail)
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": &communicator.Error{Code: int32(status), Description: description},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
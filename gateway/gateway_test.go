@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	microErrors "github.com/micro/go-micro/errors"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// failingHandler fails every call listing the projects with its error
+type failingHandler struct {
+	communicator.MavenlinkCommunicatorHandler
+	err error
+}
+
+func (handler *failingHandler) GetAllProjects(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	return handler.err
+}
+
+// delayedError is an RPC error telling when to retry, as the service returns for rate limited calls
+type delayedError struct {
+	rpcErr error
+	delay  time.Duration
+}
+
+func (err *delayedError) Error() string {
+	return err.rpcErr.Error()
+}
+
+func (err *delayedError) RetryAfter() time.Duration {
+	return err.delay
+}
+
+func TestRPCErrorStatuses(t *testing.T) {
+	delayed := func(code int32, delay time.Duration) error {
+		return &delayedError{microErrors.New("test", "rejected", code), delay}
+	}
+	cases := []struct {
+		name       string
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"not found", microErrors.NotFound("test", "no project"), http.StatusNotFound, ""},
+		{"rate limited", delayed(http.StatusTooManyRequests, 2500*time.Millisecond), http.StatusTooManyRequests, "3"},
+		{"rate limited without delay", delayed(http.StatusTooManyRequests, 0), http.StatusTooManyRequests, "1"},
+		{"unavailable", delayed(http.StatusServiceUnavailable, 0), http.StatusServiceUnavailable, ""},
+		{"unavailable with delay", delayed(http.StatusServiceUnavailable, time.Minute), http.StatusServiceUnavailable, "60"},
+		{"bad gateway", microErrors.New("test", "upstream failed", http.StatusBadGateway), http.StatusBadGateway, ""},
+		{"unknown", errors.New("broken"), http.StatusInternalServerError, ""},
+	}
+	for _, test := range cases {
+		gateway := New(&failingHandler{err: test.err}, &communicator.EnvironmentConfiguration{})
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, httptest.NewRequest("GET", "/projects", nil))
+		var body struct {
+			Error communicator.Error
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if w.Code != test.status || body.Error.Code != int32(test.status) {
+			t.Errorf("%s: expected %d, got %d %s", test.name, test.status, w.Code, w.Body.String())
+		}
+		if retryAfter := w.Header().Get("Retry-After"); retryAfter != test.retryAfter {
+			t.Errorf("%s: expected Retry-After %q, got %q", test.name, test.retryAfter, retryAfter)
+		}
+		if len(body.Error.Description) < 1 || body.Error.Description[0] == '{' {
+			t.Errorf("%s: expected the detail of the error, got %q", test.name, body.Error.Description)
+		}
+	}
+}
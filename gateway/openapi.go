@@ -0,0 +1,317 @@
+package gateway
+
+import (
+	"encoding/json"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/golang/protobuf/descriptor"
+	protobuf "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+// Prefix of the references to the schemas of the document
+const schemaRef = "#/components/schemas/"
+
+// openAPI serves the OpenAPI document describing the routes of the gateway.
+// The document is generated once from the proto definition of the service
+func (gateway *Gateway) openAPI(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	gateway.once.Do(func() {
+		gateway.spec, gateway.specErr = gateway.buildSpec()
+	})
+	if gateway.specErr != nil {
+		gateway.writeRPCError(w, r, gateway.specErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(gateway.spec)
+}
+
+// specBuilder holds the proto definition the OpenAPI document is generated from
+type specBuilder struct {
+	pkg      string
+	messages map[string]*protobuf.DescriptorProto
+	methods  map[string]*protobuf.MethodDescriptorProto
+	response map[string]*protobuf.FieldDescriptorProto
+}
+
+// buildSpec generates the OpenAPI document of the routes from the proto definition of the service
+func (gateway *Gateway) buildSpec() ([]byte, error) {
+	file, _ := descriptor.ForMessage(&communicator.Request{})
+	if len(file.Service) < 1 {
+		return nil, errors.New("The proto definition does not describe a service")
+	}
+	builder := &specBuilder{
+		pkg:      file.GetPackage(),
+		messages: make(map[string]*protobuf.DescriptorProto),
+		methods:  make(map[string]*protobuf.MethodDescriptorProto),
+		response: make(map[string]*protobuf.FieldDescriptorProto),
+	}
+	schemas := make(map[string]interface{})
+	for _, message := range file.MessageType {
+		builder.addMessage("."+builder.pkg, message)
+	}
+	for _, message := range file.MessageType {
+		schemas[message.GetName()] = builder.messageSchema(message)
+	}
+	for _, method := range file.Service[0].Method {
+		builder.methods[method.GetName()] = method
+	}
+	for _, field := range builder.messages["."+builder.pkg+".Response"].GetField() {
+		builder.response[field.GetName()] = field
+	}
+	schemas["ListMeta"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"page":       map[string]interface{}{"type": "integer"},
+			"per_page":   map[string]interface{}{"type": "integer"},
+			"total":      map[string]interface{}{"type": "integer"},
+			"page_count": map[string]interface{}{"type": "integer"},
+		},
+	}
+	schemas["ErrorResponse"] = map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{"error": map[string]interface{}{"$ref": schemaRef + "Error"}},
+	}
+
+	paths := make(map[string]interface{})
+	operationIds := builder.operationIds(gateway.routes)
+	for index, candidate := range gateway.routes {
+		if len(candidate.operations) < 1 {
+			continue
+		}
+		item, itemErr := builder.pathItem(candidate, operationIds[index])
+		if itemErr != nil {
+			return nil, itemErr
+		}
+		paths[openAPIPath(candidate.pattern)] = item
+	}
+	return json.Marshal(map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":       "Mavenlink Communicator",
+			"description": "HTTP JSON gateway of the " + file.Service[0].GetName() + " service",
+			"version":     "v1",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	})
+}
+
+// addMessage indexes a message(param: message) and its nested messages by their fully qualified name
+func (builder *specBuilder) addMessage(scope string, message *protobuf.DescriptorProto) {
+	name := scope + "." + message.GetName()
+	builder.messages[name] = message
+	for _, nested := range message.NestedType {
+		builder.addMessage(name, nested)
+	}
+}
+
+func (builder *specBuilder) messageSchema(message *protobuf.DescriptorProto) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, field := range message.Field {
+		properties[field.GetName()] = builder.fieldSchema(field)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (builder *specBuilder) fieldSchema(field *protobuf.FieldDescriptorProto) map[string]interface{} {
+	var schema map[string]interface{}
+	switch field.GetType() {
+	case protobuf.FieldDescriptorProto_TYPE_MESSAGE:
+		message := builder.messages[field.GetTypeName()]
+		if message.GetOptions().GetMapEntry() {
+			return map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": builder.fieldSchema(message.Field[1]),
+			}
+		}
+		schema = map[string]interface{}{"$ref": schemaRef + builder.shortName(field.GetTypeName())}
+	case protobuf.FieldDescriptorProto_TYPE_BOOL:
+		schema = map[string]interface{}{"type": "boolean"}
+	case protobuf.FieldDescriptorProto_TYPE_STRING:
+		schema = map[string]interface{}{"type": "string"}
+	case protobuf.FieldDescriptorProto_TYPE_BYTES:
+		schema = map[string]interface{}{"type": "string", "format": "byte"}
+	case protobuf.FieldDescriptorProto_TYPE_DOUBLE:
+		schema = map[string]interface{}{"type": "number", "format": "double"}
+	case protobuf.FieldDescriptorProto_TYPE_FLOAT:
+		schema = map[string]interface{}{"type": "number", "format": "float"}
+	case protobuf.FieldDescriptorProto_TYPE_INT64, protobuf.FieldDescriptorProto_TYPE_UINT64,
+		protobuf.FieldDescriptorProto_TYPE_SINT64, protobuf.FieldDescriptorProto_TYPE_FIXED64,
+		protobuf.FieldDescriptorProto_TYPE_SFIXED64:
+		schema = map[string]interface{}{"type": "integer", "format": "int64"}
+	default:
+		schema = map[string]interface{}{"type": "integer", "format": "int32"}
+	}
+	if field.GetLabel() == protobuf.FieldDescriptorProto_LABEL_REPEATED {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// shortName returns the name of the schema of a message from its fully qualified name(param: typeName)
+func (builder *specBuilder) shortName(typeName string) string {
+	return strings.TrimPrefix(typeName, "."+builder.pkg+".")
+}
+
+// operationIds names the operation of every route after its RPC. Routes sharing
+// an RPC are told apart by the literal segments of their path
+func (builder *specBuilder) operationIds(routes []route) []string {
+	uses := make(map[string]int)
+	for _, candidate := range routes {
+		if len(candidate.operations) > 0 {
+			uses[candidate.operations[0].rpc]++
+		}
+	}
+	ids := make([]string, len(routes))
+	for index, candidate := range routes {
+		if len(candidate.operations) < 1 {
+			continue
+		}
+		id := candidate.operations[0].rpc
+		if uses[id] > 1 {
+			for _, segment := range candidate.pattern {
+				if !strings.HasPrefix(segment, ":") {
+					id += camelCase(segment)
+				}
+			}
+		}
+		ids[index] = id
+	}
+	return ids
+}
+
+// pathItem describes a route(param: candidate) with its parameters and the responses of its operations
+func (builder *specBuilder) pathItem(candidate route, operationId string) (map[string]interface{}, error) {
+	var parameters []interface{}
+	for _, segment := range candidate.pattern {
+		if strings.HasPrefix(segment, ":") {
+			parameters = append(parameters, parameter(segment[1:], "path", "string", true))
+		}
+	}
+
+	var rpcs []string
+	var jsonSchemas []interface{}
+	var streamSchema interface{}
+	kinds := make(map[string]bool)
+	bypassCache := false
+	for _, described := range candidate.operations {
+		method, ok := builder.methods[described.rpc]
+		if !ok {
+			return nil, errors.Errorf("The service does not define the %s RPC", described.rpc)
+		}
+		rpcs = append(rpcs, described.rpc)
+		kinds[described.kind] = true
+		switch builder.shortName(method.GetInputType()) {
+		case "Request":
+			bypassCache = true
+		case "HealthRequest":
+			parameters = append(parameters, parameter("probe", "query", "boolean", false))
+		}
+		if described.rpc == "GetTaskTree" {
+			parameters = append(parameters, parameter("include_logged_time", "query", "boolean", false))
+		}
+		if described.field == "tasks" {
+			parameters = append(parameters, parameter("include_dependencies", "query", "boolean", false))
+		}
+		output := map[string]interface{}{"$ref": schemaRef + builder.shortName(method.GetOutputType())}
+		if described.kind == streamKind {
+			streamSchema = output
+			continue
+		}
+		data := output
+		if field, ok := builder.response[described.field]; ok {
+			data = builder.fieldSchema(field)
+		} else if described.kind == listKind {
+			data = map[string]interface{}{"type": "array", "items": output}
+		}
+		properties := map[string]interface{}{"data": data}
+		switch described.kind {
+		case listKind:
+			properties["meta"] = map[string]interface{}{"$ref": schemaRef + "ListMeta"}
+		case batchKind:
+			properties["not_found"] = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+		}
+		jsonSchemas = append(jsonSchemas, map[string]interface{}{"type": "object", "properties": properties})
+	}
+	if bypassCache {
+		parameters = append(parameters, parameter("bypass_cache", "query", "boolean", false))
+	}
+	if kinds[listKind] || kinds[streamKind] {
+		parameters = append(parameters, parameter("per_page", "query", "integer", false))
+	}
+	if kinds[listKind] {
+		parameters = append(parameters, parameter("page", "query", "integer", false))
+	}
+	if kinds[batchKind] {
+		parameters = append(parameters, parameter("ids", "query", "string", !kinds[listKind]))
+	}
+
+	content := make(map[string]interface{})
+	switch len(jsonSchemas) {
+	case 0:
+	case 1:
+		content["application/json"] = map[string]interface{}{"schema": jsonSchemas[0]}
+	default:
+		content["application/json"] = map[string]interface{}{"schema": map[string]interface{}{"oneOf": jsonSchemas}}
+	}
+	if streamSchema != nil {
+		content["application/x-ndjson"] = map[string]interface{}{"schema": streamSchema}
+	}
+	description := "Serves the " + strings.Join(rpcs, " RPC, or the ") + " RPC"
+	if kinds[batchKind] && kinds[listKind] {
+		description += " when the ids query parameter is provided"
+	}
+	return map[string]interface{}{
+		strings.ToLower(candidate.method): map[string]interface{}{
+			"operationId": operationId,
+			"description": description,
+			"parameters":  parameters,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{"description": "Success", "content": content},
+				"default": map[string]interface{}{
+					"description": "Error",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": schemaRef + "ErrorResponse"},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func parameter(name string, in string, kind string, required bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     name,
+		"in":       in,
+		"required": required,
+		"schema":   map[string]interface{}{"type": kind},
+	}
+}
+
+// openAPIPath writes the pattern of a route(param: pattern) with OpenAPI's path parameters
+func openAPIPath(pattern []string) string {
+	var segments []string
+	for _, segment := range pattern {
+		if strings.HasPrefix(segment, ":") {
+			segment = "{" + segment[1:] + "}"
+		}
+		segments = append(segments, segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// camelCase joins the dash separated words of a path segment(param: segment) in camel case
+func camelCase(segment string) string {
+	var words []string
+	for _, word := range strings.Split(segment, "-") {
+		if len(word) > 0 {
+			words = append(words, strings.ToUpper(word[:1])+word[1:])
+		}
+	}
+	return strings.Join(words, "")
+}
@@ -0,0 +1,215 @@
+package gateway
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-communicator/tenant"
+	"golang.org/x/net/context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// rpc is the signature shared by the unary RPC handlers
+type rpc func(ctx context.Context, req *communicator.Request, res *communicator.Response) error
+
+// routeTable maps the resource-style routes onto the RPCs of the service
+func (gateway *Gateway) routeTable() []route {
+	handler := gateway.handler
+	return []route{
+		{"GET", path("openapi.json"), nil, gateway.openAPI},
+		{"GET", path("projects"), []operation{{"GetAllProjects", listKind, "projects"},
+			{"GetProjectsByIds", batchKind, "projectsById"}}, gateway.projects},
+		{"GET", path("projects/:project"), []operation{{"GetProjectById", singleKind, "project"}},
+			gateway.single(handler.GetProjectById, func(res *communicator.Response) interface{} { return res.Project })},
+		{"GET", path("projects/:project/tasks"), []operation{{"GetTasksByProjectId", listKind, "tasks"}},
+			gateway.list(handler.GetTasksByProjectId, func(res *communicator.Response) listItems { return taskItems(res.Tasks) })},
+		{"GET", path("projects/:project/tasks/:task/subtasks"), []operation{{"GetSubTasksByParentTaskAndProjectId", listKind, "tasks"}},
+			gateway.list(handler.GetSubTasksByParentTaskAndProjectId, func(res *communicator.Response) listItems { return taskItems(res.Tasks) })},
+		{"GET", path("projects/:project/tasks/:task/subtasks/:subtask/tasks"), []operation{{"GetTasksBySubTaskParentTaskAndProjectId", listKind, "tasks"}},
+			gateway.list(handler.GetTasksBySubTaskParentTaskAndProjectId, func(res *communicator.Response) listItems { return taskItems(res.Tasks) })},
+		{"GET", path("projects/:project/tasks/:task/time-entries"), []operation{{"GetTimeentries", listKind, "timeentries"}},
+			gateway.list(handler.GetTimeentries, func(res *communicator.Response) listItems { return timeentryItems(res.Timeentries) })},
+		{"GET", path("projects/:project/time-entries"), []operation{{"StreamTimeEntries", listKind, ""}},
+			gateway.projectTimeEntries},
+		{"GET", path("projects/:project/users"), []operation{{"GetUsers", listKind, "users"}},
+			gateway.list(handler.GetUsers, func(res *communicator.Response) listItems { return userItems(res.Users) })},
+		{"GET", path("projects/:project/users/:user"), []operation{{"GetUser", singleKind, "user"}},
+			gateway.single(handler.GetUser, func(res *communicator.Response) interface{} { return res.User })},
+		{"GET", path("projects/:project/critical-path"), []operation{{"GetCriticalPathByProjectId", singleKind, "criticalPath"}},
+			gateway.single(handler.GetCriticalPathByProjectId, func(res *communicator.Response) interface{} { return res.CriticalPath })},
+		{"GET", path("projects/:project/task-tree"), []operation{{"GetTaskTree", listKind, "taskTree"}},
+			gateway.list(handler.GetTaskTree, func(res *communicator.Response) listItems { return taskNodeItems(res.TaskTree) })},
+		{"GET", path("tasks"), []operation{{"GetTasksByIds", batchKind, "tasksById"}},
+			gateway.batch(handler.GetTasksByIds, func(res *communicator.Response) interface{} { return res.TasksById })},
+		{"GET", path("users"), []operation{{"GetUsersByIds", batchKind, "usersById"}},
+			gateway.batch(handler.GetUsersByIds, func(res *communicator.Response) interface{} { return res.UsersById })},
+		{"GET", path("users/:user"), []operation{{"GetUser", singleKind, "user"}},
+			gateway.single(handler.GetUser, func(res *communicator.Response) interface{} { return res.User })},
+		{"GET", path("time-entries"), []operation{{"GetTimeentriesByIds", batchKind, "timeentriesById"}},
+			gateway.batch(handler.GetTimeentriesByIds, func(res *communicator.Response) interface{} { return res.TimeentriesById })},
+		{"GET", path("health"), []operation{{"Health", singleKind, ""}}, gateway.health},
+		{"GET", path("stream/projects"), []operation{{"StreamProjects", streamKind, ""}}, gateway.streamProjects},
+		{"GET", path("stream/projects/:project/tasks"), []operation{{"StreamTasks", streamKind, ""}}, gateway.streamTasks},
+		{"GET", path("stream/projects/:project/time-entries"), []operation{{"StreamTimeEntries", streamKind, ""}},
+			gateway.streamTimeEntries},
+		{"GET", path("stream/time-entries"), []operation{{"StreamTimeEntries", streamKind, ""}}, gateway.streamTimeEntries},
+	}
+}
+
+func path(pattern string) []string {
+	return strings.Split(pattern, "/")
+}
+
+// routeRequest builds the RPC request of a route from the query and the path parameters(param: params)
+func routeRequest(r *http.Request, params map[string]string) *communicator.Request {
+	req := request(r)
+	req.Workspace = params["project"]
+	req.Task = params["task"]
+	req.SubTask = params["subtask"]
+	req.KeyOrId = params["user"]
+	return req
+}
+
+// single serves a route returning one resource selected from the response by the callback(param: data)
+func (gateway *Gateway) single(call rpc, data func(*communicator.Response) interface{}) func(http.ResponseWriter,
+	*http.Request, map[string]string) {
+
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		res := new(communicator.Response)
+		if err := call(tenant.RequestContext(r), routeRequest(r, params), res); err != nil {
+			gateway.writeRPCError(w, r, err)
+			return
+		}
+		writeData(w, data(res), nil)
+	}
+}
+
+// list serves a route returning a page of the resources selected from the response by the callback(param: items)
+func (gateway *Gateway) list(call rpc, items func(*communicator.Response) listItems) func(http.ResponseWriter,
+	*http.Request, map[string]string) {
+
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		res := new(communicator.Response)
+		if err := call(tenant.RequestContext(r), routeRequest(r, params), res); err != nil {
+			gateway.writeRPCError(w, r, err)
+			return
+		}
+		selected := items(res)
+		writeList(w, r, selected.Len(), selected.Slice)
+	}
+}
+
+// batch serves a route looking resources up by the IDs of the "ids" query parameter
+func (gateway *Gateway) batch(call rpc, found func(*communicator.Response) interface{}) func(http.ResponseWriter,
+	*http.Request, map[string]string) {
+
+	return func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		req := routeRequest(r, params)
+		if len(req.Ids) < 1 {
+			writeError(w, http.StatusBadRequest, "The ids query parameter is required")
+			return
+		}
+		res := new(communicator.Response)
+		if err := call(tenant.RequestContext(r), req, res); err != nil {
+			gateway.writeRPCError(w, r, err)
+			return
+		}
+		writeData(w, found(res), append([]string{}, res.NotFound...))
+	}
+}
+
+// projects serves every project, or the projects of the "ids" query parameter when provided
+func (gateway *Gateway) projects(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	if len(request(r).Ids) > 0 {
+		gateway.batch(gateway.handler.GetProjectsByIds,
+			func(res *communicator.Response) interface{} { return res.ProjectsById })(w, r, params)
+		return
+	}
+	gateway.list(gateway.handler.GetAllProjects,
+		func(res *communicator.Response) listItems { return projectItems(res.Projects) })(w, r, params)
+}
+
+// health serves the checks of the service, probing Mavenlink when the "probe" query parameter is set
+func (gateway *Gateway) health(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	req := new(communicator.HealthRequest)
+	req.Probe, _ = strconv.ParseBool(r.URL.Query().Get("probe"))
+	res := new(communicator.HealthResponse)
+	if err := gateway.handler.Health(tenant.RequestContext(r), req, res); err != nil {
+		gateway.writeRPCError(w, r, err)
+		return
+	}
+	writeData(w, res, nil)
+}
+
+// projectTimeEntries serves a page of every time entry of a project
+func (gateway *Gateway) projectTimeEntries(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	stream := new(timeentryCollector)
+	if err := gateway.handler.StreamTimeEntries(tenant.RequestContext(r), routeRequest(r, params), stream); err != nil {
+		gateway.writeRPCError(w, r, err)
+		return
+	}
+	items := timeentryItems(stream.timeentries)
+	writeList(w, r, items.Len(), items.Slice)
+}
+
+func (gateway *Gateway) streamProjects(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	stream := &projectStream{newStreamWriter(w)}
+	gateway.finishStream(w, r, stream.streamWriter,
+		gateway.handler.StreamProjects(tenant.RequestContext(r), routeRequest(r, params), stream))
+}
+
+func (gateway *Gateway) streamTasks(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	stream := &taskStream{newStreamWriter(w)}
+	gateway.finishStream(w, r, stream.streamWriter,
+		gateway.handler.StreamTasks(tenant.RequestContext(r), routeRequest(r, params), stream))
+}
+
+func (gateway *Gateway) streamTimeEntries(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	stream := &timeentryStream{newStreamWriter(w)}
+	gateway.finishStream(w, r, stream.streamWriter,
+		gateway.handler.StreamTimeEntries(tenant.RequestContext(r), routeRequest(r, params), stream))
+}
+
+// finishStream reports the error(param: err) ending a stream, with its status when nothing was sent yet
+func (gateway *Gateway) finishStream(w http.ResponseWriter, r *http.Request, stream *streamWriter, err error) {
+	if err == nil {
+		stream.start()
+		return
+	}
+	if !stream.started {
+		gateway.writeRPCError(w, r, err)
+		return
+	}
+	stream.fail(err)
+}
+
+// listItems is a list of resources the gateway can page through
+type listItems interface {
+	Len() int
+	Slice(start int, end int) interface{}
+}
+
+type projectItems []*communicator.Project
+
+func (items projectItems) Len() int                             { return len(items) }
+func (items projectItems) Slice(start int, end int) interface{} { return items[start:end] }
+
+type taskItems []*communicator.Task
+
+func (items taskItems) Len() int                             { return len(items) }
+func (items taskItems) Slice(start int, end int) interface{} { return items[start:end] }
+
+type taskNodeItems []*communicator.TaskNode
+
+func (items taskNodeItems) Len() int                             { return len(items) }
+func (items taskNodeItems) Slice(start int, end int) interface{} { return items[start:end] }
+
+type timeentryItems []*communicator.Timeentry
+
+func (items timeentryItems) Len() int                             { return len(items) }
+func (items timeentryItems) Slice(start int, end int) interface{} { return items[start:end] }
+
+type userItems []*communicator.User
+
+func (items userItems) Len() int                             { return len(items) }
+func (items userItems) Slice(start int, end int) interface{} { return items[start:end] }
@@ -0,0 +1,105 @@
+package gateway
+
+import (
+	"encoding/json"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"io"
+	"net/http"
+)
+
+// streamWriter writes the messages of a streaming RPC as newline delimited JSON,
+// flushing each of them to the client as soon as it is sent
+type streamWriter struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+	started bool
+}
+
+func newStreamWriter(w http.ResponseWriter) *streamWriter {
+	return &streamWriter{w: w, encoder: json.NewEncoder(w)}
+}
+
+// start sends the headers of the stream unless already sent
+func (stream *streamWriter) start() {
+	if stream.started {
+		return
+	}
+	stream.started = true
+	stream.w.Header().Set("Content-Type", "application/x-ndjson")
+	stream.w.WriteHeader(http.StatusOK)
+}
+
+func (stream *streamWriter) SendMsg(message interface{}) error {
+	stream.start()
+	if err := stream.encoder.Encode(message); err != nil {
+		return err
+	}
+	if flusher, ok := stream.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// fail ends a started stream with a last line describing the error(param: err)
+func (stream *streamWriter) fail(err error) {
+	stream.encoder.Encode(map[string]interface{}{
+		"error": &communicator.Error{Code: http.StatusInternalServerError, Description: err.Error()},
+	})
+}
+
+func (stream *streamWriter) RecvMsg(interface{}) error {
+	return io.EOF
+}
+
+func (stream *streamWriter) Close() error {
+	return nil
+}
+
+type projectStream struct {
+	*streamWriter
+}
+
+func (stream *projectStream) Send(project *communicator.Project) error {
+	return stream.SendMsg(project)
+}
+
+type taskStream struct {
+	*streamWriter
+}
+
+func (stream *taskStream) Send(task *communicator.Task) error {
+	return stream.SendMsg(task)
+}
+
+type timeentryStream struct {
+	*streamWriter
+}
+
+func (stream *timeentryStream) Send(timeentry *communicator.Timeentry) error {
+	return stream.SendMsg(timeentry)
+}
+
+// timeentryCollector keeps the time entries of a stream to return them as a list
+type timeentryCollector struct {
+	timeentries []*communicator.Timeentry
+}
+
+func (stream *timeentryCollector) Send(timeentry *communicator.Timeentry) error {
+	stream.timeentries = append(stream.timeentries, timeentry)
+	return nil
+}
+
+func (stream *timeentryCollector) SendMsg(message interface{}) error {
+	if timeentry, ok := message.(*communicator.Timeentry); ok {
+		return stream.Send(timeentry)
+	}
+	return nil
+}
+
+func (stream *timeentryCollector) RecvMsg(interface{}) error {
+	return io.EOF
+}
+
+func (stream *timeentryCollector) Close() error {
+	return nil
+}
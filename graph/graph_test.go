@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	"github.com/desertjinn/mavenlink-communicator/tenant"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// query posts the query(param: query) to the handler with the headers(param: headers) and decodes its data
+func query(t *testing.T, handler http.Handler, query string, headers map[string]string) (int, map[string][]map[string]interface{}) {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest("POST", "/graphql", bytes.NewBuffer(body))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	var res struct {
+		Data   map[string][]map[string]interface{}
+		Errors []interface{}
+	}
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Errors) > 0 {
+			t.Fatalf("unexpected errors %v", res.Errors)
+		}
+	}
+	return w.Code, res.Data
+}
+
+func TestListsAreFetchedInOneCall(t *testing.T) {
+	server := mavenlinktest.NewServer()
+	defer server.Close()
+	mavenlink := new(API.MavenlinkApi)
+	if err := mavenlink.SetEnv(server.Config()); err != nil {
+		t.Fatal(err)
+	}
+	status, data := query(t, NewHandler(mavenlink, nil),
+		`{ tasks(ids: ["3001", "3002", "3003", "3999"]) { id } users(ids: ["2001", "2002", "2003"]) { id } }`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+	if len(data["tasks"]) != 3 || len(data["users"]) != 3 {
+		t.Errorf("unexpected data %v", data)
+	}
+	if requests := server.Requests(mavenlinktest.Stories); len(requests) != 1 {
+		t.Errorf("expected the tasks to be fetched in one call, got %d", len(requests))
+	}
+	if requests := server.Requests(mavenlinktest.Users); len(requests) != 1 {
+		t.Errorf("expected the users to be fetched in one call, got %d", len(requests))
+	}
+}
+
+func TestQueriesUseTheTokenOfTheCaller(t *testing.T) {
+	server := mavenlinktest.NewServer()
+	defer server.Close()
+	shared := server.Config()
+	shared.Token = "another-token"
+	shared.TenantTokens = map[string]string{"acme": mavenlinktest.Token}
+	mavenlink := new(API.MavenlinkApi)
+	if err := mavenlink.SetEnv(shared); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewHandler(mavenlink, tenant.NewPool(shared))
+	status, data := query(t, handler, `{ projects(ids: ["1001"]) { id } }`, map[string]string{tenant.TenantKey: "acme"})
+	if status != http.StatusOK || len(data["projects"]) != 1 {
+		t.Errorf("expected the project to be fetched with the token of the tenant, got %d %v", status, data)
+	}
+	status, _ = query(t, handler, `{ projects(ids: ["1001"]) { id } }`, map[string]string{tenant.TenantKey: "unknown"})
+	if status != http.StatusUnauthorized {
+		t.Errorf("expected an unknown tenant to be refused, got %d", status)
+	}
+}
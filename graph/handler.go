@@ -0,0 +1,67 @@
+package graph
+
+import (
+	"context"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-communicator/tenant"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"net/http"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8070"
+
+// Handler serves GraphQL queries over the resources of the Mavenlink API
+type Handler struct {
+	mavenlink API.MavenlinkApiInterface
+	tenants   *tenant.Pool
+	relay     *relay.Handler
+}
+
+// NewHandler creates a handler resolving queries with the Mavenlink API(param: mavenlink), or
+// with the client of the pool(param: tenants) matching the credentials passed by the caller
+// in the headers of the query. Without a pool every query uses the Mavenlink API
+func NewHandler(mavenlink API.MavenlinkApiInterface, tenants *tenant.Pool) *Handler {
+	return &Handler{
+		mavenlink: mavenlink,
+		tenants:   tenants,
+		relay:     &relay.Handler{Schema: graphql.MustParseSchema(schema, &resolver{})},
+	}
+}
+
+// ListenAndServe serves queries on the address of the configuration(param: configuration)
+// until the listener fails
+func ListenAndServe(mavenlink API.MavenlinkApiInterface, tenants *tenant.Pool, configuration *communicator.EnvironmentConfiguration) error {
+	address := configuration.GraphqlAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", NewHandler(mavenlink, tenants))
+	return http.ListenAndServe(address, mux)
+}
+
+// ServeHTTP resolves a query with loaders of its own, so that the resources
+// it needs are batched and fetched once for the whole query
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	mavenlink := handler.mavenlink
+	if handler.tenants != nil {
+		tenantMavenlink, err := handler.tenants.For(tenant.RequestContext(r))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if tenantMavenlink != nil {
+			mavenlink = tenantMavenlink
+		}
+	}
+	ctx := context.WithValue(r.Context(), loadersKey{}, newLoaders(mavenlink))
+	handler.relay.ServeHTTP(w, r.WithContext(ctx))
+}
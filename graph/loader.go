@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"sync"
+	"time"
+)
+
+// Time a loader waits for more keys before fetching a batch, and the largest batch it fetches
+const (
+	batchWait    = 2 * time.Millisecond
+	maxBatchSize = 100
+)
+
+// result is the outcome of loading a single key, available once done is closed
+type result struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// loader collects the keys requested by concurrent resolvers and fetches them
+// with a single call of its batch function. Every key is fetched once, later
+// loads of the same key share its result. A loader lives for a single query
+type loader struct {
+	batch   func(keys []string) (map[string]interface{}, error)
+	mutex   sync.Mutex
+	results map[string]*result
+	pending []string
+}
+
+func newLoader(batch func(keys []string) (map[string]interface{}, error)) *loader {
+	return &loader{batch: batch, results: make(map[string]*result)}
+}
+
+// Load returns the value of the key(param: key), or nil when the batch function did not find it
+func (loader *loader) Load(key string) (interface{}, error) {
+	values, err := loader.LoadMany([]string{key})
+	return values[0], err
+}
+
+// LoadMany returns the values of the keys(param: keys) in their order, nil for the keys
+// the batch function did not find. The keys are queued together so that they are
+// fetched in the same batches rather than one after the other
+func (loader *loader) LoadMany(keys []string) ([]interface{}, error) {
+	loaded := make([]*result, len(keys))
+	loader.mutex.Lock()
+	for i, key := range keys {
+		existing, ok := loader.results[key]
+		if !ok {
+			existing = &result{done: make(chan struct{})}
+			loader.results[key] = existing
+			loader.pending = append(loader.pending, key)
+			if len(loader.pending) == 1 {
+				time.AfterFunc(batchWait, loader.dispatch)
+			}
+			if len(loader.pending) >= maxBatchSize {
+				pending := loader.pending
+				loader.pending = nil
+				go loader.fetch(pending)
+			}
+		}
+		loaded[i] = existing
+	}
+	loader.mutex.Unlock()
+	values := make([]interface{}, len(keys))
+	var err error
+	for i, existing := range loaded {
+		<-existing.done
+		values[i] = existing.value
+		if existing.err != nil && err == nil {
+			err = existing.err
+		}
+	}
+	return values, err
+}
+
+// dispatch fetches the keys collected since the first of them was requested
+func (loader *loader) dispatch() {
+	loader.mutex.Lock()
+	keys := loader.pending
+	loader.pending = nil
+	loader.mutex.Unlock()
+	if len(keys) > 0 {
+		loader.fetch(keys)
+	}
+}
+
+func (loader *loader) fetch(keys []string) {
+	values, err := loader.batch(keys)
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+	for _, key := range keys {
+		loaded := loader.results[key]
+		loaded.value = values[key]
+		loaded.err = err
+		close(loaded.done)
+	}
+}
+
+// memo calls a function once per key for the lifetime of a query, sharing
+// its result between the resolvers asking for the same key
+type memo struct {
+	mutex   sync.Mutex
+	results map[string]*result
+}
+
+func newMemo() *memo {
+	return &memo{results: make(map[string]*result)}
+}
+
+// Do returns the result of the function(param: load) for the key(param: key), calling it on first use
+func (memo *memo) Do(key string, load func() (interface{}, error)) (interface{}, error) {
+	memo.mutex.Lock()
+	loaded, ok := memo.results[key]
+	if !ok {
+		loaded = &result{done: make(chan struct{})}
+		memo.results[key] = loaded
+	}
+	memo.mutex.Unlock()
+	if !ok {
+		loaded.value, loaded.err = load()
+		close(loaded.done)
+	}
+	<-loaded.done
+	return loaded.value, loaded.err
+}
@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"context"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+)
+
+// Key of the loaders of a query in its context
+type loadersKey struct{}
+
+// loaders batch and deduplicate the calls made to the Mavenlink API while
+// resolving a single query. Resources looked up by ID are batched with the
+// batch lookups, the stories, participants and time entries of a workspace
+// are retrieved once however many tasks ask for them
+type loaders struct {
+	mavenlink   API.MavenlinkApiInterface
+	projects    *loader
+	tasks       *loader
+	users       *loader
+	timeentries *loader
+	trees       *memo
+	members     *memo
+	logged      *memo
+}
+
+// projectTree indexes the task tree of a workspace
+type projectTree struct {
+	roots []*communicator.TaskNode
+	nodes map[string]*communicator.TaskNode
+}
+
+func newLoaders(mavenlink API.MavenlinkApiInterface) *loaders {
+	return &loaders{
+		mavenlink: mavenlink,
+		projects: newLoader(func(ids []string) (map[string]interface{}, error) {
+			found, _, err := mavenlink.GetProjectsByIds(ids)
+			values := make(map[string]interface{})
+			for id, project := range found {
+				values[id] = project
+			}
+			return values, err
+		}),
+		tasks: newLoader(func(ids []string) (map[string]interface{}, error) {
+			found, _, err := mavenlink.GetTasksByIds(ids)
+			values := make(map[string]interface{})
+			for id, task := range found {
+				values[id] = task
+			}
+			return values, err
+		}),
+		users: newLoader(func(ids []string) (map[string]interface{}, error) {
+			found, _, err := mavenlink.GetUsersByIds(ids)
+			values := make(map[string]interface{})
+			for id, user := range found {
+				values[id] = user
+			}
+			return values, err
+		}),
+		timeentries: newLoader(func(ids []string) (map[string]interface{}, error) {
+			found, _, err := mavenlink.GetTimeEntriesByIds(ids)
+			values := make(map[string]interface{})
+			for id, timeentry := range found {
+				values[id] = timeentry
+			}
+			return values, err
+		}),
+		trees:   newMemo(),
+		members: newMemo(),
+		logged:  newMemo(),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (loaders *loaders) project(id string) (*communicator.Project, error) {
+	value, err := loaders.projects.Load(id)
+	if value == nil {
+		return nil, err
+	}
+	return value.(*communicator.Project), err
+}
+
+func (loaders *loaders) task(id string) (*communicator.Task, error) {
+	value, err := loaders.tasks.Load(id)
+	if value == nil {
+		return nil, err
+	}
+	return value.(*communicator.Task), err
+}
+
+func (loaders *loaders) user(id string) (*communicator.User, error) {
+	value, err := loaders.users.Load(id)
+	if value == nil {
+		return nil, err
+	}
+	return value.(*communicator.User), err
+}
+
+func (loaders *loaders) timeentry(id string) (*communicator.Timeentry, error) {
+	value, err := loaders.timeentries.Load(id)
+	if value == nil {
+		return nil, err
+	}
+	return value.(*communicator.Timeentry), err
+}
+
+// projectsOf returns the workspaces found among the IDs(param: ids), fetched together
+func (loaders *loaders) projectsOf(ids []string) ([]*communicator.Project, error) {
+	values, err := loaders.projects.LoadMany(ids)
+	if err != nil {
+		return nil, err
+	}
+	var projects []*communicator.Project
+	for _, value := range values {
+		if value != nil {
+			projects = append(projects, value.(*communicator.Project))
+		}
+	}
+	return projects, nil
+}
+
+// tasksOf returns the stories found among the IDs(param: ids), fetched together
+func (loaders *loaders) tasksOf(ids []string) ([]*communicator.Task, error) {
+	values, err := loaders.tasks.LoadMany(ids)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*communicator.Task
+	for _, value := range values {
+		if value != nil {
+			tasks = append(tasks, value.(*communicator.Task))
+		}
+	}
+	return tasks, nil
+}
+
+// usersOf returns the users found among the IDs(param: ids), fetched together
+func (loaders *loaders) usersOf(ids []string) ([]*communicator.User, error) {
+	values, err := loaders.users.LoadMany(ids)
+	if err != nil {
+		return nil, err
+	}
+	var users []*communicator.User
+	for _, value := range values {
+		if value != nil {
+			users = append(users, value.(*communicator.User))
+		}
+	}
+	return users, nil
+}
+
+// timeentriesOf returns the time entries found among the IDs(param: ids), fetched together
+func (loaders *loaders) timeentriesOf(ids []string) ([]*communicator.Timeentry, error) {
+	values, err := loaders.timeentries.LoadMany(ids)
+	if err != nil {
+		return nil, err
+	}
+	var timeentries []*communicator.Timeentry
+	for _, value := range values {
+		if value != nil {
+			timeentries = append(timeentries, value.(*communicator.Timeentry))
+		}
+	}
+	return timeentries, nil
+}
+
+// tree returns every story of a workspace(param: workspaceId) with its assignees and children
+func (loaders *loaders) tree(workspaceId string) (*projectTree, error) {
+	value, err := loaders.trees.Do(workspaceId, func() (interface{}, error) {
+		roots, err := loaders.mavenlink.GetTaskTreeFromProjectId(workspaceId, false)
+		if err != nil {
+			return nil, err
+		}
+		tree := &projectTree{roots: roots, nodes: make(map[string]*communicator.TaskNode)}
+		var index func(nodes []*communicator.TaskNode)
+		index = func(nodes []*communicator.TaskNode) {
+			for _, node := range nodes {
+				tree.nodes[node.Task.Id] = node
+				index(node.Children)
+			}
+		}
+		index(roots)
+		return tree, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*projectTree), nil
+}
+
+// participants returns the users participating in a workspace(param: workspaceId)
+func (loaders *loaders) participants(workspaceId string) ([]*communicator.User, error) {
+	value, err := loaders.members.Do(workspaceId, func() (interface{}, error) {
+		return loaders.mavenlink.GetUsersFromProjectId(workspaceId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.User), nil
+}
+
+// timeEntries returns every time entry of a workspace(param: workspaceId)
+func (loaders *loaders) timeEntries(workspaceId string) ([]*communicator.Timeentry, error) {
+	value, err := loaders.logged.Do(workspaceId, func() (interface{}, error) {
+		var timeentries []*communicator.Timeentry
+		err := loaders.mavenlink.StreamTimeEntries(workspaceId, 0, func(timeentry *communicator.Timeentry) error {
+			timeentries = append(timeentries, timeentry)
+			return nil
+		})
+		return timeentries, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*communicator.Timeentry), nil
+}
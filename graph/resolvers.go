@@ -0,0 +1,293 @@
+package graph
+
+import (
+	"context"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/graph-gophers/graphql-go"
+)
+
+// resolver resolves the fields of the Query type
+type resolver struct{}
+
+func (r *resolver) Project(ctx context.Context, args struct{ ID graphql.ID }) (*projectResolver, error) {
+	project, err := loadersFrom(ctx).project(string(args.ID))
+	if project == nil {
+		return nil, err
+	}
+	return &projectResolver{project}, err
+}
+
+func (r *resolver) Projects(ctx context.Context, args struct{ Ids *[]graphql.ID }) ([]*projectResolver, error) {
+	var projects []*projectResolver
+	if args.Ids == nil {
+		all, err := loadersFrom(ctx).mavenlink.GetProjects()
+		if err != nil {
+			return nil, err
+		}
+		for _, project := range all {
+			projects = append(projects, &projectResolver{project})
+		}
+		return projects, nil
+	}
+	found, err := loadersFrom(ctx).projectsOf(idsOf(*args.Ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range found {
+		projects = append(projects, &projectResolver{project})
+	}
+	return projects, nil
+}
+
+func (r *resolver) Task(ctx context.Context, args struct{ ID graphql.ID }) (*taskResolver, error) {
+	task, err := loadersFrom(ctx).task(string(args.ID))
+	if task == nil {
+		return nil, err
+	}
+	return &taskResolver{task}, err
+}
+
+func (r *resolver) Tasks(ctx context.Context, args struct{ Ids []graphql.ID }) ([]*taskResolver, error) {
+	var tasks []*taskResolver
+	found, err := loadersFrom(ctx).tasksOf(idsOf(args.Ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range found {
+		tasks = append(tasks, &taskResolver{task})
+	}
+	return tasks, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	user, err := loadersFrom(ctx).user(string(args.ID))
+	if user == nil {
+		return nil, err
+	}
+	return &userResolver{user}, err
+}
+
+func (r *resolver) Users(ctx context.Context, args struct{ Ids []graphql.ID }) ([]*userResolver, error) {
+	var users []*userResolver
+	found, err := loadersFrom(ctx).usersOf(idsOf(args.Ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range found {
+		users = append(users, &userResolver{user})
+	}
+	return users, nil
+}
+
+func (r *resolver) TimeEntry(ctx context.Context, args struct{ ID graphql.ID }) (*timeentryResolver, error) {
+	timeentry, err := loadersFrom(ctx).timeentry(string(args.ID))
+	if timeentry == nil {
+		return nil, err
+	}
+	return &timeentryResolver{timeentry}, err
+}
+
+func (r *resolver) TimeEntries(ctx context.Context, args struct{ Ids []graphql.ID }) ([]*timeentryResolver, error) {
+	var timeentries []*timeentryResolver
+	found, err := loadersFrom(ctx).timeentriesOf(idsOf(args.Ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, timeentry := range found {
+		timeentries = append(timeentries, &timeentryResolver{timeentry})
+	}
+	return timeentries, nil
+}
+
+// idsOf returns the IDs(param: ids) of a query as strings
+func idsOf(ids []graphql.ID) []string {
+	var keys []string
+	for _, id := range ids {
+		keys = append(keys, string(id))
+	}
+	return keys
+}
+
+// projectResolver resolves the fields of the Project type
+type projectResolver struct {
+	project *communicator.Project
+}
+
+func (r *projectResolver) ID() graphql.ID           { return graphql.ID(r.project.Id) }
+func (r *projectResolver) Title() string            { return r.project.Title }
+func (r *projectResolver) Description() string      { return r.project.Description }
+func (r *projectResolver) AccessLevel() string      { return r.project.AccessLevel }
+func (r *projectResolver) AccountId() int32         { return r.project.AccountId }
+func (r *projectResolver) Archived() bool           { return r.project.Archived }
+func (r *projectResolver) Currency() string         { return r.project.Currency }
+func (r *projectResolver) CurrencySymbol() string   { return r.project.CurrencySymbol }
+func (r *projectResolver) DueDate() string          { return r.project.DueDate }
+func (r *projectResolver) EffectiveDueDate() string { return r.project.EffectiveDueDate }
+func (r *projectResolver) StartDate() string        { return r.project.StartDate }
+func (r *projectResolver) CreatedAt() string        { return r.project.CreatedAt }
+func (r *projectResolver) UpdatedAt() string        { return r.project.UpdatedAt }
+
+func (r *projectResolver) Tasks(ctx context.Context, args struct{ RootsOnly bool }) ([]*taskResolver, error) {
+	tree, err := loadersFrom(ctx).tree(r.project.Id)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*taskResolver
+	var collect func(nodes []*communicator.TaskNode)
+	collect = func(nodes []*communicator.TaskNode) {
+		for _, node := range nodes {
+			tasks = append(tasks, &taskResolver{node.Task})
+			if !args.RootsOnly {
+				collect(node.Children)
+			}
+		}
+	}
+	collect(tree.roots)
+	return tasks, nil
+}
+
+func (r *projectResolver) Users(ctx context.Context) ([]*userResolver, error) {
+	participants, err := loadersFrom(ctx).participants(r.project.Id)
+	if err != nil {
+		return nil, err
+	}
+	var users []*userResolver
+	for _, user := range participants {
+		users = append(users, &userResolver{user})
+	}
+	return users, nil
+}
+
+func (r *projectResolver) TimeEntries(ctx context.Context) ([]*timeentryResolver, error) {
+	all, err := loadersFrom(ctx).timeEntries(r.project.Id)
+	if err != nil {
+		return nil, err
+	}
+	var timeentries []*timeentryResolver
+	for _, timeentry := range all {
+		timeentries = append(timeentries, &timeentryResolver{timeentry})
+	}
+	return timeentries, nil
+}
+
+// taskResolver resolves the fields of the Task type. The relations of a task
+// are resolved from the tree of its workspace, retrieved once per query
+type taskResolver struct {
+	task *communicator.Task
+}
+
+func (r *taskResolver) ID() graphql.ID          { return graphql.ID(r.task.Id) }
+func (r *taskResolver) Title() string           { return r.task.Title }
+func (r *taskResolver) Description() string     { return r.task.Description }
+func (r *taskResolver) StoryType() string       { return r.task.StoryType }
+func (r *taskResolver) Priority() string        { return r.task.Priority }
+func (r *taskResolver) Archived() bool          { return r.task.Archived }
+func (r *taskResolver) WorkspaceId() graphql.ID { return graphql.ID(r.task.WorkspaceId) }
+func (r *taskResolver) CreatorId() string       { return r.task.CreatorId }
+func (r *taskResolver) ParentId() string        { return r.task.ParentId }
+func (r *taskResolver) DueDate() string         { return r.task.DueDate }
+func (r *taskResolver) State() string           { return r.task.State }
+func (r *taskResolver) StartDate() string       { return r.task.StartDate }
+func (r *taskResolver) CreatedAt() string       { return r.task.CreatedAt }
+func (r *taskResolver) UpdatedAt() string       { return r.task.UpdatedAt }
+
+func (r *taskResolver) Project(ctx context.Context) (*projectResolver, error) {
+	return (&resolver{}).Project(ctx, struct{ ID graphql.ID }{graphql.ID(r.task.WorkspaceId)})
+}
+
+func (r *taskResolver) Parent(ctx context.Context) (*taskResolver, error) {
+	if len(r.task.ParentId) < 1 {
+		return nil, nil
+	}
+	return (&resolver{}).Task(ctx, struct{ ID graphql.ID }{graphql.ID(r.task.ParentId)})
+}
+
+func (r *taskResolver) Children(ctx context.Context) ([]*taskResolver, error) {
+	node, err := r.node(ctx)
+	if node == nil {
+		return nil, err
+	}
+	var children []*taskResolver
+	for _, child := range node.Children {
+		children = append(children, &taskResolver{child.Task})
+	}
+	return children, nil
+}
+
+func (r *taskResolver) Assignees(ctx context.Context) ([]*userResolver, error) {
+	node, err := r.node(ctx)
+	if node == nil {
+		return nil, err
+	}
+	var assignees []*userResolver
+	for _, user := range node.Assignees {
+		assignees = append(assignees, &userResolver{user})
+	}
+	return assignees, nil
+}
+
+func (r *taskResolver) TimeEntries(ctx context.Context) ([]*timeentryResolver, error) {
+	all, err := loadersFrom(ctx).timeEntries(r.task.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+	var timeentries []*timeentryResolver
+	for _, timeentry := range all {
+		if timeentry.StoryId == r.task.Id {
+			timeentries = append(timeentries, &timeentryResolver{timeentry})
+		}
+	}
+	return timeentries, nil
+}
+
+// node returns the task in the tree of its workspace
+func (r *taskResolver) node(ctx context.Context) (*communicator.TaskNode, error) {
+	tree, err := loadersFrom(ctx).tree(r.task.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+	return tree.nodes[r.task.Id], nil
+}
+
+// userResolver resolves the fields of the User type
+type userResolver struct {
+	user *communicator.User
+}
+
+func (r *userResolver) ID() graphql.ID       { return graphql.ID(r.user.Id) }
+func (r *userResolver) FullName() string     { return r.user.FullName }
+func (r *userResolver) EmailAddress() string { return r.user.EmailAddress }
+func (r *userResolver) Headline() string     { return r.user.Headline }
+func (r *userResolver) AccountId() string    { return r.user.AccountId }
+
+// timeentryResolver resolves the fields of the Timeentry type
+type timeentryResolver struct {
+	timeentry *communicator.Timeentry
+}
+
+func (r *timeentryResolver) ID() graphql.ID          { return graphql.ID(r.timeentry.Id) }
+func (r *timeentryResolver) DatePerformed() string   { return r.timeentry.DatePerformed }
+func (r *timeentryResolver) TimeInMinutes() int32    { return r.timeentry.TimeInMinutes }
+func (r *timeentryResolver) Notes() string           { return r.timeentry.Notes }
+func (r *timeentryResolver) WorkspaceId() graphql.ID { return graphql.ID(r.timeentry.WorkspaceId) }
+func (r *timeentryResolver) StoryId() string         { return r.timeentry.StoryId }
+func (r *timeentryResolver) CreatedAt() string       { return r.timeentry.CreatedAt }
+func (r *timeentryResolver) UpdatedAt() string       { return r.timeentry.UpdatedAt }
+
+func (r *timeentryResolver) User() *userResolver {
+	if r.timeentry.User == nil {
+		return nil
+	}
+	return &userResolver{r.timeentry.User}
+}
+
+func (r *timeentryResolver) Task(ctx context.Context) (*taskResolver, error) {
+	if len(r.timeentry.StoryId) < 1 {
+		return nil, nil
+	}
+	return (&resolver{}).Task(ctx, struct{ ID graphql.ID }{graphql.ID(r.timeentry.StoryId)})
+}
+
+func (r *timeentryResolver) Project(ctx context.Context) (*projectResolver, error) {
+	return (&resolver{}).Project(ctx, struct{ ID graphql.ID }{graphql.ID(r.timeentry.WorkspaceId)})
+}
@@ -0,0 +1,84 @@
+package graph
+
+// schema mirrors the Project, Task, User and Timeentry messages of the service,
+// linking them so that related resources are fetched in a single query
+const schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	project(id: ID!): Project
+	projects(ids: [ID!]): [Project!]!
+	task(id: ID!): Task
+	tasks(ids: [ID!]!): [Task!]!
+	user(id: ID!): User
+	users(ids: [ID!]!): [User!]!
+	timeEntry(id: ID!): Timeentry
+	timeEntries(ids: [ID!]!): [Timeentry!]!
+}
+
+type Project {
+	id: ID!
+	title: String!
+	description: String!
+	accessLevel: String!
+	accountId: Int!
+	archived: Boolean!
+	currency: String!
+	currencySymbol: String!
+	dueDate: String!
+	effectiveDueDate: String!
+	startDate: String!
+	createdAt: String!
+	updatedAt: String!
+	# Top level tasks of the project, or every task when rootsOnly is false
+	tasks(rootsOnly: Boolean = true): [Task!]!
+	users: [User!]!
+	timeEntries: [Timeentry!]!
+}
+
+type Task {
+	id: ID!
+	title: String!
+	description: String!
+	storyType: String!
+	priority: String!
+	archived: Boolean!
+	workspaceId: ID!
+	creatorId: String!
+	parentId: String!
+	dueDate: String!
+	state: String!
+	startDate: String!
+	createdAt: String!
+	updatedAt: String!
+	project: Project
+	parent: Task
+	children: [Task!]!
+	assignees: [User!]!
+	timeEntries: [Timeentry!]!
+}
+
+type User {
+	id: ID!
+	fullName: String!
+	emailAddress: String!
+	headline: String!
+	accountId: String!
+}
+
+type Timeentry {
+	id: ID!
+	datePerformed: String!
+	timeInMinutes: Int!
+	notes: String!
+	workspaceId: ID!
+	storyId: String!
+	createdAt: String!
+	updatedAt: String!
+	user: User
+	task: Task
+	project: Project
+}
+`
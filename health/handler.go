@@ -0,0 +1,56 @@
+package health
+
+import (
+	"encoding/json"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"net/http"
+	"strconv"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8086"
+
+// Paths of the probes
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// NewHandler serves the liveness probe, answering as long as the process runs, and
+// the readiness probe, failing while the checks of the checker(param: checker) fail.
+// The readiness probe asks Mavenlink when probing is enabled(param: probe) or when
+// the request sets the probe query parameter
+func NewHandler(checker *Checker, probe bool) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, &communicator.HealthResponse{Status: StatusOk})
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		probeMavenlink := probe
+		if value := r.URL.Query().Get("probe"); len(value) > 0 {
+			probeMavenlink, _ = strconv.ParseBool(value)
+		}
+		response := checker.Check(probeMavenlink)
+		status := http.StatusOK
+		if response.Status == StatusFailing {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, response)
+	})
+	return mux
+}
+
+// ListenAndServe serves the probes at the address of the configuration(param: configuration) until the listener fails
+func ListenAndServe(checker *Checker, configuration *communicator.EnvironmentConfiguration) error {
+	address := configuration.HealthAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	return http.ListenAndServe(address, NewHandler(checker, configuration.HealthProbe))
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
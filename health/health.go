@@ -0,0 +1,219 @@
+package health
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Statuses of the service and of its checks. A failing service cannot serve
+// requests, while a degraded one serves them with reduced guarantees
+const (
+	StatusOk       = "ok"
+	StatusDegraded = "degraded"
+	StatusFailing  = "failing"
+)
+
+// Mavenlink is probed at most once per interval, the result being reused in between
+// so that frequent readiness probes do not count against Mavenlink's rate limit
+const probeInterval = 30 * time.Second
+
+// Pinger makes a cheap authenticated request to Mavenlink
+type Pinger interface {
+	Ping() error
+}
+
+// TokenSource tells whether a token can be provided, without obtaining or refreshing one
+type TokenSource interface {
+	Authorized() error
+}
+
+// CacheStats returns the usage counters of the cache
+type CacheStats func() (hits uint64, misses uint64, entries int)
+
+// LastSync returns the start time of the last successful sync, and of the last successful full sync
+type LastSync func() (time.Time, time.Time)
+
+// Checker verifies that the service is able to serve requests
+type Checker struct {
+	configurationMutex sync.RWMutex
+	configuration      *communicator.EnvironmentConfiguration
+	pinger             Pinger
+	tokenSource        TokenSource
+	cacheStats         CacheStats
+	lastSync           LastSync
+	staleness          time.Duration
+
+	probeMutex sync.Mutex
+	probing    bool
+	probedAt   time.Time
+	probeErr   error
+}
+
+// New creates a checker of the configuration(param: configuration) probing
+// Mavenlink through the pinger(param: pinger) when asked to
+func New(configuration *communicator.EnvironmentConfiguration, pinger Pinger) *Checker {
+	return &Checker{configuration: configuration, pinger: pinger}
+}
+
+// Reload replaces the configuration(param: configuration) checked
+func (checker *Checker) Reload(configuration *communicator.EnvironmentConfiguration) {
+	checker.configurationMutex.Lock()
+	defer checker.configurationMutex.Unlock()
+	checker.configuration = configuration
+}
+
+func (checker *Checker) currentConfiguration() *communicator.EnvironmentConfiguration {
+	checker.configurationMutex.RLock()
+	defer checker.configurationMutex.RUnlock()
+	return checker.configuration
+}
+
+// WatchTokenSource checks that the source(param: source) of the token used without a static token can provide one
+func (checker *Checker) WatchTokenSource(source TokenSource) {
+	checker.tokenSource = source
+}
+
+// WatchCache reports the counters returned by the function(param: stats) as the status of the cache
+func (checker *Checker) WatchCache(stats CacheStats) {
+	checker.cacheStats = stats
+}
+
+// WatchSync reports the times returned by the function(param: lastSync) as the status of the
+// mirror's sync, which is stale once the last sync is older than the duration(param: staleness)
+func (checker *Checker) WatchSync(lastSync LastSync, staleness time.Duration) {
+	checker.lastSync = lastSync
+	checker.staleness = staleness
+}
+
+// Check runs the checks of the service, probing Mavenlink when asked to(param: probe)
+func (checker *Checker) Check(probe bool) *communicator.HealthResponse {
+	configuration := checker.currentConfiguration()
+	response := &communicator.HealthResponse{
+		Checks: []*communicator.HealthCheck{checkUrl(configuration), checker.checkToken(configuration)},
+		Cache:  checker.cacheStatus(),
+		Sync:   checker.syncStatus(),
+	}
+	if probe {
+		response.Checks = append(response.Checks, checker.checkMavenlink())
+	}
+	if response.Sync.Enabled && response.Sync.Stale {
+		response.Checks = append(response.Checks, &communicator.HealthCheck{
+			Name: "sync", Status: StatusDegraded, Detail: "The mirror has not been synced recently"})
+	}
+	response.Status = StatusOk
+	for _, check := range response.Checks {
+		if check.Status == StatusFailing {
+			response.Status = StatusFailing
+			break
+		}
+		if check.Status == StatusDegraded {
+			response.Status = StatusDegraded
+		}
+	}
+	return response
+}
+
+func checkUrl(configuration *communicator.EnvironmentConfiguration) *communicator.HealthCheck {
+	check := &communicator.HealthCheck{Name: "url", Status: StatusOk}
+	if configuration.MemoryEnabled == true {
+		check.Detail = "Not used by the in-memory backend"
+		return check
+	}
+	parsed, err := url.Parse(configuration.Url)
+	if err != nil || len(parsed.Scheme) < 1 || len(parsed.Host) < 1 {
+		check.Status = StatusFailing
+		check.Detail = "The Mavenlink URL is not a valid absolute URL"
+	}
+	return check
+}
+
+func (checker *Checker) checkToken(configuration *communicator.EnvironmentConfiguration) *communicator.HealthCheck {
+	check := &communicator.HealthCheck{Name: "token", Status: StatusOk}
+	switch {
+	case configuration.MemoryEnabled == true:
+		check.Detail = "In-memory backend"
+	case len(configuration.Token) > 0:
+		check.Detail = "Static token"
+	case checker.tokenSource != nil:
+		check.Detail = "OAuth token"
+		if err := checker.tokenSource.Authorized(); err != nil {
+			check.Status = StatusFailing
+			check.Detail = err.Error()
+		}
+	case configuration.TenantRequired == true:
+		check.Detail = "Tokens passed by the callers"
+	case configuration.HttpMode == API.ReplayMode:
+		check.Detail = "Recorded responses replayed"
+	default:
+		check.Status = StatusFailing
+		check.Detail = "No Mavenlink token is configured"
+	}
+	return check
+}
+
+// checkMavenlink probes Mavenlink, reusing the last result within the probe interval.
+// Mavenlink being unreachable degrades the service, which may still serve cached
+// or mirrored data and cannot recover by being restarted. Checks made while a probe
+// is under way report the result of the previous one rather than waiting for it
+func (checker *Checker) checkMavenlink() *communicator.HealthCheck {
+	check := &communicator.HealthCheck{Name: "mavenlink", Status: StatusOk}
+	if checker.pinger == nil {
+		check.Detail = "Not probed"
+		return check
+	}
+	checker.probeMutex.Lock()
+	probe := !checker.probing && time.Since(checker.probedAt) >= probeInterval
+	if probe {
+		checker.probing = true
+	}
+	probedAt, err := checker.probedAt, checker.probeErr
+	checker.probeMutex.Unlock()
+	if probe {
+		err = checker.pinger.Ping()
+		checker.probeMutex.Lock()
+		checker.probing = false
+		checker.probedAt = time.Now()
+		checker.probeErr = err
+		checker.probeMutex.Unlock()
+	} else if probedAt.IsZero() {
+		check.Detail = "Being probed"
+		return check
+	}
+	if err != nil {
+		check.Status = StatusDegraded
+		check.Detail = err.Error()
+	}
+	return check
+}
+
+func (checker *Checker) cacheStatus() *communicator.CacheStatus {
+	status := &communicator.CacheStatus{}
+	if checker.cacheStats != nil {
+		status.Enabled = true
+		hits, misses, entries := checker.cacheStats()
+		status.Hits = hits
+		status.Misses = misses
+		status.Entries = int32(entries)
+	}
+	return status
+}
+
+func (checker *Checker) syncStatus() *communicator.SyncStatus {
+	status := &communicator.SyncStatus{}
+	if checker.lastSync == nil {
+		return status
+	}
+	status.Enabled = true
+	lastSync, lastFullSync := checker.lastSync()
+	if !lastSync.IsZero() {
+		status.LastSync = lastSync.Format(time.RFC3339)
+	}
+	if !lastFullSync.IsZero() {
+		status.LastFullSync = lastFullSync.Format(time.RFC3339)
+	}
+	status.Stale = lastSync.IsZero() || time.Since(lastSync) > checker.staleness
+	return status
+}
@@ -0,0 +1,78 @@
+package health
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingPinger answers its pings once released, counting them
+type blockingPinger struct {
+	pings   int32
+	release chan struct{}
+}
+
+func (pinger *blockingPinger) Ping() error {
+	atomic.AddInt32(&pinger.pings, 1)
+	<-pinger.release
+	return errors.New("Mavenlink is unreachable")
+}
+
+func mavenlinkCheck(response *communicator.HealthResponse) *communicator.HealthCheck {
+	for _, check := range response.Checks {
+		if check.Name == "mavenlink" {
+			return check
+		}
+	}
+	return nil
+}
+
+func TestChecksDoNotWaitForTheProbe(t *testing.T) {
+	pinger := &blockingPinger{release: make(chan struct{})}
+	checker := New(&communicator.EnvironmentConfiguration{Url: "https://api.mavenlink.com/api/v1/", Token: "token"}, pinger)
+	probed := make(chan *communicator.HealthResponse)
+	go func() { probed <- checker.Check(true) }()
+	for atomic.LoadInt32(&pinger.pings) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	checked := make(chan *communicator.HealthResponse)
+	go func() { checked <- checker.Check(true) }()
+	select {
+	case response := <-checked:
+		if check := mavenlinkCheck(response); check.Status != StatusOk || check.Detail != "Being probed" {
+			t.Errorf("expected the check to report the probe under way, got %v", check)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the check not to wait for the probe")
+	}
+
+	close(pinger.release)
+	if check := mavenlinkCheck(<-probed); check.Status != StatusDegraded {
+		t.Errorf("expected the failed probe to degrade the service, got %v", check)
+	}
+	if check := mavenlinkCheck(checker.Check(true)); check.Status != StatusDegraded {
+		t.Errorf("expected the result of the probe to be reused, got %v", check)
+	}
+	if pings := atomic.LoadInt32(&pinger.pings); pings != 1 {
+		t.Errorf("expected Mavenlink to be probed once within the interval, got %d", pings)
+	}
+}
+
+// expiredTokenSource has a token which expired and could not be refreshed
+type expiredTokenSource struct{}
+
+func (source expiredTokenSource) Authorized() error {
+	return errors.New("The Mavenlink token expired and could not be refreshed")
+}
+
+func TestCheckTokenSource(t *testing.T) {
+	checker := New(&communicator.EnvironmentConfiguration{Url: "https://api.mavenlink.com/api/v1/", OauthEnabled: true}, nil)
+	checker.WatchTokenSource(expiredTokenSource{})
+	response := checker.Check(false)
+	if response.Status != StatusFailing || response.Checks[1].Name != "token" || response.Checks[1].Status != StatusFailing {
+		t.Errorf("expected the expired token to fail the service, got %v", response)
+	}
+}
@@ -1,17 +1,44 @@
 package log
 
 import (
-	"github.com/micro/go-log"
+	microErrors "github.com/micro/go-micro/errors"
 	"github.com/micro/go-micro/server"
 	"golang.org/x/net/context"
 	"time"
 )
 
+// The server ends successful streams with this error
+const endOfStream = "EOS"
+
 // ConsoleLogWrapper implements the server.HandlerWrapper for
-// logging information to the console
+// logging information to the console. Every request is given the ID passed
+// by its caller, or a new one, shared by the entries written while serving it
 func ConsoleLogWrapper(fn server.HandlerFunc) server.HandlerFunc {
 	return func(ctx context.Context, req server.Request, rsp interface{}) error {
-		log.Logf("[%v] server request: %s\n", time.Now(), req.Method())
-		return fn(ctx, req, rsp)
+		id := RequestIDFrom(ctx)
+		if len(id) < 1 {
+			id = NewRequestID()
+		}
+		ctx = WithRequestID(ctx, id)
+		started := time.Now()
+		err := fn(ctx, req, rsp)
+		fields := Fields{
+			"request_id": id,
+			"method":     req.Method(),
+			"stream":     req.Stream(),
+			"latency_ms": float64(time.Since(started)) / float64(time.Millisecond),
+			"status":     200,
+		}
+		if err == nil || err.Error() == endOfStream {
+			Info("server request", fields)
+			return err
+		}
+		fields["status"] = 500
+		if rpcErr := microErrors.Parse(err.Error()); rpcErr.Code > 0 {
+			fields["status"] = rpcErr.Code
+		}
+		fields["error"] = err
+		Warn("server request", fields)
+		return err
 	}
 }
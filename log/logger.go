@@ -0,0 +1,111 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log entry
+type Level int
+
+// Levels of the entries, an entry being written when its level is at least the logger's
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+var levelNames = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+}
+
+// ParseLevel returns the level named by the value(param: name), e.g. "debug"
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("unknown log level %q", name)
+}
+
+// Fields are the structured values of a log entry
+type Fields map[string]interface{}
+
+// Logger writes entries as JSON objects, one per line, with their sensitive
+// values redacted
+type Logger struct {
+	mutex sync.Mutex
+	out   io.Writer
+	level Level
+}
+
+// std is the logger used by the package functions
+var std = &Logger{out: os.Stderr, level: InfoLevel}
+
+// SetLevel sets the lowest level(param: level) of the entries written
+func SetLevel(level Level) {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	std.level = level
+}
+
+// SetOutput sets the writer(param: out) entries are written to
+func SetOutput(out io.Writer) {
+	std.mutex.Lock()
+	defer std.mutex.Unlock()
+	std.out = out
+}
+
+// Debug writes an entry useful when investigating an issue
+func Debug(message string, fields Fields) {
+	std.write(DebugLevel, message, fields)
+}
+
+// Info writes an entry describing the normal operation of the service
+func Info(message string, fields Fields) {
+	std.write(InfoLevel, message, fields)
+}
+
+// Warn writes an entry describing an issue the service recovered from
+func Warn(message string, fields Fields) {
+	std.write(WarnLevel, message, fields)
+}
+
+// Error writes an entry describing a failure
+func Error(message string, fields Fields) {
+	std.write(ErrorLevel, message, fields)
+}
+
+func (logger *Logger) write(level Level, message string, fields Fields) {
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	if level < logger.level {
+		return
+	}
+	entry := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		entry[key] = redactField(key, value)
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = levelNames[level]
+	entry["msg"] = Redact(message)
+	var line bytes.Buffer
+	encoder := json.NewEncoder(&line)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(entry); err != nil {
+		line.Reset()
+		encoder.Encode(map[string]string{"level": "error", "msg": "Failed to encode log entry: " + err.Error()})
+	}
+	logger.out.Write(line.Bytes())
+}
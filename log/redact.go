@@ -0,0 +1,52 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Replacement of the redacted values
+const redacted = "[REDACTED]"
+
+// Fields whose name contains one of these words hold credentials and are never written
+var sensitiveKeys = []string{"authorization", "token", "secret", "password", "api_key", "apikey"}
+
+// Patterns of the credentials and personal data redacted from every written value
+var (
+	authorizationPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+	jwtPattern           = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	credentialPattern    = regexp.MustCompile(`(?i)\b((?:access_|refresh_)?token|client_secret|secret|password|api_key|code)=[^&\s"]+`)
+	emailPattern         = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+)
+
+// Redact replaces the tokens and email addresses found in the value(param: value)
+func Redact(value string) string {
+	value = authorizationPattern.ReplaceAllString(value, "$1 "+redacted)
+	value = jwtPattern.ReplaceAllString(value, redacted)
+	value = credentialPattern.ReplaceAllString(value, "$1="+redacted)
+	return emailPattern.ReplaceAllString(value, redacted)
+}
+
+// redactField returns the value(param: value) of a field(param: key) as written in an entry
+func redactField(key string, value interface{}) interface{} {
+	lowerKey := strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(lowerKey, sensitive) {
+			return redacted
+		}
+	}
+	switch typed := value.(type) {
+	case nil, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+		return typed
+	case string:
+		return Redact(typed)
+	case error:
+		return Redact(typed.Error())
+	case time.Duration:
+		return typed.String()
+	default:
+		return Redact(fmt.Sprint(typed))
+	}
+}
@@ -0,0 +1,43 @@
+package log
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+	"strings"
+)
+
+// RequestIDKey is the metadata key and HTTP header carrying the ID of a request
+const RequestIDKey = "X-Request-Id"
+
+// Key of the request ID in the context of a request
+type requestIDKey struct{}
+
+// NewRequestID returns a random ID identifying the entries of a request
+func NewRequestID() string {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// WithRequestID returns a copy of the context(param: ctx) carrying the request ID(param: id)
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom returns the ID of the request of the context(param: ctx), taken from
+// the context itself or from the metadata passed by the caller, or an empty string
+func RequestIDFrom(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+	if md, ok := metadata.FromContext(ctx); ok {
+		for key, value := range md {
+			if strings.EqualFold(key, RequestIDKey) {
+				return value
+			}
+		}
+	}
+	return ""
+}
@@ -1,40 +1,102 @@
 package main
 
 import (
+	"flag"
 	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/auth"
+	"github.com/desertjinn/mavenlink-communicator/cache"
+	"github.com/desertjinn/mavenlink-communicator/cli"
+	"github.com/desertjinn/mavenlink-communicator/config"
+	"github.com/desertjinn/mavenlink-communicator/events"
+	"github.com/desertjinn/mavenlink-communicator/gateway"
+	"github.com/desertjinn/mavenlink-communicator/graph"
+	"github.com/desertjinn/mavenlink-communicator/health"
 	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	"github.com/desertjinn/mavenlink-communicator/memory"
+	"github.com/desertjinn/mavenlink-communicator/metrics"
+	"github.com/desertjinn/mavenlink-communicator/mirror"
+	"github.com/desertjinn/mavenlink-communicator/oauth"
 	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
-	"github.com/kelseyhightower/envconfig"
+	"github.com/desertjinn/mavenlink-communicator/tenant"
+	"github.com/desertjinn/mavenlink-communicator/trace"
+	"github.com/desertjinn/mavenlink-communicator/webhook"
 	"github.com/micro/go-micro"
+	microErrors "github.com/micro/go-micro/errors"
+	"github.com/pkg/errors"
 	//k8s "github.com/micro/kubernetes/go/micro"
 	"golang.org/x/net/context"
 	"log"
+	"net"
+	"os"
+	"time"
 )
 
-// Define the interface available in this service
+// Name of this service, which must match the package name given in the protobuf definition
+const serviceName = "costrategix.service.mavenlink.communicator"
+
+// Define the interface available in this service. The records returned by the cache are
+// shared between callers and must never be modified, the handlers hand out lists and maps
+// of their own so that wrappers, such as the one of auth, may remove the records of a response
 type service struct {
 	mavenlink API.MavenlinkApiInterface
+	tenants   *tenant.Pool
+	health    *health.Checker
+}
+
+// mavenlinkFor returns the Mavenlink API used to serve the request(param: req), acting
+// with the credentials the caller passed in the metadata of the context(param: ctx)
+// and skipping the cache when the caller asks to bypass it
+func (s *service) mavenlinkFor(ctx context.Context, req *communicator.Request) (API.MavenlinkApiInterface, error) {
+	mavenlink := s.mavenlink
+	if s.tenants != nil {
+		tenantMavenlink, err := s.tenants.For(ctx)
+		if err != nil {
+			return nil, microErrors.Unauthorized(serviceName, "%s", err.Error())
+		}
+		if tenantMavenlink != nil {
+			mavenlink = tenantMavenlink
+		}
+	}
+	if cached, ok := mavenlink.(*cache.MavenlinkCache); ok && req.BypassCache {
+		mavenlink = cached.Uncached()
+	}
+	// Record the calls made to serve the request as part of its trace, and make
+	// them on behalf of the request so that they carry its ID
+	if trace.Enabled() {
+		mavenlink = API.Traced(mavenlink, ctx)
+	} else {
+		mavenlink = API.WithContext(mavenlink, ctx)
+	}
+	return mavenlink, nil
 }
 
 // GetAllProjects can be used to retrieve the list of all available projects
 func (s *service) GetAllProjects(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	projects, err := s.mavenlink.GetProjects()
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	projects, err := mavenlink.GetProjects()
+	if err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved project data to response
-	res.Projects = projects
+	res.Projects = append([]*communicator.Project(nil), projects...)
 	return nil
 }
 
 // GetProjectById can be used to retrieve a single project by ID from Mavenlink
 func (s *service) GetProjectById(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	project, err := s.mavenlink.GetProject(req.Workspace)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	project, err := mavenlink.GetProject(req.Workspace)
+	if err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved project data to response
 	res.Project = project
 	return nil
@@ -43,106 +105,610 @@ func (s *service) GetProjectById(ctx context.Context, req *communicator.Request,
 // GetTasksByProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetTasksByProjectId(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	tasks, err := s.mavenlink.GetTasksFromProjectId(req.Workspace)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	tasks, err := mavenlink.GetTasksFromProjectId(req.Workspace)
+	if err != nil {
+		return rpcError(err)
+	}
+	if tasks, err = withDependencies(mavenlink, req, tasks); err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
-	res.Tasks = tasks
+	res.Tasks = append([]*communicator.Task(nil), tasks...)
 	return nil
 }
 
 // GetSubTasksByProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetSubTasksByParentTaskAndProjectId(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	tasks, err := s.mavenlink.GetSubTasksFromProjectId(req.Workspace, req.Task)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	tasks, err := mavenlink.GetSubTasksFromProjectId(req.Workspace, req.Task)
+	if err != nil {
+		return rpcError(err)
+	}
+	if tasks, err = withDependencies(mavenlink, req, tasks); err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
-	res.Tasks = tasks
+	res.Tasks = append([]*communicator.Task(nil), tasks...)
 	return nil
 }
 
 // GetTasksFromParentTaskAndProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetTasksBySubTaskParentTaskAndProjectId(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	tasks, err := s.mavenlink.GetIssueTasksFromProjectId(req.Workspace, req.SubTask)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	tasks, err := mavenlink.GetIssueTasksFromProjectId(req.Workspace, req.SubTask)
+	if err != nil {
+		return rpcError(err)
+	}
+	if tasks, err = withDependencies(mavenlink, req, tasks); err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
-	res.Tasks = tasks
+	res.Tasks = append([]*communicator.Task(nil), tasks...)
 	return nil
 }
 
 // GetTasksFromParentTaskAndProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetTimeentries(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	timeentries, err := s.mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(req.Workspace, req.Task)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	timeentries, err := mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(req.Workspace, req.Task)
+	if err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
-	res.Timeentries = timeentries
+	res.Timeentries = append([]*communicator.Timeentry(nil), timeentries...)
 	return nil
 }
 
 // GetTasksFromParentTaskAndProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetUsers(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
 	// Retrieve all projects
-	users, err := s.mavenlink.GetUsersFromProjectId(req.Workspace)
+	mavenlink, err := s.mavenlinkFor(ctx, req)
 	if err != nil {
 		return err
 	}
+	users, err := mavenlink.GetUsersFromProjectId(req.Workspace)
+	if err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
-	res.Users = users
+	res.Users = append([]*communicator.User(nil), users...)
 	return nil
 }
 
 // GetTasksFromParentTaskAndProjectId can be used to retrieve all stories by workspace ID from Mavenlink
 func (s *service) GetUser(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
-	// Retrieve all projects
-	user := s.mavenlink.GetUserFromProjectId(req.Workspace, req.KeyOrId)
+	var user *communicator.User
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	// Retrieve the user, from the workspace's participants when a workspace is provided
+	if len(req.Workspace) > 0 {
+		user, err = mavenlink.GetUserFromProjectId(req.Workspace, req.KeyOrId)
+	} else {
+		user, err = mavenlink.GetUserById(req.KeyOrId)
+	}
+	if err != nil {
+		return rpcError(err)
+	}
 	// Assign retrieved tasks to response
 	res.User = user
 	return nil
 }
 
+// GetCriticalPathByProjectId can be used to compute the critical path and slack of a workspace from Mavenlink
+func (s *service) GetCriticalPathByProjectId(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Compute the critical path from the workspace's stories
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	criticalPath, err := mavenlink.GetCriticalPathFromProjectId(req.Workspace)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign computed critical path to response
+	res.CriticalPath = criticalPath
+	return nil
+}
+
+// GetTaskTree can be used to retrieve all stories of a workspace from Mavenlink nested under their parents
+func (s *service) GetTaskTree(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Retrieve the task tree
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	tree, err := mavenlink.GetTaskTreeFromProjectId(req.Workspace, req.IncludeLoggedTime)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign retrieved task tree to response
+	res.TaskTree = append([]*communicator.TaskNode(nil), tree...)
+	return nil
+}
+
+// StreamProjects can be used to receive all available projects one at a time as pages arrive from Mavenlink
+func (s *service) StreamProjects(ctx context.Context, req *communicator.Request,
+	stream communicator.MavenlinkCommunicator_StreamProjectsStream) error {
+	// Send each project as soon as its page is retrieved
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	return rpcError(mavenlink.StreamProjects(req.PerPage, func(project *communicator.Project) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return stream.Send(project)
+	}))
+}
+
+// StreamTasks can be used to receive all stories of a workspace one at a time as pages arrive from Mavenlink
+func (s *service) StreamTasks(ctx context.Context, req *communicator.Request,
+	stream communicator.MavenlinkCommunicator_StreamTasksStream) error {
+	// Send each task as soon as its page is retrieved
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	return rpcError(mavenlink.StreamTasks(req.Workspace, req.PerPage, func(task *communicator.Task) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return stream.Send(task)
+	}))
+}
+
+// StreamTimeEntries can be used to receive time entries of a workspace, or of every workspace when none
+// is provided, one at a time as pages arrive from Mavenlink
+func (s *service) StreamTimeEntries(ctx context.Context, req *communicator.Request,
+	stream communicator.MavenlinkCommunicator_StreamTimeEntriesStream) error {
+	// Send each time entry as soon as its page is retrieved
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	return rpcError(mavenlink.StreamTimeEntries(req.Workspace, req.PerPage, func(timeentry *communicator.Timeentry) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return stream.Send(timeentry)
+	}))
+}
+
+// GetProjectsByIds can be used to retrieve several projects by ID from Mavenlink
+func (s *service) GetProjectsByIds(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Retrieve the requested projects
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	projects, notFound, err := mavenlink.GetProjectsByIds(req.Ids)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign copies of the retrieved projects and missing IDs to response, the cached ones being shared
+	res.ProjectsById = make(map[string]*communicator.Project, len(projects))
+	for id, project := range projects {
+		res.ProjectsById[id] = project
+	}
+	res.NotFound = append([]string(nil), notFound...)
+	return nil
+}
+
+// GetTasksByIds can be used to retrieve several stories by ID from Mavenlink
+func (s *service) GetTasksByIds(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Retrieve the requested tasks
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	tasks, notFound, err := mavenlink.GetTasksByIds(req.Ids)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign copies of the retrieved tasks and missing IDs to response, the cached ones being shared
+	res.TasksById = make(map[string]*communicator.Task, len(tasks))
+	for id, task := range tasks {
+		res.TasksById[id] = task
+	}
+	res.NotFound = append([]string(nil), notFound...)
+	return nil
+}
+
+// GetUsersByIds can be used to retrieve several users by ID from Mavenlink
+func (s *service) GetUsersByIds(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Retrieve the requested users
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	users, notFound, err := mavenlink.GetUsersByIds(req.Ids)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign copies of the retrieved users and missing IDs to response, the cached ones being shared
+	res.UsersById = make(map[string]*communicator.User, len(users))
+	for id, user := range users {
+		res.UsersById[id] = user
+	}
+	res.NotFound = append([]string(nil), notFound...)
+	return nil
+}
+
+// GetTimeentriesByIds can be used to retrieve several time entries by ID from Mavenlink
+func (s *service) GetTimeentriesByIds(ctx context.Context, req *communicator.Request, res *communicator.Response) error {
+	// Retrieve the requested time entries
+	mavenlink, err := s.mavenlinkFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	timeentries, notFound, err := mavenlink.GetTimeEntriesByIds(req.Ids)
+	if err != nil {
+		return rpcError(err)
+	}
+	// Assign copies of the retrieved time entries and missing IDs to response, the cached ones being shared
+	res.TimeentriesById = make(map[string]*communicator.Timeentry, len(timeentries))
+	for id, timeentry := range timeentries {
+		res.TimeentriesById[id] = timeentry
+	}
+	res.NotFound = append([]string(nil), notFound...)
+	return nil
+}
+
+// Health reports whether the service is able to serve requests, probing Mavenlink when asked to
+func (s *service) Health(ctx context.Context, req *communicator.HealthRequest, res *communicator.HealthResponse) error {
+	*res = *s.health.Check(req.Probe)
+	return nil
+}
+
+// withDependencies returns the tasks(param: tasks) of the workspace of the request(param: req)
+// with their predecessors and successors when the caller asks for them
+func withDependencies(mavenlink API.MavenlinkApiInterface, req *communicator.Request,
+	tasks []*communicator.Task) ([]*communicator.Task, error) {
+
+	if !req.IncludeDependencies || len(tasks) < 1 {
+		return tasks, nil
+	}
+	dependencies, err := mavenlink.GetTaskDependenciesFromProjectId(req.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	return API.WithDependencies(tasks, dependencies), nil
+}
+
+// rpcError converts errors returned by the Mavenlink API into errors carrying
+// the matching status code for the caller. Failures of Mavenlink itself are
+// reported as 502, and as 503 when it is unavailable or cannot be reached
+func rpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if API.IsNotFound(err) {
+		return microErrors.NotFound(serviceName, "%s", err.Error())
+	}
+	if statusErr, ok := errors.Cause(err).(*API.StatusError); ok {
+		switch code := statusErr.StatusCode; {
+		case code == 400:
+			return microErrors.BadRequest(serviceName, "%s", err.Error())
+		case code == 401:
+			return microErrors.Unauthorized(serviceName, "%s", err.Error())
+		case code == 403:
+			return microErrors.Forbidden(serviceName, "%s", err.Error())
+		case code == 429 || code == 503:
+			return &retryableError{microErrors.New(serviceName, err.Error(), int32(code)), statusErr.RetryAfter}
+		case code >= 500:
+			return microErrors.New(serviceName, err.Error(), 502)
+		}
+	}
+	if _, ok := errors.Cause(err).(net.Error); ok {
+		return microErrors.New(serviceName, err.Error(), 503)
+	}
+	return err
+}
+
+// retryableError is the RPC error of a call Mavenlink rejected for the time being, keeping
+// the delay it asked callers to wait before retrying, zero when it did not say
+type retryableError struct {
+	rpcErr     error
+	retryAfter time.Duration
+}
+
+func (err *retryableError) Error() string {
+	return err.rpcErr.Error()
+}
+
+// RetryAfter returns the delay Mavenlink asked to wait before retrying
+func (err *retryableError) RetryAfter() time.Duration {
+	return err.retryAfter
+}
+
+// setLogLevel writes the entries of the level of the configuration(param: configuration)
+// and above, every entry in debug mode
+func setLogLevel(configuration *communicator.EnvironmentConfiguration) {
+	level := LOG.InfoLevel
+	if len(configuration.LogLevel) > 0 {
+		level, _ = LOG.ParseLevel(configuration.LogLevel)
+	}
+	if configuration.Debug == true {
+		level = LOG.DebugLevel
+	}
+	LOG.SetLevel(level)
+}
+
+// configure loads the configuration(param: env) from its file and the environment, failing
+// when invalid, and applies the settings of the API client. The OAuth token source is
+// returned when OAuth is enabled
+func configure(env *communicator.EnvironmentConfiguration) (*oauth.Source, error) {
+	if err := config.Load(env); err != nil {
+		return nil, err
+	}
+	setLogLevel(env)
+	API.SetRequestTimeout(time.Duration(env.RequestTimeout) * time.Second)
+	// Record Mavenlink's responses, or replay them to work offline, when asked to
+	if err := API.SetHTTPMode(env.HttpMode, env.CassetteDir); err != nil {
+		return nil, err
+	}
+	// Authenticate with the token granted through Mavenlink's OAuth flow
+	// unless a static token is configured
+	if env.OauthEnabled == false {
+		return nil, nil
+	}
+	source, err := oauth.NewSource(env)
+	if err != nil {
+		return nil, err
+	}
+	API.SetTokenSource(source)
+	return source, nil
+}
+
 func main() {
 	var env communicator.EnvironmentConfiguration
-	// Retrieve environment configuration
-	envConfigErr := envconfig.Process("mavenlink-communicator", &env)
-	if envConfigErr != nil {
-		log.Fatal(envConfigErr)
+	// Run a command of the CLI instead of the server when one is given, the configuration
+	// is only loaded by the commands calling Mavenlink since -rpc ones call the service
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		load := func() (*communicator.EnvironmentConfiguration, error) {
+			_, err := configure(&env)
+			return &env, err
+		}
+		cliErr := cli.Run(os.Args[1:], load, serviceName, os.Stdout)
+		if cliErr == flag.ErrHelp {
+			return
+		}
+		if cliErr != nil {
+			log.Fatal(cliErr)
+		}
+		return
+	}
+	// Retrieve the configuration from its file and the environment, failing fast when invalid
+	oauthSource, configErr := configure(&env)
+	if configErr != nil {
+		log.Fatal(configErr)
 	}
 
 	// Create an instance of the interface provided in this service
 	// note: we're not setting env during initialization as the struct
 	//       members are private
-	mavenlink := &API.MavenlinkApi{}
-	mavenlink.SetEnv(&env)
+	mavenlinkApi := &API.MavenlinkApi{}
+	mavenlinkApi.SetEnv(&env)
+	var mavenlink API.MavenlinkApiInterface = mavenlinkApi
+	var pinger health.Pinger = mavenlinkApi
+	// Serve the records of an in-memory account instead of Mavenlink's when enabled
+	var backend *memory.Backend
+	if env.MemoryEnabled == true {
+		seed := memory.DefaultSeed()
+		if len(env.MemorySeedDir) > 0 {
+			var seedErr error
+			seed, seedErr = memory.LoadSeed(env.MemorySeedDir)
+			if seedErr != nil {
+				log.Fatal(seedErr)
+			}
+		}
+		var backendErr error
+		backend, backendErr = memory.New(seed, &env)
+		if backendErr != nil {
+			log.Fatal(backendErr)
+		}
+		mavenlink = backend
+		pinger = backend
+	}
+	// Keep a local mirror in sync in the background when reads are served from
+	// it or when changes are published as events
+	var store *mirror.Store
+	var syncer *mirror.Syncer
+	stopSync := make(chan struct{})
+	if env.MirrorEnabled == true || env.EventsEnabled == true {
+		var storeErr error
+		store, storeErr = mirror.Open(env.MirrorPath)
+		if storeErr != nil {
+			log.Fatal(storeErr)
+		}
+		defer store.Close()
+		syncer = mirror.NewSyncer(mavenlinkApi, store, &env)
+	}
+	if env.MirrorEnabled == true {
+		mavenlink = mirror.New(mavenlink, store, &env)
+	}
+	// Keep rarely changing Mavenlink data in memory unless disabled, or
+	// served from memory already where caching would hide the writes
+	if env.CacheDisabled == false && backend == nil {
+		mavenlink = cache.New(mavenlink, &env)
+	}
+	var cacheStats func() (uint64, uint64, int)
+	if cached, ok := mavenlink.(*cache.MavenlinkCache); ok {
+		cacheStats = func() (uint64, uint64, int) {
+			stats := cached.Stats()
+			return stats.Hits, stats.Misses, stats.Entries
+		}
+	}
 
-	// Create a new service
-	srv := micro.NewService(
+	// Verify the configuration and report the status of the cache and of the sync
+	checker := health.New(&env, pinger)
+	if oauthSource != nil {
+		checker.WatchTokenSource(oauthSource)
+	}
+	if cacheStats != nil {
+		checker.WatchCache(cacheStats)
+	}
+	if store != nil {
+		checker.WatchSync(store.LastSync, mirror.Staleness(&env))
+	}
+
+	options := []micro.Option{
 		// This name must match the package name given in the protobuf definition
-		micro.Name("costrategix.service.mavenlink.communicator"),
+		micro.Name(serviceName),
 		micro.Version("v1"),
 		// Specify a log wrapper to log requests to this service in the console
 		micro.WrapHandler(LOG.ConsoleLogWrapper),
-	)
+	}
+	// Count and time the requests served when metrics are enabled
+	if env.MetricsEnabled == true {
+		options = append(options, micro.WrapHandler(metrics.Wrapper))
+	}
+	// Trace the requests served and the calls made to Mavenlink when an exporter is configured
+	if len(env.TraceExporter) > 0 {
+		exporter, exporterErr := trace.NewExporter(&env, serviceName)
+		if exporterErr != nil {
+			log.Fatal(exporterErr)
+		}
+		trace.SetExporter(exporter)
+		options = append(options, micro.WrapHandler(trace.Wrapper))
+	}
+	// Only let authenticated callers in, within the limits of the policy
+	if env.AuthEnabled == true {
+		authenticator, authErr := auth.New(&env)
+		if authErr != nil {
+			log.Fatal(authErr)
+		}
+		options = append(options, micro.WrapHandler(authenticator.Wrapper))
+	}
+	// Create a new service
+	srv := micro.NewService(options...)
 	// Init will parse the command line flags.
 	srv.Init()
 
 	// Register handler
-	communicator.RegisterMavenlinkCommunicatorHandler(srv.Server(), &service{mavenlink})
+	handler := &service{mavenlink, tenant.NewPool(&env), checker}
+	// Tokens of the callers are meaningless to the in-memory backend
+	if backend != nil {
+		handler.tenants = nil
+	}
+	communicator.RegisterMavenlinkCommunicatorHandler(srv.Server(), handler)
+
+	// Expose the RPCs as HTTP JSON routes when enabled
+	if env.GatewayEnabled == true {
+		go func() {
+			log.Fatal(gateway.ListenAndServe(handler, &env))
+		}()
+	}
+
+	// Answer GraphQL queries over the Mavenlink resources when enabled
+	if env.GraphqlEnabled == true {
+		go func() {
+			log.Fatal(graph.ListenAndServe(mavenlink, handler.tenants, &env))
+		}()
+	}
+
+	// Publish the changes found by the syncs on the broker when enabled
+	if env.EventsEnabled == true {
+		publisher := events.NewPublisher(srv.Client(), store)
+		go publisher.Run(stopSync)
+		syncer.Listen(publisher)
+	}
+	if syncer != nil {
+		go syncer.Run(stopSync)
+	}
+
+	// Receive Mavenlink's notifications when enabled, dropping the cached results they affect
+	if env.WebhookEnabled == true {
+		var invalidators webhook.Invalidators
+		if cached, ok := mavenlink.(*cache.MavenlinkCache); ok {
+			invalidators = append(invalidators, cached)
+		}
+		// Tenants keep caches of their own
+		if handler.tenants != nil {
+			invalidators = append(invalidators, handler.tenants)
+		}
+		publisher := micro.NewPublisher(events.ResourceChangedTopic, srv.Client())
+		go func() {
+			log.Fatal(webhook.ListenAndServe(&env, invalidators, publisher))
+		}()
+	}
+
+	// Serve the endpoints authorizing the application when OAuth is enabled
+	if oauthSource != nil {
+		go func() {
+			log.Fatal(oauth.ListenAndServe(oauthSource, &env))
+		}()
+	}
+
+	// Expose the metrics of the service to Prometheus when enabled
+	if env.MetricsEnabled == true {
+		if cacheStats != nil {
+			metrics.RegisterCache("shared", cacheStats)
+		}
+		if handler.tenants != nil {
+			metrics.RegisterCache("tenants", handler.tenants.Stats)
+		}
+		go func() {
+			log.Fatal(metrics.ListenAndServe(&env))
+		}()
+	}
+
+	// Serve the writes of the in-memory backend when enabled
+	if backend != nil {
+		go func() {
+			log.Fatal(memory.ListenAndServe(backend, &env))
+		}()
+	}
+
+	// Serve the liveness and readiness probes when enabled
+	if env.HealthEnabled == true {
+		go func() {
+			log.Fatal(health.ListenAndServe(checker, &env))
+		}()
+	}
+
+	// Apply the reloadable settings when the configuration changes, letting in-flight
+	// requests finish with the configuration they started with
+	reloader := config.NewReloader(&env, func(configuration *communicator.EnvironmentConfiguration) {
+		setLogLevel(configuration)
+		API.SetRequestTimeout(time.Duration(configuration.RequestTimeout) * time.Second)
+		if err := mavenlink.SetEnv(configuration); err != nil {
+			LOG.Error("Configuration not applied", LOG.Fields{"error": err})
+		}
+		if handler.tenants != nil {
+			handler.tenants.Reload(configuration)
+		}
+		checker.Reload(configuration)
+	})
+	go reloader.Run(stopSync)
 
 	// Run the server
-	if serverError := srv.Run(); serverError != nil {
+	serverError := srv.Run()
+	close(stopSync)
+	if serverError != nil {
 		if env.Debug == true {
 			log.Fatal(serverError)
 		} else {
-			log.Printf("Error initializing the service : %s", serverError.Error())
+			LOG.Error("Service stopped", LOG.Fields{"error": serverError})
 		}
 
 	}
@@ -0,0 +1,538 @@
+package main
+
+import (
+	"encoding/json"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/cache"
+	"github.com/desertjinn/mavenlink-communicator/gateway"
+	"github.com/desertjinn/mavenlink-communicator/health"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/desertjinn/mavenlink-communicator/tenant"
+	microErrors "github.com/micro/go-micro/errors"
+	"github.com/micro/go-micro/metadata"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestService returns the handler of the service calling a fake Mavenlink server,
+// through the cache when asked to(param: cached). The test(param: t) must close the server
+func newTestService(t *testing.T, cached bool) (*service, *mavenlinktest.Server) {
+	server := mavenlinktest.NewServer()
+	env := server.Config()
+	api := new(API.MavenlinkApi)
+	if err := api.SetEnv(env); err != nil {
+		t.Fatal(err)
+	}
+	var mavenlink API.MavenlinkApiInterface = api
+	if cached {
+		mavenlink = cache.New(api, env)
+	}
+	return &service{mavenlink, tenant.NewPool(env), health.New(env, api)}, server
+}
+
+// codeOf returns the status code carried by the RPC error(param: err), decoded as RPC clients do
+func codeOf(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	return microErrors.Parse(err.Error()).Code
+}
+
+func TestGetAllProjectsHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	res := &communicator.Response{}
+	if err := handler.GetAllProjects(context.Background(), &communicator.Request{}, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Projects) != 3 {
+		t.Errorf("expected every project, got %v", res.Projects)
+	}
+}
+
+func TestGetProjectByIdHandlerNotFound(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	err := handler.GetProjectById(context.Background(), &communicator.Request{Workspace: "9999"}, &communicator.Response{})
+	if codeOf(err) != http.StatusNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestGetTasksByProjectIdHandlerDependencies(t *testing.T) {
+	handler, server := newTestService(t, true)
+	defer server.Close()
+	// Failing dependencies only fail the callers asking for them, once the request and its two retries failed
+	server.Fail(mavenlinktest.StoryDependencies, mavenlinktest.Fault{Status: http.StatusInternalServerError}, 3)
+	res := &communicator.Response{}
+	if err := handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Tasks) != 3 || len(res.Tasks[0].Predecessors)+len(res.Tasks[0].Successors) != 0 {
+		t.Errorf("expected the tasks without dependencies, got %v", res.Tasks)
+	}
+	req := &communicator.Request{Workspace: "1001", IncludeDependencies: true}
+	if err := handler.GetTasksByProjectId(context.Background(), req, &communicator.Response{}); err == nil {
+		t.Error("expected the failed dependencies to be reported")
+	}
+	res = &communicator.Response{}
+	if err := handler.GetTasksByProjectId(context.Background(), req, res); err != nil {
+		t.Fatal(err)
+	}
+	linked := 0
+	for _, task := range res.Tasks {
+		linked += len(task.Predecessors) + len(task.Successors)
+	}
+	if linked != 4 {
+		t.Errorf("expected both ends of the two dependencies, got %v", res.Tasks)
+	}
+	// The cached tasks are shared and left untouched
+	res = &communicator.Response{}
+	handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, res)
+	for _, task := range res.Tasks {
+		if len(task.Predecessors)+len(task.Successors) != 0 {
+			t.Errorf("expected the cached task %s without dependencies", task.Id)
+		}
+	}
+}
+
+func TestHandlerStatusErrors(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	server.Fail(mavenlinktest.Stories, mavenlinktest.Fault{Status: http.StatusUnauthorized}, 1)
+	err := handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, &communicator.Response{})
+	if codeOf(err) != http.StatusUnauthorized {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+	server.Fail(mavenlinktest.Stories, mavenlinktest.Fault{Status: http.StatusInternalServerError}, 3)
+	err = handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, &communicator.Response{})
+	if err == nil {
+		t.Error("expected the server error to be returned")
+	}
+}
+
+func TestRPCErrors(t *testing.T) {
+	unreachable := &url.Error{Op: "Get", URL: "https://api.mavenlink.com/api/v1/workspaces.json", Err: &net.OpError{
+		Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	cases := []struct {
+		err        error
+		code       int32
+		retryAfter time.Duration
+	}{
+		{&API.NotFoundError{Resource: "Project", Id: "9999"}, http.StatusNotFound, 0},
+		{&API.StatusError{StatusCode: http.StatusForbidden}, http.StatusForbidden, 0},
+		{&API.StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}, http.StatusTooManyRequests, 3 * time.Second},
+		{&API.StatusError{StatusCode: http.StatusInternalServerError}, http.StatusBadGateway, 0},
+		{&API.StatusError{StatusCode: http.StatusServiceUnavailable}, http.StatusServiceUnavailable, 0},
+		{unreachable, http.StatusServiceUnavailable, 0},
+		{errors.New("malformed"), 0, 0},
+	}
+	for _, test := range cases {
+		err := rpcError(test.err)
+		if codeOf(err) != test.code {
+			t.Errorf("%v: expected %d, got %v", test.err, test.code, err)
+		}
+		var retryAfter time.Duration
+		if retryable, ok := err.(*retryableError); ok {
+			retryAfter = retryable.RetryAfter()
+		}
+		if retryAfter != test.retryAfter {
+			t.Errorf("%v: expected to retry after %s, got %s", test.err, test.retryAfter, retryAfter)
+		}
+	}
+}
+
+func TestGatewayUpstreamErrors(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	routes := gateway.New(handler, server.Config())
+	// The fake server asks to retry rate limited calls after a second
+	server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: http.StatusTooManyRequests}, 3)
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/projects", nil))
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected the rate limit to be passed on, got %d %v", w.Code, w.Header())
+	}
+	server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Status: http.StatusInternalServerError}, 3)
+	w = httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/projects", nil))
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected the failure of Mavenlink to be a bad gateway, got %d", w.Code)
+	}
+	server.Close()
+	w = httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/projects", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected unreachable Mavenlink to be unavailable, got %d", w.Code)
+	}
+}
+
+func TestHandlerUsesCallerToken(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	ctx := metadata.NewContext(context.Background(), metadata.Metadata{tenant.TokenKey: "someone-else"})
+	err := handler.GetUsers(ctx, &communicator.Request{Workspace: "1001"}, &communicator.Response{})
+	if codeOf(err) != http.StatusUnauthorized {
+		t.Errorf("expected the token of the caller to be rejected, got %v", err)
+	}
+}
+
+func TestHandlerPassesTheRequestId(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	ctx := metadata.NewContext(context.Background(), metadata.Metadata{LOG.RequestIDKey: "rpc-request"})
+	if err := handler.GetAllProjects(ctx, &communicator.Request{}, &communicator.Response{}); err != nil {
+		t.Fatal(err)
+	}
+	requests := server.Requests(mavenlinktest.Workspaces)
+	if len(requests) != 1 || requests[0].Header.Get(LOG.RequestIDKey) != "rpc-request" {
+		t.Errorf("expected Mavenlink to be called with the ID of the request, got %v", requests)
+	}
+}
+
+func TestHandlerBypassCache(t *testing.T) {
+	handler, server := newTestService(t, true)
+	defer server.Close()
+	for _, bypass := range []bool{false, false, true} {
+		res := &communicator.Response{}
+		if err := handler.GetAllProjects(context.Background(), &communicator.Request{BypassCache: bypass}, res); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 2 {
+		t.Errorf("expected the cache to serve the second call only, got %d requests", len(requests))
+	}
+}
+
+func TestHandlerCacheExpiryAndInvalidation(t *testing.T) {
+	server := mavenlinktest.NewServer()
+	defer server.Close()
+	env := server.Config()
+	env.CacheProjectsTtl = 1
+	api := new(API.MavenlinkApi)
+	if err := api.SetEnv(env); err != nil {
+		t.Fatal(err)
+	}
+	cached := cache.New(api, env)
+	handler := &service{cached, tenant.NewPool(env), health.New(env, api)}
+	call := func(rpc func(context.Context, *communicator.Request, *communicator.Response) error) {
+		if err := rpc(context.Background(), &communicator.Request{Workspace: "1001"}, &communicator.Response{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	call(handler.GetAllProjects)
+	call(handler.GetAllProjects)
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 1 {
+		t.Errorf("expected the projects to be cached, got %d requests", len(requests))
+	}
+	time.Sleep(1100 * time.Millisecond)
+	call(handler.GetAllProjects)
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 2 {
+		t.Errorf("expected the expired projects to be fetched again, got %d requests", len(requests))
+	}
+
+	call(handler.GetTasksByProjectId)
+	server.Reset()
+	call(handler.GetTasksByProjectId)
+	if requests := server.Requests(""); len(requests) != 0 {
+		t.Errorf("expected the tasks to be cached, got %v", requests)
+	}
+	// Webhook notifications invalidate the tasks of the workspace of the changed story
+	cached.InvalidateTask("1001", "3002")
+	call(handler.GetTasksByProjectId)
+	if requests := server.Requests(mavenlinktest.Stories); len(requests) < 1 {
+		t.Error("expected the invalidated tasks to be fetched again")
+	}
+	server.Reset()
+	cached.InvalidateTask("1002", "3102")
+	call(handler.GetTasksByProjectId)
+	if requests := server.Requests(""); len(requests) != 0 {
+		t.Errorf("expected the tasks of other workspaces to stay cached, got %v", requests)
+	}
+}
+
+func TestHandlerResponsesDoNotShareTheCache(t *testing.T) {
+	handler, server := newTestService(t, true)
+	defer server.Close()
+	req := &communicator.Request{Ids: []string{"3001", "3002", "9999"}}
+	res := &communicator.Response{}
+	if err := handler.GetTasksByIds(context.Background(), req, res); err != nil {
+		t.Fatal(err)
+	}
+	// Wrappers may remove the records of a response
+	delete(res.TasksById, "3001")
+	res.NotFound = append(res.NotFound, "3001")
+	list := &communicator.Response{}
+	if err := handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, list); err != nil {
+		t.Fatal(err)
+	}
+	list.Tasks[0] = nil
+
+	res = &communicator.Response{}
+	if err := handler.GetTasksByIds(context.Background(), req, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.TasksById) != 2 || !reflect.DeepEqual(res.NotFound, []string{"9999"}) {
+		t.Errorf("expected the cached tasks to be left untouched, got %v and %v", res.TasksById, res.NotFound)
+	}
+	list = &communicator.Response{}
+	if err := handler.GetTasksByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, list); err != nil {
+		t.Fatal(err)
+	}
+	if list.Tasks[0] == nil {
+		t.Error("expected the cached list to be left untouched")
+	}
+	if requests := server.Requests(mavenlinktest.Stories); len(requests) != 2 {
+		t.Errorf("expected the second calls to be served by the cache, got %d requests", len(requests))
+	}
+}
+
+func TestCriticalPathHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	res := &communicator.Response{}
+	if err := handler.GetCriticalPathByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, res); err != nil {
+		t.Fatal(err)
+	}
+	path := res.CriticalPath
+	if path == nil || path.WorkspaceId != "1001" || path.StartDate != "2018-09-03" || path.FinishDate != "2018-10-26" ||
+		path.Duration != 54 {
+		t.Fatalf("expected the dates of the top level tasks, got %v", path)
+	}
+	// The tasks keep their scheduled dates, the days left before their successors being slack
+	slack := make(map[string]int32)
+	for _, task := range path.Tasks {
+		slack[task.TaskId] = task.Slack
+	}
+	if !reflect.DeepEqual(slack, map[string]int32{"3001": 3, "3004": 1, "3005": 0}) {
+		t.Errorf("expected the slack of the chained tasks, got %v", slack)
+	}
+	if !reflect.DeepEqual(path.TaskIds, []string{"3005"}) {
+		t.Errorf("expected the task without slack to be critical, got %v", path.TaskIds)
+	}
+	server.Fail(mavenlinktest.StoryDependencies, mavenlinktest.Fault{Status: http.StatusForbidden}, 1)
+	err := handler.GetCriticalPathByProjectId(context.Background(), &communicator.Request{Workspace: "1001"}, &communicator.Response{})
+	if codeOf(err) != http.StatusForbidden {
+		t.Errorf("expected the failed dependencies to be reported, got %v", err)
+	}
+}
+
+func TestTaskTreeHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	res := &communicator.Response{}
+	req := &communicator.Request{Workspace: "1001", IncludeLoggedTime: true}
+	if err := handler.GetTaskTree(context.Background(), req, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.TaskTree) != 3 || res.TaskTree[0].Task.Id != "3001" {
+		t.Fatalf("expected the top level tasks at the root, got %v", res.TaskTree)
+	}
+	design := res.TaskTree[0]
+	if len(design.Children) != 1 || len(design.Children[0].Children) != 1 {
+		t.Fatalf("expected the tasks nested under their parents, got %v", design)
+	}
+	wireframes, issue := design.Children[0], design.Children[0].Children[0]
+	if len(wireframes.Assignees) != 2 {
+		t.Errorf("expected the assignees of the task, got %v", wireframes.Assignees)
+	}
+	// The minutes logged against the nested tasks roll up to their parents
+	if issue.LoggedMinutes != 45 || issue.TotalLoggedMinutes != 45 {
+		t.Errorf("expected the minutes of the issue, got %d and %d", issue.LoggedMinutes, issue.TotalLoggedMinutes)
+	}
+	if wireframes.LoggedMinutes != 330 || wireframes.TotalLoggedMinutes != 375 {
+		t.Errorf("expected the minutes of the task and its issue, got %d and %d", wireframes.LoggedMinutes, wireframes.TotalLoggedMinutes)
+	}
+	if design.LoggedMinutes != 0 || design.TotalLoggedMinutes != 375 {
+		t.Errorf("expected the minutes of the milestone's tasks, got %d and %d", design.LoggedMinutes, design.TotalLoggedMinutes)
+	}
+
+	server.Reset()
+	res = &communicator.Response{}
+	if err := handler.GetTaskTree(context.Background(), &communicator.Request{Workspace: "1001"}, res); err != nil {
+		t.Fatal(err)
+	}
+	if res.TaskTree[0].TotalLoggedMinutes != 0 || len(server.Requests(mavenlinktest.TimeEntries)) != 0 {
+		t.Errorf("expected the time entries to be skipped unless asked for, got %v", res.TaskTree[0])
+	}
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	routes := gateway.New(handler, server.Config())
+	cases := []struct {
+		method string
+		target string
+		status int
+		total  int
+	}{
+		{"GET", "/projects", http.StatusOK, 3},
+		{"GET", "/projects?per_page=2&page=2", http.StatusOK, 3},
+		{"GET", "/projects/1001/tasks", http.StatusOK, 3},
+		{"GET", "/projects/1001/users", http.StatusOK, 2},
+		{"GET", "/projects/1001/task-tree", http.StatusOK, 3},
+		{"GET", "/projects/1001", http.StatusOK, -1},
+		{"GET", "/projects/1001/critical-path", http.StatusOK, -1},
+		{"GET", "/projects/9999", http.StatusNotFound, -1},
+		{"GET", "/tasks", http.StatusBadRequest, -1},
+		{"POST", "/projects", http.StatusMethodNotAllowed, -1},
+		{"GET", "/nowhere", http.StatusNotFound, -1},
+	}
+	for _, test := range cases {
+		w := httptest.NewRecorder()
+		routes.ServeHTTP(w, httptest.NewRequest(test.method, test.target, nil))
+		var body struct {
+			Data  json.RawMessage
+			Meta  struct{ Total int }
+			Error *communicator.Error
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s %s: %v", test.method, test.target, err)
+		}
+		if w.Code != test.status {
+			t.Errorf("%s %s: expected %d, got %d %s", test.method, test.target, test.status, w.Code, w.Body.String())
+			continue
+		}
+		if w.Code != http.StatusOK && (body.Error == nil || body.Error.Code != int32(w.Code)) {
+			t.Errorf("%s %s: expected the error to be described, got %s", test.method, test.target, w.Body.String())
+		}
+		if test.total >= 0 && body.Meta.Total != test.total {
+			t.Errorf("%s %s: expected %d items, got %s", test.method, test.target, test.total, w.Body.String())
+		}
+	}
+
+	// Batch lookups list the IDs which were not found
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/tasks?ids=3001,9999", nil))
+	var batch struct {
+		Data     map[string]*communicator.Task
+		NotFound []string `json:"not_found"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &batch); err != nil {
+		t.Fatal(err)
+	}
+	if batch.Data["3001"] == nil || !reflect.DeepEqual(batch.NotFound, []string{"9999"}) {
+		t.Errorf("expected the task found and the ID not found, got %s", w.Body.String())
+	}
+
+	// Every route is described by the OpenAPI document
+	w = httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	var spec struct {
+		Openapi string
+		Paths   map[string]map[string]struct{ OperationId string }
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Openapi != "3.0.0" || len(spec.Paths) != 20 {
+		t.Errorf("expected every route to be described, got %d paths", len(spec.Paths))
+	}
+	for path, operationId := range map[string]string{
+		"/projects/{project}":               "GetProjectById",
+		"/projects/{project}/critical-path": "GetCriticalPathByProjectId",
+		"/projects/{project}/task-tree":     "GetTaskTree",
+		"/health":                           "Health",
+	} {
+		if operation := spec.Paths[path]["get"]; operation.OperationId != operationId {
+			t.Errorf("expected %s to be described by %s, got %v", path, operationId, spec.Paths[path])
+		}
+	}
+}
+
+func TestGetTasksByIdsHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	res := &communicator.Response{}
+	req := &communicator.Request{Ids: []string{"3002", "3101", "3999"}}
+	if err := handler.GetTasksByIds(context.Background(), req, res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.TasksById) != 2 || len(res.NotFound) != 1 || res.NotFound[0] != "3999" {
+		t.Errorf("unexpected response %v", res)
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	res := &communicator.HealthResponse{}
+	if err := handler.Health(context.Background(), &communicator.HealthRequest{Probe: true}, res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Status != health.StatusOk || len(server.Requests(mavenlinktest.Me)) != 1 {
+		t.Errorf("expected a healthy probed service, got %v", res)
+	}
+}
+
+func TestGatewayHealth(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	routes := gateway.New(handler, server.Config())
+	w := httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/health?probe=true", nil))
+	var body struct {
+		Data communicator.HealthResponse
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusOK || body.Data.Status != health.StatusOk || len(server.Requests(mavenlinktest.Me)) != 1 {
+		t.Errorf("expected the probed checks of the service, got %d %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	routes.ServeHTTP(w, httptest.NewRequest("GET", "/openapi.json", nil))
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationId string
+			Parameters  []struct{ Name string }
+		}
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatal(err)
+	}
+	operation := spec.Paths["/health"]["get"]
+	if operation.OperationId != "Health" || len(operation.Parameters) != 1 || operation.Parameters[0].Name != "probe" {
+		t.Errorf("expected the health route to be described, got %v", operation)
+	}
+}
+
+// projectStream collects the projects sent on a stream
+type projectStream struct {
+	projects []*communicator.Project
+}
+
+func (stream *projectStream) SendMsg(interface{}) error { return nil }
+func (stream *projectStream) RecvMsg(interface{}) error { return nil }
+func (stream *projectStream) Close() error              { return nil }
+func (stream *projectStream) Send(project *communicator.Project) error {
+	stream.projects = append(stream.projects, project)
+	return nil
+}
+
+func TestStreamProjectsHandler(t *testing.T) {
+	handler, server := newTestService(t, false)
+	defer server.Close()
+	stream := &projectStream{}
+	if err := handler.StreamProjects(context.Background(), &communicator.Request{PerPage: 1}, stream); err != nil {
+		t.Fatal(err)
+	}
+	if len(stream.projects) != 3 || len(server.Requests(mavenlinktest.Workspaces)) != 3 {
+		t.Errorf("expected a project per page, got %d", len(stream.projects))
+	}
+	server.Fail(mavenlinktest.Workspaces, mavenlinktest.Fault{Malformed: true}, 1)
+	if err := handler.StreamProjects(context.Background(), &communicator.Request{}, &projectStream{}); err == nil {
+		t.Error("expected a malformed page to fail the stream")
+	}
+}
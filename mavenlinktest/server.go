@@ -0,0 +1,394 @@
+// Package mavenlinktest provides an in-process fake of the Mavenlink API serving
+// recorded fixtures, with the pagination, filtering parameters and failures the
+// service relies on, for use in tests
+package mavenlinktest
+
+import (
+	"encoding/json"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Token is the only bearer token the fake server accepts
+const Token = "mavenlinktest-token"
+
+// Path the endpoints are served under, as on Mavenlink
+const basePath = "/api/v1/"
+
+// Page sizes used by Mavenlink when none is asked for, and at most
+const (
+	defaultPerPage = 20
+	maxPerPage     = 200
+)
+
+// Resources served by the fake server, named as their endpoints and fixture files
+const (
+	Workspaces        = "workspaces"
+	Stories           = "stories"
+	TimeEntries       = "time_entries"
+	Users             = "users"
+	StoryDependencies = "story_dependencies"
+	// Me is the endpoint of the user the token belongs to
+	Me = "users/me"
+)
+
+// record is a fixture record, kept as decoded so that it is served as recorded
+type record map[string]interface{}
+
+func (record record) id() string {
+	return stringOf(record["id"])
+}
+
+// Fault is a failure the fake server answers requests with instead of their fixtures
+type Fault struct {
+	// Status is the HTTP status code of the failure, e.g. 401, 429 or 500
+	Status int
+	// Malformed answers with a truncated JSON body and a 200 status
+	Malformed bool
+}
+
+// Request is a request received by the fake server
+type Request struct {
+	Endpoint string
+	Query    url.Values
+	Header   http.Header
+}
+
+// injected is a fault with the number of requests it still applies to, every request when below one
+type injected struct {
+	fault Fault
+	times int
+}
+
+// Server is a fake Mavenlink API listening on a local port
+type Server struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	fixtures map[string][]record
+	faults   map[string][]*injected
+	requests []Request
+}
+
+// NewServer starts a fake server serving the fixtures recorded with this package
+func NewServer() *Server {
+	_, source, _, _ := runtime.Caller(0)
+	server, err := NewServerFromDir(filepath.Join(filepath.Dir(source), "fixtures"))
+	if err != nil {
+		panic(err)
+	}
+	return server
+}
+
+// NewServerFromDir starts a fake server serving the fixtures found in the directory(param: dir),
+// one JSON array of records per resource named after it, e.g. workspaces.json. Missing files
+// leave their resource empty
+func NewServerFromDir(dir string) (*Server, error) {
+	server := &Server{fixtures: make(map[string][]record), faults: make(map[string][]*injected)}
+	for _, resource := range []string{Workspaces, Stories, TimeEntries, Users, StoryDependencies} {
+		raw, err := ioutil.ReadFile(filepath.Join(dir, resource+".json"))
+		if err != nil {
+			continue
+		}
+		var records []record
+		if err := json.Unmarshal(raw, &records); err != nil {
+			return nil, errors.Wrapf(err, "Invalid fixture %s", resource)
+		}
+		server.fixtures[resource] = records
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serve))
+	return server, nil
+}
+
+// BaseURL returns the base URL of the API, ending with a slash as the configuration expects
+func (server *Server) BaseURL() string {
+	return server.URL + basePath
+}
+
+// Config returns a configuration of the service calling the fake server with the accepted token
+func (server *Server) Config() *communicator.EnvironmentConfiguration {
+	return &communicator.EnvironmentConfiguration{Url: server.BaseURL(), Token: Token}
+}
+
+// Fail answers the next requests(param: times) to the endpoint(param: endpoint), e.g.
+// Workspaces, with the fault(param: fault), every following request when times is below one
+func (server *Server) Fail(endpoint string, fault Fault, times int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults[endpoint] = append(server.faults[endpoint], &injected{fault: fault, times: times})
+}
+
+// Requests returns the requests received for the endpoint(param: endpoint), for every endpoint when empty
+func (server *Server) Requests(endpoint string) []Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var requests []Request
+	for _, request := range server.requests {
+		if len(endpoint) < 1 || request.Endpoint == endpoint {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Remove deletes the record(param: id) of the resource(param: resource), e.g. Stories,
+// as if it was deleted in Mavenlink
+func (server *Server) Remove(resource string, id string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var kept []record
+	for _, record := range server.fixtures[resource] {
+		if record.id() != id {
+			kept = append(kept, record)
+		}
+	}
+	server.fixtures[resource] = kept
+}
+
+// Reset drops the injected faults and the received requests
+func (server *Server) Reset() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.faults = make(map[string][]*injected)
+	server.requests = nil
+}
+
+func (server *Server) serve(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, basePath), ".json")
+	query := r.URL.Query()
+	fault, faulty := server.record(endpoint, query, r.Header)
+	if r.Header.Get("Authorization") != "Bearer "+Token {
+		writeError(w, http.StatusUnauthorized, "authentication", "Invalid or missing token")
+		return
+	}
+	if faulty {
+		if fault.Malformed {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"count": 1, "results": [{"key": "`))
+			return
+		}
+		if fault.Status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		writeError(w, fault.Status, "system", http.StatusText(fault.Status))
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "system", "Only reads are supported")
+		return
+	}
+	switch endpoint {
+	case Workspaces, Stories, TimeEntries, Users, StoryDependencies:
+		server.list(w, endpoint, query)
+	case Me:
+		users := server.fixtures[Users]
+		if len(users) < 1 {
+			writeError(w, http.StatusNotFound, "system", "No user")
+			return
+		}
+		writeJSON(w, http.StatusOK, page(Users, users[:1], 1, 1, nil))
+	default:
+		writeError(w, http.StatusNotFound, "system", "Unknown endpoint "+r.URL.Path)
+	}
+}
+
+// record stores the request and returns the fault it must be answered with, if any
+func (server *Server) record(endpoint string, query url.Values, header http.Header) (Fault, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.requests = append(server.requests, Request{Endpoint: endpoint, Query: query, Header: header})
+	faults := server.faults[endpoint]
+	if len(faults) < 1 {
+		return Fault{}, false
+	}
+	next := faults[0]
+	if next.times > 0 {
+		next.times--
+		if next.times == 0 {
+			server.faults[endpoint] = faults[1:]
+		}
+	}
+	return next.fault, true
+}
+
+// list serves the records of the resource(param: resource) matching the filters of the query(param: query)
+func (server *Server) list(w http.ResponseWriter, resource string, query url.Values) {
+	server.mutex.Lock()
+	records := server.fixtures[resource]
+	server.mutex.Unlock()
+	var matching []record
+	for _, record := range records {
+		if server.matches(record, query) {
+			matching = append(matching, record)
+		}
+	}
+	perPage := intParameter(query, "per_page", defaultPerPage)
+	if perPage < 1 || perPage > maxPerPage {
+		writeError(w, http.StatusBadRequest, "validation", "per_page must be between 1 and 200")
+		return
+	}
+	pageNumber := intParameter(query, "page", 1)
+	if pageNumber < 1 {
+		writeError(w, http.StatusBadRequest, "validation", "page must be positive")
+		return
+	}
+	writeJSON(w, http.StatusOK, page(resource, matching, pageNumber, perPage, server.included(resource, matching, query)))
+}
+
+// matches tells whether the record(param: record) passes the filters of the query(param: query)
+func (server *Server) matches(record record, query url.Values) bool {
+	if only := query.Get("only"); len(only) > 0 && !contains(strings.Split(only, ","), record.id()) {
+		return false
+	}
+	if workspace := query.Get("workspace_id"); len(workspace) > 0 && stringOf(record["workspace_id"]) != workspace {
+		return false
+	}
+	if query.Get("parents_only") == "true" && len(stringOf(record["parent_id"])) > 0 {
+		return false
+	}
+	if parent := query.Get("with_parent_id"); len(parent) > 0 && stringOf(record["parent_id"]) != parent {
+		return false
+	}
+	if workspace := query.Get("participant_in"); len(workspace) > 0 && !server.participates(workspace, record.id()) {
+		return false
+	}
+	if after := query.Get("updated_after"); len(after) > 0 {
+		threshold, thresholdErr := time.Parse(time.RFC3339, after)
+		updated, updatedErr := time.Parse(time.RFC3339, stringOf(record["updated_at"]))
+		if thresholdErr != nil || updatedErr != nil || !updated.After(threshold) {
+			return false
+		}
+	}
+	return true
+}
+
+// participates tells whether the user(param: user) participates in the workspace(param: workspace)
+func (server *Server) participates(workspace string, user string) bool {
+	for _, record := range server.fixtures[Workspaces] {
+		if record.id() == workspace {
+			return contains(stringsOf(record["participant_ids"]), user)
+		}
+	}
+	return false
+}
+
+// included returns the users the query(param: query) asks to include with the records(param: records)
+func (server *Server) included(resource string, records []record, query url.Values) []record {
+	var ids []string
+	for _, include := range strings.Split(query.Get("include"), ",") {
+		switch {
+		case resource == Stories && include == "assignees":
+			for _, record := range records {
+				ids = append(ids, stringsOf(record["assignee_ids"])...)
+			}
+		case resource == TimeEntries && include == "user":
+			for _, record := range records {
+				ids = append(ids, stringOf(record["user_id"]))
+			}
+		}
+	}
+	var users []record
+	for _, user := range server.fixtures[Users] {
+		if contains(ids, user.id()) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// page builds the response body of the page(param: pageNumber) of the records(param: records)
+// as Mavenlink does, with the included users(param: users)
+func page(resource string, records []record, pageNumber int, perPage int, users []record) map[string]interface{} {
+	pageCount := (len(records) + perPage - 1) / perPage
+	start := (pageNumber - 1) * perPage
+	if start > len(records) {
+		start = len(records)
+	}
+	end := start + perPage
+	if end > len(records) {
+		end = len(records)
+	}
+	results := make([]map[string]string, 0, end-start)
+	byId := make(map[string]record)
+	for _, record := range records[start:end] {
+		results = append(results, map[string]string{"key": resource, "id": record.id()})
+		byId[record.id()] = record
+	}
+	body := map[string]interface{}{
+		"count":   len(records),
+		"results": results,
+		resource:  byId,
+		"meta": map[string]int{
+			"count":       len(records),
+			"page_count":  pageCount,
+			"page_number": pageNumber,
+			"page_size":   perPage,
+		},
+	}
+	if len(users) > 0 && resource != Users {
+		usersById := make(map[string]record)
+		for _, user := range users {
+			usersById[user.id()] = user
+		}
+		body[Users] = usersById
+	}
+	return body
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError answers with an error body shaped like Mavenlink's
+func writeError(w http.ResponseWriter, status int, kind string, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]string{{"type": kind, "message": message}},
+	})
+}
+
+func intParameter(query url.Values, name string, fallback int) int {
+	value, err := strconv.Atoi(query.Get(name))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func stringOf(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	}
+	return ""
+}
+
+func stringsOf(value interface{}) []string {
+	values, _ := value.([]interface{})
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		strs = append(strs, stringOf(value))
+	}
+	return strs
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.TrimSpace(candidate) == value {
+			return true
+		}
+	}
+	return false
+}
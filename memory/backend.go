@@ -0,0 +1,479 @@
+// Package memory provides a MavenlinkApiInterface keeping its workspaces, stories,
+// time entries and users in memory, so that the service can run without Mavenlink
+package memory
+
+import (
+	"fmt"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Types of stories, as in Mavenlink
+const (
+	Milestone   = "milestone"
+	Task        = "task"
+	Deliverable = "deliverable"
+	Issue       = "issue"
+)
+
+// Stories nest at most three levels deep: tasks, their sub tasks and the issues of those
+const maxDepth = 3
+
+// Types of the dependencies between stories, as in Mavenlink
+var dependencyTypes = map[string]bool{
+	"finish_to_start":  true,
+	"start_to_start":   true,
+	"finish_to_finish": true,
+	"start_to_finish":  true,
+}
+
+// ErrConflict is returned when a record is created with the ID of an existing one
+var ErrConflict = errors.New("A record with this ID already exists")
+
+// RuleError is returned when a write breaks a rule of Mavenlink, e.g. a story
+// nested too deep or a time entry logged by a user outside of the workspace
+type RuleError struct {
+	Rule string
+}
+
+func (e *RuleError) Error() string {
+	return e.Rule
+}
+
+func broken(format string, arguments ...interface{}) error {
+	return &RuleError{Rule: fmt.Sprintf(format, arguments...)}
+}
+
+// Backend keeps the records of a fake Mavenlink account in memory, enforcing the
+// rules of Mavenlink on writes. It is safe for concurrent use
+type Backend struct {
+	// env holds the *communicator.EnvironmentConfiguration
+	env   atomic.Value
+	mutex sync.RWMutex
+	seed  *Seed
+
+	workspaces   map[string]*communicator.MavenlinkWorkspace
+	stories      map[string]*communicator.MavenlinkStory
+	timeentries  map[string]*communicator.MavenlinkTimeentry
+	users        map[string]*communicator.MavenlinkUser
+	dependencies map[string]*communicator.MavenlinkStoryDependency
+	// participants holds the IDs of the participants of each workspace
+	participants map[string]map[string]bool
+	lastId       int64
+}
+
+// New creates a backend holding the records of the seed(param: seed), failing when they break the rules
+func New(seed *Seed, configuration *communicator.EnvironmentConfiguration) (*Backend, error) {
+	backend := &Backend{seed: seed}
+	if err := backend.SetEnv(configuration); err != nil {
+		return nil, err
+	}
+	if err := backend.Reset(); err != nil {
+		return nil, err
+	}
+	return backend, nil
+}
+
+func (backend *Backend) SetEnv(configuration *communicator.EnvironmentConfiguration) error {
+	if configuration == nil {
+		return errors.New("No configurations detected")
+	}
+	backend.env.Store(configuration)
+	return nil
+}
+
+// Reset drops every record and loads the records of the seed again
+func (backend *Backend) Reset() error {
+	backend.mutex.Lock()
+	backend.workspaces = make(map[string]*communicator.MavenlinkWorkspace)
+	backend.stories = make(map[string]*communicator.MavenlinkStory)
+	backend.timeentries = make(map[string]*communicator.MavenlinkTimeentry)
+	backend.users = make(map[string]*communicator.MavenlinkUser)
+	backend.dependencies = make(map[string]*communicator.MavenlinkStoryDependency)
+	backend.participants = make(map[string]map[string]bool)
+	backend.lastId = 0
+	backend.mutex.Unlock()
+	if backend.seed == nil {
+		return nil
+	}
+	return backend.load(backend.seed)
+}
+
+// load writes the records of the seed(param: seed), stories being created after their parents
+func (backend *Backend) load(seed *Seed) error {
+	for _, user := range seed.Users {
+		if _, err := backend.CreateUser(user); err != nil {
+			return errors.Wrapf(err, "Invalid seed user %s", user.Id)
+		}
+	}
+	for _, workspace := range seed.Workspaces {
+		if _, err := backend.CreateWorkspace(workspace); err != nil {
+			return errors.Wrapf(err, "Invalid seed workspace %s", workspace.Id)
+		}
+	}
+	for workspaceId, userIds := range seed.Participants {
+		for _, userId := range userIds {
+			if err := backend.AddParticipant(workspaceId, userId); err != nil {
+				return errors.Wrapf(err, "Invalid seed participant %s of %s", userId, workspaceId)
+			}
+		}
+	}
+	pending := seed.Stories
+	for len(pending) > 0 {
+		var waiting []*communicator.MavenlinkStory
+		for _, story := range pending {
+			if len(story.ParentId) > 0 && backend.story(story.ParentId) == nil {
+				waiting = append(waiting, story)
+				continue
+			}
+			if _, err := backend.CreateStory(story); err != nil {
+				return errors.Wrapf(err, "Invalid seed story %s", story.Id)
+			}
+		}
+		if len(waiting) == len(pending) {
+			return errors.Errorf("Invalid seed story %s: parent %s not found", waiting[0].Id, waiting[0].ParentId)
+		}
+		pending = waiting
+	}
+	for _, dependency := range seed.StoryDependencies {
+		if _, err := backend.CreateStoryDependency(dependency); err != nil {
+			return errors.Wrapf(err, "Invalid seed story dependency %s", dependency.Id)
+		}
+	}
+	for _, timeentry := range seed.TimeEntries {
+		if _, err := backend.CreateTimeEntry(timeentry); err != nil {
+			return errors.Wrapf(err, "Invalid seed time entry %s", timeentry.Id)
+		}
+	}
+	return nil
+}
+
+// CreateWorkspace stores a copy of the workspace(param: workspace), returning it with its ID and timestamps
+func (backend *Backend) CreateWorkspace(workspace *communicator.MavenlinkWorkspace) (*communicator.MavenlinkWorkspace, error) {
+	created := proto.Clone(workspace).(*communicator.MavenlinkWorkspace)
+	if len(created.Title) < 1 {
+		return nil, broken("A workspace needs a title")
+	}
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if err := backend.assignId(&created.Id, backend.workspaces[created.Id] != nil); err != nil {
+		return nil, err
+	}
+	stamp(&created.CreatedAt, &created.UpdatedAt)
+	backend.workspaces[created.Id] = created
+	backend.participants[created.Id] = make(map[string]bool)
+	return proto.Clone(created).(*communicator.MavenlinkWorkspace), nil
+}
+
+// CreateUser stores a copy of the user(param: user), returning it with its ID
+func (backend *Backend) CreateUser(user *communicator.MavenlinkUser) (*communicator.MavenlinkUser, error) {
+	created := proto.Clone(user).(*communicator.MavenlinkUser)
+	if len(created.FullName) < 1 {
+		return nil, broken("A user needs a full name")
+	}
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if err := backend.assignId(&created.Id, backend.users[created.Id] != nil); err != nil {
+		return nil, err
+	}
+	backend.users[created.Id] = created
+	return proto.Clone(created).(*communicator.MavenlinkUser), nil
+}
+
+// AddParticipant makes the user(param: userId) a participant of the workspace(param: workspaceId)
+func (backend *Backend) AddParticipant(workspaceId string, userId string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.workspaces[workspaceId] == nil {
+		return &API.NotFoundError{Resource: "Project", Id: workspaceId}
+	}
+	if backend.users[userId] == nil {
+		return &API.NotFoundError{Resource: "User", Id: userId}
+	}
+	backend.participants[workspaceId][userId] = true
+	return nil
+}
+
+// CreateStory stores a copy of the story(param: story), returning it with its ID and timestamps.
+// A story belongs to an existing workspace, its parent being a story of the same workspace.
+// Milestones are top level stories, and stories nest at most three levels deep
+func (backend *Backend) CreateStory(story *communicator.MavenlinkStory) (*communicator.MavenlinkStory, error) {
+	created := proto.Clone(story).(*communicator.MavenlinkStory)
+	if len(created.StoryType) < 1 {
+		created.StoryType = Task
+	}
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if err := backend.checkStory(created); err != nil {
+		return nil, err
+	}
+	if err := backend.assignId(&created.Id, backend.stories[created.Id] != nil); err != nil {
+		return nil, err
+	}
+	stamp(&created.CreatedAt, &created.UpdatedAt)
+	backend.stories[created.Id] = created
+	return proto.Clone(created).(*communicator.MavenlinkStory), nil
+}
+
+func (backend *Backend) checkStory(story *communicator.MavenlinkStory) error {
+	if len(story.Title) < 1 {
+		return broken("A story needs a title")
+	}
+	switch story.StoryType {
+	case Milestone, Task, Deliverable, Issue:
+	default:
+		return broken("Unknown story type %q", story.StoryType)
+	}
+	if backend.workspaces[story.WorkspaceId] == nil {
+		return &API.NotFoundError{Resource: "Project", Id: story.WorkspaceId}
+	}
+	if len(story.ParentId) > 0 {
+		parent := backend.stories[story.ParentId]
+		if parent == nil {
+			return &API.NotFoundError{Resource: "Task", Id: story.ParentId}
+		}
+		if parent.WorkspaceId != story.WorkspaceId {
+			return broken("Story %s belongs to another workspace", parent.Id)
+		}
+		if story.StoryType == Milestone {
+			return broken("A milestone cannot have a parent")
+		}
+		if backend.depth(parent) >= maxDepth {
+			return broken("Stories cannot be nested more than %d levels deep", maxDepth)
+		}
+	}
+	for _, assignee := range story.AssigneeIds {
+		if !backend.participants[story.WorkspaceId][assignee] {
+			return broken("User %s does not participate in workspace %s", assignee, story.WorkspaceId)
+		}
+	}
+	return nil
+}
+
+// depth returns the level of the story(param: story), one for top level stories
+func (backend *Backend) depth(story *communicator.MavenlinkStory) int {
+	depth := 1
+	for len(story.ParentId) > 0 && backend.stories[story.ParentId] != nil {
+		story = backend.stories[story.ParentId]
+		depth++
+	}
+	return depth
+}
+
+// CreateTimeEntry stores a copy of the time entry(param: timeentry), returning it with its ID
+// and timestamps. Time is logged by a participant of the workspace, on a story of the workspace
+func (backend *Backend) CreateTimeEntry(timeentry *communicator.MavenlinkTimeentry) (*communicator.MavenlinkTimeentry, error) {
+	created := proto.Clone(timeentry).(*communicator.MavenlinkTimeentry)
+	if created.TimeInMinutes < 1 {
+		return nil, broken("A time entry needs a positive time in minutes")
+	}
+	if len(created.DatePerformed) < 1 {
+		created.DatePerformed = time.Now().Format("2006-01-02")
+	}
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.workspaces[created.WorkspaceId] == nil {
+		return nil, &API.NotFoundError{Resource: "Project", Id: created.WorkspaceId}
+	}
+	if len(created.StoryId) > 0 {
+		story := backend.stories[created.StoryId]
+		if story == nil {
+			return nil, &API.NotFoundError{Resource: "Task", Id: created.StoryId}
+		}
+		if story.WorkspaceId != created.WorkspaceId {
+			return nil, broken("Story %s belongs to another workspace", story.Id)
+		}
+	}
+	if !backend.participants[created.WorkspaceId][created.UserId] {
+		return nil, broken("User %s does not participate in workspace %s", created.UserId, created.WorkspaceId)
+	}
+	if err := backend.assignId(&created.Id, backend.timeentries[created.Id] != nil); err != nil {
+		return nil, err
+	}
+	stamp(&created.CreatedAt, &created.UpdatedAt)
+	backend.timeentries[created.Id] = created
+	return proto.Clone(created).(*communicator.MavenlinkTimeentry), nil
+}
+
+// CreateStoryDependency stores a copy of the dependency(param: dependency), returning it with its
+// ID and timestamps. Both stories belong to the same workspace and dependencies do not form cycles
+func (backend *Backend) CreateStoryDependency(dependency *communicator.MavenlinkStoryDependency) (
+	*communicator.MavenlinkStoryDependency, error) {
+
+	created := proto.Clone(dependency).(*communicator.MavenlinkStoryDependency)
+	if len(created.DependencyType) < 1 {
+		created.DependencyType = "finish_to_start"
+	}
+	if !dependencyTypes[created.DependencyType] {
+		return nil, broken("Unknown dependency type %q", created.DependencyType)
+	}
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	source, target := backend.stories[created.SourceId], backend.stories[created.TargetId]
+	if source == nil {
+		return nil, &API.NotFoundError{Resource: "Task", Id: created.SourceId}
+	}
+	if target == nil {
+		return nil, &API.NotFoundError{Resource: "Task", Id: created.TargetId}
+	}
+	if source.WorkspaceId != target.WorkspaceId {
+		return nil, broken("Dependent stories belong to the same workspace")
+	}
+	if source.Id == target.Id || backend.precedes(target.Id, source.Id) {
+		return nil, broken("Story %s cannot depend on story %s without a cycle", target.Id, source.Id)
+	}
+	created.WorkspaceId = source.WorkspaceId
+	if err := backend.assignId(&created.Id, backend.dependencies[created.Id] != nil); err != nil {
+		return nil, err
+	}
+	stamp(&created.CreatedAt, &created.UpdatedAt)
+	backend.dependencies[created.Id] = created
+	return proto.Clone(created).(*communicator.MavenlinkStoryDependency), nil
+}
+
+// precedes tells whether the story(param: from) precedes the story(param: to) through the dependencies
+func (backend *Backend) precedes(from string, to string) bool {
+	visited := map[string]bool{from: true}
+	pending := []string{from}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		for _, dependency := range backend.dependencies {
+			if dependency.SourceId != current || visited[dependency.TargetId] {
+				continue
+			}
+			if dependency.TargetId == to {
+				return true
+			}
+			visited[dependency.TargetId] = true
+			pending = append(pending, dependency.TargetId)
+		}
+	}
+	return false
+}
+
+// DeleteWorkspace removes the workspace(param: id) with its stories, time entries and dependencies
+func (backend *Backend) DeleteWorkspace(id string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.workspaces[id] == nil {
+		return &API.NotFoundError{Resource: "Project", Id: id}
+	}
+	for storyId, story := range backend.stories {
+		if story.WorkspaceId == id {
+			backend.deleteStory(storyId)
+		}
+	}
+	for timeentryId, timeentry := range backend.timeentries {
+		if timeentry.WorkspaceId == id {
+			delete(backend.timeentries, timeentryId)
+		}
+	}
+	delete(backend.workspaces, id)
+	delete(backend.participants, id)
+	return nil
+}
+
+// DeleteStory removes the story(param: id) with its sub stories, their time entries and dependencies
+func (backend *Backend) DeleteStory(id string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.stories[id] == nil {
+		return &API.NotFoundError{Resource: "Task", Id: id}
+	}
+	backend.deleteStory(id)
+	return nil
+}
+
+func (backend *Backend) deleteStory(id string) {
+	delete(backend.stories, id)
+	for childId, child := range backend.stories {
+		if child.ParentId == id {
+			backend.deleteStory(childId)
+		}
+	}
+	for timeentryId, timeentry := range backend.timeentries {
+		if timeentry.StoryId == id {
+			delete(backend.timeentries, timeentryId)
+		}
+	}
+	for dependencyId, dependency := range backend.dependencies {
+		if dependency.SourceId == id || dependency.TargetId == id {
+			delete(backend.dependencies, dependencyId)
+		}
+	}
+}
+
+// DeleteTimeEntry removes the time entry(param: id)
+func (backend *Backend) DeleteTimeEntry(id string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.timeentries[id] == nil {
+		return &API.NotFoundError{Resource: "Timeentry", Id: id}
+	}
+	delete(backend.timeentries, id)
+	return nil
+}
+
+// DeleteStoryDependency removes the story dependency(param: id)
+func (backend *Backend) DeleteStoryDependency(id string) error {
+	backend.mutex.Lock()
+	defer backend.mutex.Unlock()
+	if backend.dependencies[id] == nil {
+		return &API.NotFoundError{Resource: "TaskDependency", Id: id}
+	}
+	delete(backend.dependencies, id)
+	return nil
+}
+
+// assignId gives the next free numeric ID to a record without one(param: id), or keeps
+// its own unless taken(param: taken). IDs are numbers shared by every resource, as in Mavenlink
+func (backend *Backend) assignId(id *string, taken bool) error {
+	if len(*id) < 1 {
+		backend.lastId++
+		*id = strconv.FormatInt(backend.lastId, 10)
+		return nil
+	}
+	if taken {
+		return ErrConflict
+	}
+	if numeric, err := strconv.ParseInt(*id, 10, 64); err == nil && numeric > backend.lastId {
+		backend.lastId = numeric
+	}
+	return nil
+}
+
+// stamp sets the creation and update times(param: createdAt, updatedAt) left empty to now
+func stamp(createdAt *string, updatedAt *string) {
+	now := time.Now().Format(time.RFC3339)
+	if len(*createdAt) < 1 {
+		*createdAt = now
+	}
+	if len(*updatedAt) < 1 {
+		*updatedAt = *createdAt
+	}
+}
+
+func (backend *Backend) story(id string) *communicator.MavenlinkStory {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	return backend.stories[id]
+}
+
+// sortedIds returns the IDs(param: ids) in numeric order, as Mavenlink lists records
+func sortedIds(ids []string) []string {
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
@@ -0,0 +1,201 @@
+package memory
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// The backend stands in for the Mavenlink API
+var _ API.MavenlinkApiInterface = (*Backend)(nil)
+
+func newTestBackend(t *testing.T) *Backend {
+	backend, err := New(DefaultSeed(), &communicator.EnvironmentConfiguration{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return backend
+}
+
+func isRuleError(err error) bool {
+	_, ok := err.(*RuleError)
+	return ok
+}
+
+func TestDefaultSeed(t *testing.T) {
+	backend := newTestBackend(t)
+	tasks, err := backend.GetTasksFromProjectId("10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[0].Id != "20" {
+		t.Errorf("unexpected top level tasks %v", tasks)
+	}
+	criticalPath, err := backend.GetCriticalPathFromProjectId("10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The task is held back by the milestone it precedes rather than by the end of the project
+	if len(criticalPath.Tasks) != 2 || criticalPath.Tasks[0].TaskId != "20" || criticalPath.Tasks[0].Slack != 2 {
+		t.Errorf("expected the dependency to bound the slack, got %v", criticalPath.Tasks)
+	}
+	issues, err := backend.GetIssueTasksFromProjectId("10", "21")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].User == nil || issues[0].User.FullName != "Ada Lovelace" {
+		t.Errorf("unexpected issues %v", issues)
+	}
+	tree, err := backend.GetTaskTreeFromProjectId("10", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree) != 2 || tree[0].TotalLoggedMinutes != 390 {
+		t.Errorf("expected the logged time to roll up, got %v", tree)
+	}
+	if _, err := backend.GetProject("99"); !API.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestLoadSeedFromFixtures(t *testing.T) {
+	_, source, _, _ := runtime.Caller(0)
+	seed, err := LoadSeed(filepath.Join(filepath.Dir(source), "..", "mavenlinktest", "fixtures"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend, err := New(seed, &communicator.EnvironmentConfiguration{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	users, err := backend.GetUsersFromProjectId("1002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Id != "2002" || users[1].Id != "2003" {
+		t.Errorf("unexpected participants %v", users)
+	}
+}
+
+func TestHierarchyRules(t *testing.T) {
+	backend := newTestBackend(t)
+	other, err := backend.CreateWorkspace(&communicator.MavenlinkWorkspace{Title: "Other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]*communicator.MavenlinkStory{
+		"too deep":            {Title: "Deeper", WorkspaceId: "10", ParentId: "22"},
+		"milestone parent":    {Title: "Milestone", StoryType: Milestone, WorkspaceId: "10", ParentId: "20"},
+		"other workspace":     {Title: "Elsewhere", WorkspaceId: other.Id, ParentId: "20"},
+		"assignee outsider":   {Title: "Assigned", WorkspaceId: other.Id, AssigneeIds: []string{"1"}},
+		"unknown story type":  {Title: "Odd", StoryType: "chore", WorkspaceId: "10"},
+		"missing story title": {WorkspaceId: "10"},
+	}
+	for name, story := range cases {
+		if _, err := backend.CreateStory(story); !isRuleError(err) {
+			t.Errorf("%s: expected a broken rule, got %v", name, err)
+		}
+	}
+	if _, err := backend.CreateStory(&communicator.MavenlinkStory{Title: "Orphan", WorkspaceId: "10", ParentId: "99"}); !API.IsNotFound(err) {
+		t.Errorf("expected a missing parent, got %v", err)
+	}
+	created, err := backend.CreateStory(&communicator.MavenlinkStory{Title: "Sub task", WorkspaceId: "10", ParentId: "20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Id != "44" || created.StoryType != Task || len(created.CreatedAt) < 1 {
+		t.Errorf("expected the next ID and defaults, got %v", created)
+	}
+	if _, err := backend.CreateStory(&communicator.MavenlinkStory{Id: "20", Title: "Twin", WorkspaceId: "10"}); err != ErrConflict {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+}
+
+func TestTimeEntryAndDependencyRules(t *testing.T) {
+	backend := newTestBackend(t)
+	outsider, _ := backend.CreateUser(&communicator.MavenlinkUser{FullName: "Alan Turing"})
+	if _, err := backend.CreateTimeEntry(&communicator.MavenlinkTimeentry{
+		WorkspaceId: "10", StoryId: "21", UserId: outsider.Id, TimeInMinutes: 30}); !isRuleError(err) {
+		t.Errorf("expected time logged by an outsider to be refused, got %v", err)
+	}
+	if err := backend.AddParticipant("10", outsider.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := backend.CreateTimeEntry(&communicator.MavenlinkTimeentry{
+		WorkspaceId: "10", StoryId: "21", UserId: outsider.Id, TimeInMinutes: 30}); err != nil {
+		t.Errorf("expected time logged by a participant, got %v", err)
+	}
+	if _, err := backend.CreateStoryDependency(&communicator.MavenlinkStoryDependency{SourceId: "23", TargetId: "20"}); !isRuleError(err) {
+		t.Errorf("expected a cycle to be refused, got %v", err)
+	}
+}
+
+func TestDeleteStoryCascades(t *testing.T) {
+	backend := newTestBackend(t)
+	if err := backend.DeleteStory("20"); err != nil {
+		t.Fatal(err)
+	}
+	_, missing, _ := backend.GetTasksByIds([]string{"20", "21", "22", "23"})
+	if strings.Join(missing, ",") != "20,21,22" {
+		t.Errorf("expected the sub stories to be deleted, missing %v", missing)
+	}
+	_, missing, _ = backend.GetTimeEntriesByIds([]string{"40", "41", "42"})
+	if len(missing) != 3 {
+		t.Errorf("expected the time entries to be deleted, missing %v", missing)
+	}
+	dependencies, _ := backend.GetTaskDependenciesFromProjectId("10")
+	if len(dependencies) != 0 {
+		t.Errorf("expected the dependencies to be deleted, got %v", dependencies)
+	}
+	if err := backend.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if tasks, _ := backend.GetTasksFromProjectId("10"); len(tasks) != 2 {
+		t.Errorf("expected the seed to be loaded again, got %v", tasks)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	backend := newTestBackend(t)
+	server := httptest.NewServer(NewHandler(backend))
+	defer server.Close()
+	requests := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"POST", "/stories", `{"title": "Deploy", "workspace_id": "10", "parent_id": "20"}`, http.StatusCreated},
+		{"POST", "/stories", `{"title": "Deeper", "workspace_id": "10", "parent_id": "22"}`, http.StatusUnprocessableEntity},
+		{"POST", "/stories", `{"id": "20", "title": "Twin", "workspace_id": "10"}`, http.StatusConflict},
+		{"POST", "/stories", `{"title": `, http.StatusBadRequest},
+		{"POST", "/workspaces", `{"title": "Second", "participant_ids": ["2"]}`, http.StatusCreated},
+		{"POST", "/workspaces/10/participants", `{"user_id": "99"}`, http.StatusNotFound},
+		{"DELETE", "/time_entries/40", ``, http.StatusNoContent},
+		{"DELETE", "/users/1", ``, http.StatusNotFound},
+	}
+	for _, request := range requests {
+		httpReq, _ := http.NewRequest(request.method, server.URL+request.path, strings.NewReader(request.body))
+		httpResp, err := http.DefaultClient.Do(httpReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		httpResp.Body.Close()
+		if httpResp.StatusCode != request.status {
+			t.Errorf("%s %s: expected %d, got %d", request.method, request.path, request.status, httpResp.StatusCode)
+		}
+	}
+	projects, _ := backend.GetProjects()
+	second := projects[len(projects)-1]
+	if users, _ := backend.GetUsersFromProjectId(second.Id); second.Title != "Second" || len(users) != 1 || users[0].Id != "2" {
+		t.Errorf("expected the participants of the created workspace, got %v", users)
+	}
+	if tasks, _ := backend.GetSubTasksFromProjectId("10", "20"); len(tasks) != 2 {
+		t.Errorf("expected the created sub task to be read, got %v", tasks)
+	}
+}
@@ -0,0 +1,154 @@
+package memory
+
+import (
+	"encoding/json"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8050"
+
+// Largest record body accepted
+const maxBodySize = 1 << 20
+
+// participantRequest is the body of a request adding a participant to a workspace
+type participantRequest struct {
+	UserId string `json:"user_id"`
+}
+
+// NewHandler serves the writes of the backend(param: backend) as JSON, records being shaped as Mavenlink's:
+//
+//	POST   /workspaces, /stories, /time_entries, /users, /story_dependencies creates a record
+//	POST   /workspaces/<id>/participants adds the user of the body {"user_id": "<id>"}
+//	DELETE /workspaces/<id>, /stories/<id>, /time_entries/<id>, /story_dependencies/<id>
+//	POST   /reset drops every record and loads the seed again
+func NewHandler(backend *Backend) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		var created interface{}
+		var err error
+		switch {
+		case r.Method == http.MethodPost && len(segments) == 1:
+			created, err = create(backend, segments[0], r.Body)
+		case r.Method == http.MethodPost && len(segments) == 3 && segments[0] == "workspaces" && segments[2] == "participants":
+			participant := &participantRequest{}
+			if err = json.NewDecoder(r.Body).Decode(participant); err == nil {
+				err = backend.AddParticipant(segments[1], participant.UserId)
+			}
+		case r.Method == http.MethodDelete && len(segments) == 2:
+			err = remove(backend, segments[0], segments[1])
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Unknown route " + r.Method + " " + r.URL.Path})
+			return
+		}
+		if err != nil {
+			writeJSON(w, statusOf(err), map[string]string{"error": err.Error()})
+			return
+		}
+		if created == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	})
+}
+
+// ListenAndServe serves the writes at the address of the configuration(param: configuration) until the listener fails
+func ListenAndServe(backend *Backend, configuration *communicator.EnvironmentConfiguration) error {
+	address := configuration.MemoryAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	return http.ListenAndServe(address, NewHandler(backend))
+}
+
+// create decodes a record of the resource(param: resource) from the body(param: body) and stores it.
+// The reset route is served here as it takes no ID either
+func create(backend *Backend, resource string, body io.Reader) (interface{}, error) {
+	decoder := json.NewDecoder(body)
+	switch resource {
+	case "workspaces":
+		workspace := &seededWorkspace{MavenlinkWorkspace: &communicator.MavenlinkWorkspace{}}
+		if err := decoder.Decode(workspace); err != nil {
+			return nil, errors.Wrap(err, "Invalid workspace")
+		}
+		created, err := backend.CreateWorkspace(workspace.MavenlinkWorkspace)
+		if err != nil {
+			return nil, err
+		}
+		for _, userId := range workspace.ParticipantIds {
+			if err := backend.AddParticipant(created.Id, userId); err != nil {
+				return nil, err
+			}
+		}
+		return created, nil
+	case "stories":
+		story := &communicator.MavenlinkStory{}
+		if err := decoder.Decode(story); err != nil {
+			return nil, errors.Wrap(err, "Invalid story")
+		}
+		return backend.CreateStory(story)
+	case "time_entries":
+		timeentry := &communicator.MavenlinkTimeentry{}
+		if err := decoder.Decode(timeentry); err != nil {
+			return nil, errors.Wrap(err, "Invalid time entry")
+		}
+		return backend.CreateTimeEntry(timeentry)
+	case "users":
+		user := &communicator.MavenlinkUser{}
+		if err := decoder.Decode(user); err != nil {
+			return nil, errors.Wrap(err, "Invalid user")
+		}
+		return backend.CreateUser(user)
+	case "story_dependencies":
+		dependency := &communicator.MavenlinkStoryDependency{}
+		if err := decoder.Decode(dependency); err != nil {
+			return nil, errors.Wrap(err, "Invalid story dependency")
+		}
+		return backend.CreateStoryDependency(dependency)
+	case "reset":
+		return nil, backend.Reset()
+	}
+	return nil, &API.NotFoundError{Resource: "Resource", Id: resource}
+}
+
+// remove deletes the record(param: id) of the resource(param: resource)
+func remove(backend *Backend, resource string, id string) error {
+	switch resource {
+	case "workspaces":
+		return backend.DeleteWorkspace(id)
+	case "stories":
+		return backend.DeleteStory(id)
+	case "time_entries":
+		return backend.DeleteTimeEntry(id)
+	case "story_dependencies":
+		return backend.DeleteStoryDependency(id)
+	}
+	return &API.NotFoundError{Resource: "Resource", Id: resource}
+}
+
+// statusOf returns the status code answering the error(param: err) of a write
+func statusOf(err error) int {
+	if API.IsNotFound(err) {
+		return http.StatusNotFound
+	}
+	if err == ErrConflict {
+		return http.StatusConflict
+	}
+	if _, ok := errors.Cause(err).(*RuleError); ok {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,390 @@
+package memory
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"strings"
+)
+
+// config returns the current configuration
+func (backend *Backend) config() *communicator.EnvironmentConfiguration {
+	configuration, _ := backend.env.Load().(*communicator.EnvironmentConfiguration)
+	return configuration
+}
+
+func (backend *Backend) FormatErrors(err error, message string) *communicator.Error {
+	errResp := new(communicator.Error)
+	errResp.Code = int32(400)
+	if backend.config().Debug == true {
+		errResp.Description = err.Error()
+	} else {
+		errResp.Description = message
+	}
+	return errResp
+}
+
+// Ping always succeeds, the backend being in the process
+func (backend *Backend) Ping() error {
+	return nil
+}
+
+func (backend *Backend) GetProjects() ([]*communicator.Project, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	var projects []*communicator.Project
+	for _, id := range backend.workspaceIds() {
+		projects = append(projects, API.WorkspaceToProject(backend.workspaces[id]))
+	}
+	return projects, nil
+}
+
+func (backend *Backend) GetProject(keyOrId string) (*communicator.Project, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	workspace, ok := backend.workspaces[keyOrId]
+	if !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: keyOrId}
+	}
+	return API.WorkspaceToProject(workspace), nil
+}
+
+func (backend *Backend) GetTasksFromProjectId(keyOrId string) ([]*communicator.Task, error) {
+	return backend.tasksOf(keyOrId, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) < 1
+	}, false)
+}
+
+func (backend *Backend) GetSubTasksFromProjectId(workspace string, task string) ([]*communicator.Task, error) {
+	return backend.tasksOf(workspace, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) > 0 && story.ParentId == task
+	}, false)
+}
+
+func (backend *Backend) GetIssueTasksFromProjectId(keyOrId string, subTask string) ([]*communicator.Task, error) {
+	return backend.tasksOf(keyOrId, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) > 0 && story.ParentId == subTask
+	}, true)
+}
+
+func (backend *Backend) GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string,
+	issueTaskKeyOrId string) ([]*communicator.Timeentry, error) {
+
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	if _, ok := backend.workspaces[projectKeyOrId]; !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: projectKeyOrId}
+	}
+	var timeentries []*communicator.Timeentry
+	for _, id := range backend.timeentryIds(projectKeyOrId) {
+		timeentry := backend.timeentries[id]
+		if strings.EqualFold(issueTaskKeyOrId, timeentry.StoryId) {
+			timeentryWithUser := API.TimeEntryToTimeentry(timeentry)
+			if backend.participants[projectKeyOrId][timeentry.UserId] {
+				timeentryWithUser.User = API.MavenlinkUserToUser(backend.users[timeentry.UserId])
+			}
+			timeentries = append(timeentries, timeentryWithUser)
+		}
+	}
+	return timeentries, nil
+}
+
+func (backend *Backend) GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	participants, ok := backend.participants[projectKeyOrId]
+	if !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: projectKeyOrId}
+	}
+	var ids []string
+	for id := range participants {
+		ids = append(ids, id)
+	}
+	var users []*communicator.User
+	for _, id := range sortedIds(ids) {
+		users = append(users, API.MavenlinkUserToUser(backend.users[id]))
+	}
+	return users, nil
+}
+
+func (backend *Backend) GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	if !backend.participants[projectKeyOrId][userId] {
+		return nil, &API.NotFoundError{Resource: "User", Id: userId}
+	}
+	return API.MavenlinkUserToUser(backend.users[userId]), nil
+}
+
+func (backend *Backend) GetUserById(userId string) (*communicator.User, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	user, ok := backend.users[userId]
+	if !ok {
+		return nil, &API.NotFoundError{Resource: "User", Id: userId}
+	}
+	return API.MavenlinkUserToUser(user), nil
+}
+
+func (backend *Backend) GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	if _, ok := backend.workspaces[projectKeyOrId]; !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: projectKeyOrId}
+	}
+	return backend.dependenciesOf(projectKeyOrId), nil
+}
+
+func (backend *Backend) GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error) {
+	tasks, err := backend.GetTasksFromProjectId(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := backend.GetTaskDependenciesFromProjectId(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	tasks = API.WithDependencies(tasks, dependencies)
+	criticalPath, err := API.CriticalPathFromTasks(tasks)
+	if err != nil {
+		return nil, err
+	}
+	criticalPath.WorkspaceId = projectKeyOrId
+	return criticalPath, nil
+}
+
+func (backend *Backend) GetTaskTreeFromProjectId(projectKeyOrId string,
+	includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	if _, ok := backend.workspaces[projectKeyOrId]; !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: projectKeyOrId}
+	}
+	var stories []*communicator.MavenlinkStory
+	for _, id := range backend.storyIds(projectKeyOrId) {
+		stories = append(stories, backend.stories[id])
+	}
+	users := make(map[string]*communicator.User)
+	for id := range backend.participants[projectKeyOrId] {
+		users[id] = API.MavenlinkUserToUser(backend.users[id])
+	}
+	loggedMinutes := make(map[string]int32)
+	if includeLoggedTime {
+		for _, id := range backend.timeentryIds(projectKeyOrId) {
+			loggedMinutes[backend.timeentries[id].StoryId] += backend.timeentries[id].TimeInMinutes
+		}
+	}
+	return API.BuildTaskTree(stories, users, loggedMinutes)
+}
+
+// StreamProjects hands every workspace to the callback(param: emit), the page size being irrelevant in memory
+func (backend *Backend) StreamProjects(perPage int32, emit func(*communicator.Project) error) error {
+	projects, err := backend.GetProjects()
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		if emitErr := emit(project); emitErr != nil {
+			return emitErr
+		}
+	}
+	return nil
+}
+
+func (backend *Backend) StreamTasks(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Task) error) error {
+
+	backend.mutex.RLock()
+	var tasks []*communicator.Task
+	for _, id := range backend.storyIds(projectKeyOrId) {
+		tasks = append(tasks, backend.taskOf(backend.stories[id]))
+	}
+	backend.mutex.RUnlock()
+	for _, task := range tasks {
+		if emitErr := emit(task); emitErr != nil {
+			return emitErr
+		}
+	}
+	return nil
+}
+
+// StreamTimeEntries hands the time entries of a workspace(param: projectKeyOrId), or of
+// every workspace when none is provided, to the callback(param: emit)
+func (backend *Backend) StreamTimeEntries(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Timeentry) error) error {
+
+	backend.mutex.RLock()
+	var timeentries []*communicator.Timeentry
+	for _, id := range backend.timeentryIds(projectKeyOrId) {
+		timeentries = append(timeentries, backend.timeentryOf(backend.timeentries[id]))
+	}
+	backend.mutex.RUnlock()
+	for _, timeentry := range timeentries {
+		if emitErr := emit(timeentry); emitErr != nil {
+			return emitErr
+		}
+	}
+	return nil
+}
+
+func (backend *Backend) GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	projects := make(map[string]*communicator.Project)
+	missing := missingIds(ids, func(id string) bool {
+		workspace, ok := backend.workspaces[id]
+		if ok {
+			projects[id] = API.WorkspaceToProject(workspace)
+		}
+		return ok
+	})
+	return projects, missing, nil
+}
+
+func (backend *Backend) GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	tasks := make(map[string]*communicator.Task)
+	missing := missingIds(ids, func(id string) bool {
+		story, ok := backend.stories[id]
+		if ok {
+			tasks[id] = backend.taskOf(story)
+		}
+		return ok
+	})
+	return tasks, missing, nil
+}
+
+func (backend *Backend) GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	users := make(map[string]*communicator.User)
+	missing := missingIds(ids, func(id string) bool {
+		user, ok := backend.users[id]
+		if ok {
+			users[id] = API.MavenlinkUserToUser(user)
+		}
+		return ok
+	})
+	return users, missing, nil
+}
+
+func (backend *Backend) GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error) {
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	timeentries := make(map[string]*communicator.Timeentry)
+	missing := missingIds(ids, func(id string) bool {
+		timeentry, ok := backend.timeentries[id]
+		if ok {
+			timeentries[id] = backend.timeentryOf(timeentry)
+		}
+		return ok
+	})
+	return timeentries, missing, nil
+}
+
+// tasksOf returns the stories of a workspace(param: workspace) accepted by the filter(param: keep)
+// as tasks, with their assignee when requested(param: withAssignee)
+func (backend *Backend) tasksOf(workspace string, keep func(*communicator.MavenlinkStory) bool,
+	withAssignee bool) ([]*communicator.Task, error) {
+
+	backend.mutex.RLock()
+	defer backend.mutex.RUnlock()
+	if _, ok := backend.workspaces[workspace]; !ok {
+		return nil, &API.NotFoundError{Resource: "Project", Id: workspace}
+	}
+	var tasks []*communicator.Task
+	for _, id := range backend.storyIds(workspace) {
+		story := backend.stories[id]
+		if !keep(story) {
+			continue
+		}
+		task := API.StoryToTask(story)
+		if withAssignee {
+			task = backend.taskOf(story)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// taskOf maps the story(param: story) onto a task with its last assignee
+func (backend *Backend) taskOf(story *communicator.MavenlinkStory) *communicator.Task {
+	task := API.StoryToTask(story)
+	for _, assignee := range story.AssigneeIds {
+		if user, ok := backend.users[assignee]; ok {
+			task.User = API.MavenlinkUserToUser(user)
+		}
+	}
+	return task
+}
+
+// timeentryOf maps the time entry(param: timeentry) onto a Timeentry with its user
+func (backend *Backend) timeentryOf(timeentry *communicator.MavenlinkTimeentry) *communicator.Timeentry {
+	timeentryWithUser := API.TimeEntryToTimeentry(timeentry)
+	if user, ok := backend.users[timeentry.UserId]; ok {
+		timeentryWithUser.User = API.MavenlinkUserToUser(user)
+	}
+	return timeentryWithUser
+}
+
+// dependenciesOf returns the dependencies between the stories of the workspace(param: workspace)
+func (backend *Backend) dependenciesOf(workspace string) []*communicator.TaskDependency {
+	var ids []string
+	for id, dependency := range backend.dependencies {
+		if dependency.WorkspaceId == workspace {
+			ids = append(ids, id)
+		}
+	}
+	var dependencies []*communicator.TaskDependency
+	for _, id := range sortedIds(ids) {
+		dependencies = append(dependencies, API.StoryDependencyToTaskDependency(backend.dependencies[id]))
+	}
+	return dependencies
+}
+
+func (backend *Backend) workspaceIds() []string {
+	var ids []string
+	for id := range backend.workspaces {
+		ids = append(ids, id)
+	}
+	return sortedIds(ids)
+}
+
+// storyIds returns the IDs of the stories of the workspace(param: workspace)
+func (backend *Backend) storyIds(workspace string) []string {
+	var ids []string
+	for id, story := range backend.stories {
+		if story.WorkspaceId == workspace {
+			ids = append(ids, id)
+		}
+	}
+	return sortedIds(ids)
+}
+
+// timeentryIds returns the IDs of the time entries of the workspace(param: workspace), of every workspace when empty
+func (backend *Backend) timeentryIds(workspace string) []string {
+	var ids []string
+	for id, timeentry := range backend.timeentries {
+		if len(workspace) < 1 || timeentry.WorkspaceId == workspace {
+			ids = append(ids, id)
+		}
+	}
+	return sortedIds(ids)
+}
+
+// missingIds returns the IDs(param: ids) the function(param: found) does not find, each once
+func missingIds(ids []string, found func(id string) bool) []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if len(id) < 1 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if !found(id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,109 @@
+package memory
+
+import (
+	"encoding/json"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Seed holds the records a backend starts with, shaped as Mavenlink's
+type Seed struct {
+	Workspaces        []*communicator.MavenlinkWorkspace
+	Stories           []*communicator.MavenlinkStory
+	TimeEntries       []*communicator.MavenlinkTimeentry
+	Users             []*communicator.MavenlinkUser
+	StoryDependencies []*communicator.MavenlinkStoryDependency
+	// Participants holds the IDs of the participants of each workspace
+	Participants map[string][]string
+}
+
+// seededWorkspace is a workspace of a seed file, listing its participants
+type seededWorkspace struct {
+	*communicator.MavenlinkWorkspace
+	ParticipantIds []string `json:"participant_ids"`
+}
+
+// LoadSeed reads the seed of the directory(param: dir), which holds a JSON array of records per
+// resource named after it: workspaces.json, stories.json, time_entries.json, users.json and
+// story_dependencies.json. Workspaces list their participants as participant_ids. Missing files
+// leave their resource empty, so the fixtures of the mavenlinktest package can be used as is
+func LoadSeed(dir string) (*Seed, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, errors.Wrap(err, "Failed to open the seed directory")
+	}
+	seed := &Seed{Participants: make(map[string][]string)}
+	var workspaces []*seededWorkspace
+	files := []struct {
+		name   string
+		target interface{}
+	}{
+		{"workspaces.json", &workspaces},
+		{"stories.json", &seed.Stories},
+		{"time_entries.json", &seed.TimeEntries},
+		{"users.json", &seed.Users},
+		{"story_dependencies.json", &seed.StoryDependencies},
+	}
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(filepath.Join(dir, file.name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, file.target); err != nil {
+			return nil, errors.Wrapf(err, "Invalid seed file %s", file.name)
+		}
+	}
+	for _, workspace := range workspaces {
+		if workspace.MavenlinkWorkspace == nil {
+			continue
+		}
+		seed.Workspaces = append(seed.Workspaces, workspace.MavenlinkWorkspace)
+		seed.Participants[workspace.Id] = workspace.ParticipantIds
+	}
+	return seed, nil
+}
+
+// DefaultSeed returns the records of a small account the backend starts with unless
+// given a seed directory: a workspace with a task, a sub task and an issue, two
+// participants having logged time, and a milestone following the task
+func DefaultSeed() *Seed {
+	return &Seed{
+		Users: []*communicator.MavenlinkUser{
+			{Id: "1", FullName: "Ada Lovelace", EmailAddress: "ada@example.com", Headline: "Engineer", AccountId: "100"},
+			{Id: "2", FullName: "Grace Hopper", EmailAddress: "grace@example.com", Headline: "Manager", AccountId: "100"},
+		},
+		Workspaces: []*communicator.MavenlinkWorkspace{
+			{Id: "10", Title: "Sample Project", Description: "Project of the in-memory backend", AccessLevel: "open",
+				AccountId: 100, Currency: "USD", CurrencySymbol: "$", StartDate: "2018-09-03", DueDate: "2018-10-26",
+				EffectiveDueDate: "2018-10-26"},
+		},
+		Participants: map[string][]string{"10": {"1", "2"}},
+		Stories: []*communicator.MavenlinkStory{
+			{Id: "20", Title: "Build", StoryType: Task, Priority: "normal", WorkspaceId: "10", CreatorId: "2",
+				State: "started", StartDate: "2018-09-03", DueDate: "2018-09-28", AssigneeIds: []string{"1"}},
+			{Id: "21", Title: "Implement the API", StoryType: Task, Priority: "high", WorkspaceId: "10", CreatorId: "2",
+				ParentId: "20", State: "started", StartDate: "2018-09-03", DueDate: "2018-09-14", AssigneeIds: []string{"1"}},
+			{Id: "22", Title: "Pagination skips the last page", StoryType: Issue, Priority: "critical", WorkspaceId: "10",
+				CreatorId: "1", ParentId: "21", State: "not started", StartDate: "2018-09-10", DueDate: "2018-09-11",
+				AssigneeIds: []string{"1"}},
+			{Id: "23", Title: "Launch", StoryType: Milestone, Priority: "normal", WorkspaceId: "10", CreatorId: "2",
+				State: "not started", StartDate: "2018-10-01", DueDate: "2018-10-26", AssigneeIds: []string{"2"}},
+		},
+		StoryDependencies: []*communicator.MavenlinkStoryDependency{
+			{Id: "30", SourceId: "20", TargetId: "23", DependencyType: "finish_to_start"},
+		},
+		TimeEntries: []*communicator.MavenlinkTimeentry{
+			{Id: "40", DatePerformed: "2018-09-04", TimeInMinutes: 240, Notes: "Endpoints", Billable: true,
+				WorkspaceId: "10", StoryId: "21", UserId: "1"},
+			{Id: "41", DatePerformed: "2018-09-10", TimeInMinutes: 90, Notes: "Investigation", Billable: true,
+				WorkspaceId: "10", StoryId: "22", UserId: "1"},
+			{Id: "42", DatePerformed: "2018-09-05", TimeInMinutes: 60, Notes: "Review", WorkspaceId: "10",
+				StoryId: "20", UserId: "2"},
+		},
+	}
+}
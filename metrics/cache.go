@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sort"
+	"sync"
+)
+
+// Descriptions of the cache metrics, labelled with the name of the cache
+var (
+	cacheHits = prometheus.NewDesc(namespace+"cache_hits_total",
+		"Lookups answered by the cache", []string{"cache"}, nil)
+	cacheMisses = prometheus.NewDesc(namespace+"cache_misses_total",
+		"Lookups the cache could not answer", []string{"cache"}, nil)
+	cacheEntries = prometheus.NewDesc(namespace+"cache_entries",
+		"Entries held by the cache", []string{"cache"}, nil)
+	cacheHitRatio = prometheus.NewDesc(namespace+"cache_hit_ratio",
+		"Share of the lookups answered by the cache", []string{"cache"}, nil)
+)
+
+// cacheCollector reads the counters of the registered caches when the metrics are collected
+type cacheCollector struct {
+	mutex sync.Mutex
+	stats map[string]CacheStats
+}
+
+func newCacheCollector() *cacheCollector {
+	return &cacheCollector{stats: make(map[string]CacheStats)}
+}
+
+// add registers the counters of the cache named name(param: name), replacing those registered before under that name
+func (collector *cacheCollector) add(name string, stats CacheStats) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	collector.stats[name] = stats
+}
+
+func (collector *cacheCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- cacheHits
+	descs <- cacheMisses
+	descs <- cacheEntries
+	descs <- cacheHitRatio
+}
+
+func (collector *cacheCollector) Collect(metrics chan<- prometheus.Metric) {
+	collector.mutex.Lock()
+	stats := make(map[string]CacheStats, len(collector.stats))
+	names := make([]string, 0, len(collector.stats))
+	for name, cacheStats := range collector.stats {
+		stats[name] = cacheStats
+		names = append(names, name)
+	}
+	collector.mutex.Unlock()
+	sort.Strings(names)
+	for _, name := range names {
+		hits, misses, entries := stats[name]()
+		ratio := 0.0
+		if hits+misses > 0 {
+			ratio = float64(hits) / float64(hits+misses)
+		}
+		metrics <- prometheus.MustNewConstMetric(cacheHits, prometheus.CounterValue, float64(hits), name)
+		metrics <- prometheus.MustNewConstMetric(cacheMisses, prometheus.CounterValue, float64(misses), name)
+		metrics <- prometheus.MustNewConstMetric(cacheEntries, prometheus.GaugeValue, float64(entries), name)
+		metrics <- prometheus.MustNewConstMetric(cacheHitRatio, prometheus.GaugeValue, ratio, name)
+	}
+}
@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":9100"
+
+// Prefix of the names of the metrics of the service
+const namespace = "mavenlink_communicator_"
+
+// Upper bounds of the latency histograms, in seconds
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// DefaultRegistry holds the metrics of the service
+var DefaultRegistry = prometheus.NewRegistry()
+
+// Metrics of the RPCs served and of the calls made to Mavenlink
+var (
+	rpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{Name: namespace + "rpc_requests_total",
+		Help: "RPC requests served, by method and status code"}, []string{"method", "status"})
+	rpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: namespace + "rpc_duration_seconds",
+		Help: "Time taken to serve RPC requests, by method", Buckets: latencyBuckets}, []string{"method"})
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{Name: namespace + "upstream_requests_total",
+		Help: "Requests made to Mavenlink, by endpoint, HTTP method and status code"}, []string{"endpoint", "method", "status"})
+	upstreamDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: namespace + "upstream_duration_seconds",
+		Help: "Time taken by the requests made to Mavenlink, by endpoint and HTTP method", Buckets: latencyBuckets},
+		[]string{"endpoint", "method"})
+	upstreamRetries = prometheus.NewCounterVec(prometheus.CounterOpts{Name: namespace + "upstream_retries_total",
+		Help: "Requests to Mavenlink retried, by endpoint"}, []string{"endpoint"})
+	upstreamRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{Name: namespace + "upstream_rate_limited_total",
+		Help: "Requests to Mavenlink rejected by its rate limit, by endpoint"}, []string{"endpoint"})
+	caches = newCacheCollector()
+)
+
+func init() {
+	DefaultRegistry.MustRegister(rpcRequests, rpcDuration, upstreamRequests, upstreamDuration, upstreamRetries,
+		upstreamRateLimited, caches)
+}
+
+// CacheStats returns the usage counters of a cache
+type CacheStats func() (hits uint64, misses uint64, entries int)
+
+// RegisterCache exposes the counters returned by the function(param: stats) as the cache metrics
+// labelled with the name(param: name) of the cache, e.g. "shared" or "tenants"
+func RegisterCache(name string, stats CacheStats) {
+	caches.add(name, stats)
+}
+
+// ObserveUpstream records a request made to Mavenlink at the URL(param: rawURL), answered
+// with the status code(param: status), zero when no response was received, after the duration(param: elapsed)
+func ObserveUpstream(method string, rawURL string, status int, elapsed time.Duration) {
+	endpoint := Endpoint(rawURL)
+	statusLabel := "error"
+	if status > 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	upstreamRequests.WithLabelValues(endpoint, method, statusLabel).Inc()
+	upstreamDuration.WithLabelValues(endpoint, method).Observe(elapsed.Seconds())
+	if status == http.StatusTooManyRequests {
+		upstreamRateLimited.WithLabelValues(endpoint).Inc()
+	}
+}
+
+// ObserveRetry records a request made to Mavenlink at the URL(param: rawURL) being retried
+func ObserveRetry(rawURL string) {
+	upstreamRetries.WithLabelValues(Endpoint(rawURL)).Inc()
+}
+
+// Endpoint returns the endpoint of a Mavenlink URL(param: rawURL), replacing the
+// IDs found in its path so that every resource shares the label of its endpoint
+func Endpoint(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "unknown"
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	last := segments[len(segments)-1]
+	if id := strings.TrimSuffix(last, ".json"); len(id) > 0 && strings.Trim(id, "0123456789") == "" && len(segments) > 1 {
+		return segments[len(segments)-2] + "/:id.json"
+	}
+	return last
+}
+
+// Handler serves the metrics of the registry in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(DefaultRegistry, promhttp.HandlerOpts{})
+}
+
+// ListenAndServe serves the metrics on /metrics at the address of the
+// configuration(param: configuration) until the listener fails
+func ListenAndServe(configuration *communicator.EnvironmentConfiguration) error {
+	address := configuration.MetricsAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return http.ListenAndServe(address, mux)
+}
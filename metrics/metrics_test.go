@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandlerExposesTheMetrics(t *testing.T) {
+	url := "https://api.mavenlink.com/api/v1/workspaces/1001.json"
+	ObserveUpstream("GET", url, http.StatusTooManyRequests, 20*time.Millisecond)
+	ObserveRetry(url)
+	ObserveUpstream("GET", url, http.StatusOK, 10*time.Millisecond)
+	RegisterCache("shared", func() (uint64, uint64, int) { return 3, 1, 2 })
+	RegisterCache("tenants", func() (uint64, uint64, int) { return 0, 0, 0 })
+
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+	res, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	raw, _ := ioutil.ReadAll(res.Body)
+	body := string(raw)
+	for _, sample := range []string{
+		`mavenlink_communicator_upstream_requests_total{endpoint="workspaces/:id.json",method="GET",status="429"} 1`,
+		`mavenlink_communicator_upstream_requests_total{endpoint="workspaces/:id.json",method="GET",status="200"} 1`,
+		`mavenlink_communicator_upstream_rate_limited_total{endpoint="workspaces/:id.json"} 1`,
+		`mavenlink_communicator_upstream_retries_total{endpoint="workspaces/:id.json"} 1`,
+		`mavenlink_communicator_upstream_duration_seconds_count{endpoint="workspaces/:id.json",method="GET"} 2`,
+		`mavenlink_communicator_cache_hits_total{cache="shared"} 3`,
+		`mavenlink_communicator_cache_hit_ratio{cache="shared"} 0.75`,
+		`mavenlink_communicator_cache_entries{cache="tenants"} 0`,
+	} {
+		if !strings.Contains(body, sample) {
+			t.Errorf("expected the sample %s in\n%s", sample, body)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	microErrors "github.com/micro/go-micro/errors"
+	"github.com/micro/go-micro/server"
+	"golang.org/x/net/context"
+	"strconv"
+	"time"
+)
+
+// The server ends successful streams with this error
+const endOfStream = "EOS"
+
+// Wrapper implements the server.HandlerWrapper counting the requests served
+// by method and status code, and recording the time taken to serve them
+func Wrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		started := time.Now()
+		err := fn(ctx, req, rsp)
+		status := 200
+		if err != nil && err.Error() != endOfStream {
+			status = 500
+			if rpcErr := microErrors.Parse(err.Error()); rpcErr.Code > 0 {
+				status = int(rpcErr.Code)
+			}
+		}
+		rpcRequests.WithLabelValues(req.Method(), strconv.Itoa(status)).Inc()
+		rpcDuration.WithLabelValues(req.Method()).Observe(time.Since(started).Seconds())
+		return err
+	}
+}
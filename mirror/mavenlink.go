@@ -0,0 +1,533 @@
+package mirror
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Mirror wraps a MavenlinkApiInterface, serving its read methods from the
+// local store while the last sync is recent enough. Once the store is stale
+// reads go to Mavenlink, falling back on the store when Mavenlink fails.
+// Streams are always read from Mavenlink
+type Mirror struct {
+	mavenlink API.MavenlinkApiInterface
+	store     *Store
+	// settings holds the current *settings, shared by the copies of the mirror
+	settings *atomic.Value
+}
+
+// settings are the parts of the configuration the mirror reads
+type settings struct {
+	staleness time.Duration
+}
+
+// New creates a mirror in front of the Mavenlink API(param: mavenlink) reading
+// from the store(param: store) within the staleness bound of the configuration(param: configuration)
+func New(mavenlink API.MavenlinkApiInterface, store *Store,
+	configuration *communicator.EnvironmentConfiguration) *Mirror {
+
+	mirror := &Mirror{mavenlink: mavenlink, store: store, settings: &atomic.Value{}}
+	mirror.configure(configuration)
+	return mirror
+}
+
+func (mirror *Mirror) configure(configuration *communicator.EnvironmentConfiguration) {
+	mirror.settings.Store(&settings{staleness: Staleness(configuration)})
+}
+
+// current returns the current settings of the mirror
+func (mirror *Mirror) current() *settings {
+	return mirror.settings.Load().(*settings)
+}
+
+// Staleness returns how long after the last sync the store is considered
+// stale according to the configuration(param: configuration)
+func Staleness(configuration *communicator.EnvironmentConfiguration) time.Duration {
+	if configuration != nil && configuration.MirrorStaleness > 0 {
+		return time.Duration(configuration.MirrorStaleness) * time.Second
+	}
+	return defaultStaleness
+}
+
+// fresh reports whether the store was synced within the staleness bound
+func (mirror *Mirror) fresh() bool {
+	lastSync, _ := mirror.store.LastSync()
+	return !lastSync.IsZero() && time.Since(lastSync) <= mirror.current().staleness
+}
+
+// fallback reports whether a failed read from Mavenlink(param: err) should be
+// served from the store instead. Only failures of Mavenlink itself fall back,
+// answers such as a missing resource or a rejected token are returned as is
+func (mirror *Mirror) fallback(err error) bool {
+	if err == nil || API.IsNotFound(err) {
+		return false
+	}
+	if statusErr, ok := errors.Cause(err).(*API.StatusError); ok && statusErr.StatusCode < 500 {
+		return false
+	}
+	if lastSync, _ := mirror.store.LastSync(); lastSync.IsZero() {
+		return false
+	}
+	LOG.Debug("Mirror serving stale data", LOG.Fields{"error": err})
+	return true
+}
+
+func (mirror *Mirror) SetEnv(configuration *communicator.EnvironmentConfiguration) error {
+	if err := mirror.mavenlink.SetEnv(configuration); err != nil {
+		return err
+	}
+	mirror.configure(configuration)
+	return nil
+}
+
+// WithContext returns the mirror bound to the context(param: ctx), sharing its store,
+// so that the reads going to Mavenlink are made on behalf of the context
+func (mirror *Mirror) WithContext(ctx context.Context) API.MavenlinkApiInterface {
+	return &Mirror{mavenlink: API.WithContext(mirror.mavenlink, ctx), store: mirror.store, settings: mirror.settings}
+}
+
+func (mirror *Mirror) FormatErrors(err error, message string) *communicator.Error {
+	return mirror.mavenlink.FormatErrors(err, message)
+}
+
+func (mirror *Mirror) GetProjects() ([]*communicator.Project, error) {
+	if !mirror.fresh() {
+		projects, err := mirror.mavenlink.GetProjects()
+		if !mirror.fallback(err) {
+			return projects, err
+		}
+	}
+	workspaces, err := mirror.store.Workspaces()
+	if err != nil {
+		return nil, err
+	}
+	var projects []*communicator.Project
+	for _, workspace := range workspaces {
+		projects = append(projects, API.WorkspaceToProject(workspace))
+	}
+	return projects, nil
+}
+
+func (mirror *Mirror) GetProject(keyOrId string) (*communicator.Project, error) {
+	if mirror.fresh() {
+		workspace, err := mirror.store.Workspace(keyOrId)
+		if err != nil || workspace != nil {
+			return projectOf(workspace), err
+		}
+	}
+	// Workspaces created since the last sync are only known to Mavenlink
+	project, err := mirror.mavenlink.GetProject(keyOrId)
+	if !mirror.fallback(err) {
+		return project, err
+	}
+	workspace, err := mirror.store.Workspace(keyOrId)
+	if err != nil {
+		return nil, err
+	}
+	if workspace == nil {
+		return nil, &API.NotFoundError{Resource: "Project", Id: keyOrId}
+	}
+	return projectOf(workspace), nil
+}
+
+func (mirror *Mirror) GetTasksFromProjectId(keyOrId string) ([]*communicator.Task, error) {
+	if !mirror.fresh() {
+		tasks, err := mirror.mavenlink.GetTasksFromProjectId(keyOrId)
+		if !mirror.fallback(err) {
+			return tasks, err
+		}
+	}
+	return mirror.tasksOf(keyOrId, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) < 1
+	}, false)
+}
+
+func (mirror *Mirror) GetSubTasksFromProjectId(workspace string, task string) ([]*communicator.Task, error) {
+	if !mirror.fresh() {
+		tasks, err := mirror.mavenlink.GetSubTasksFromProjectId(workspace, task)
+		if !mirror.fallback(err) {
+			return tasks, err
+		}
+	}
+	return mirror.tasksOf(workspace, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) > 0 && story.ParentId == task
+	}, false)
+}
+
+func (mirror *Mirror) GetIssueTasksFromProjectId(keyOrId string, subTask string) ([]*communicator.Task, error) {
+	if !mirror.fresh() {
+		tasks, err := mirror.mavenlink.GetIssueTasksFromProjectId(keyOrId, subTask)
+		if !mirror.fallback(err) {
+			return tasks, err
+		}
+	}
+	return mirror.tasksOf(keyOrId, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) > 0 && story.ParentId == subTask
+	}, true)
+}
+
+func (mirror *Mirror) GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId string,
+	issueTaskKeyOrId string) ([]*communicator.Timeentry, error) {
+
+	if !mirror.fresh() {
+		timeentries, err := mirror.mavenlink.GetTimeEntriesFromProjectIdAndIssueTaskId(projectKeyOrId, issueTaskKeyOrId)
+		if !mirror.fallback(err) {
+			return timeentries, err
+		}
+	}
+	mirrored, err := mirror.store.TimeEntriesOfWorkspace(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := mirror.participantsOf(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	var timeentries []*communicator.Timeentry
+	for _, timeentry := range mirrored {
+		if strings.EqualFold(issueTaskKeyOrId, timeentry.StoryId) {
+			timeentryWithUser := API.TimeEntryToTimeentry(timeentry)
+			timeentryWithUser.User = participants[timeentry.UserId]
+			timeentries = append(timeentries, timeentryWithUser)
+		}
+	}
+	return timeentries, nil
+}
+
+func (mirror *Mirror) GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error) {
+	if !mirror.fresh() {
+		users, err := mirror.mavenlink.GetUsersFromProjectId(projectKeyOrId)
+		if !mirror.fallback(err) {
+			return users, err
+		}
+	}
+	participants, err := mirror.store.Participants(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	var users []*communicator.User
+	for _, participant := range participants {
+		users = append(users, API.MavenlinkUserToUser(participant))
+	}
+	return users, nil
+}
+
+func (mirror *Mirror) GetUserFromProjectId(projectKeyOrId string, userId string) (*communicator.User, error) {
+	if !mirror.fresh() {
+		user, err := mirror.mavenlink.GetUserFromProjectId(projectKeyOrId, userId)
+		if !mirror.fallback(err) {
+			return user, err
+		}
+	}
+	participants, err := mirror.participantsOf(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	user, ok := participants[userId]
+	if !ok {
+		return nil, &API.NotFoundError{Resource: "User", Id: userId}
+	}
+	return user, nil
+}
+
+func (mirror *Mirror) GetUserById(userId string) (*communicator.User, error) {
+	if mirror.fresh() {
+		user, err := mirror.store.User(userId)
+		if err != nil || user != nil {
+			return userOf(user), err
+		}
+	}
+	// Users created since the last sync are only known to Mavenlink
+	found, err := mirror.mavenlink.GetUserById(userId)
+	if !mirror.fallback(err) {
+		return found, err
+	}
+	user, err := mirror.store.User(userId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, &API.NotFoundError{Resource: "User", Id: userId}
+	}
+	return userOf(user), nil
+}
+
+func (mirror *Mirror) GetTaskDependenciesFromProjectId(projectKeyOrId string) ([]*communicator.TaskDependency, error) {
+	if !mirror.fresh() {
+		dependencies, err := mirror.mavenlink.GetTaskDependenciesFromProjectId(projectKeyOrId)
+		if !mirror.fallback(err) {
+			return dependencies, err
+		}
+	}
+	return mirror.dependenciesOf(projectKeyOrId)
+}
+
+func (mirror *Mirror) GetCriticalPathFromProjectId(projectKeyOrId string) (*communicator.CriticalPath, error) {
+	if !mirror.fresh() {
+		criticalPath, err := mirror.mavenlink.GetCriticalPathFromProjectId(projectKeyOrId)
+		if !mirror.fallback(err) {
+			return criticalPath, err
+		}
+	}
+	tasks, err := mirror.tasksOf(projectKeyOrId, func(story *communicator.MavenlinkStory) bool {
+		return len(story.ParentId) < 1
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	dependencies, err := mirror.dependenciesOf(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	criticalPath, err := API.CriticalPathFromTasks(API.WithDependencies(tasks, dependencies))
+	if err != nil {
+		return nil, err
+	}
+	criticalPath.WorkspaceId = projectKeyOrId
+	return criticalPath, nil
+}
+
+func (mirror *Mirror) GetTaskTreeFromProjectId(projectKeyOrId string,
+	includeLoggedTime bool) ([]*communicator.TaskNode, error) {
+
+	if !mirror.fresh() {
+		tree, err := mirror.mavenlink.GetTaskTreeFromProjectId(projectKeyOrId, includeLoggedTime)
+		if !mirror.fallback(err) {
+			return tree, err
+		}
+	}
+	stories, err := mirror.store.StoriesOfWorkspace(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	users, err := mirror.participantsOf(projectKeyOrId)
+	if err != nil {
+		return nil, err
+	}
+	loggedMinutes := make(map[string]int32)
+	if includeLoggedTime {
+		timeentries, timeentriesErr := mirror.store.TimeEntriesOfWorkspace(projectKeyOrId)
+		if timeentriesErr != nil {
+			return nil, timeentriesErr
+		}
+		for _, timeentry := range timeentries {
+			loggedMinutes[timeentry.StoryId] += timeentry.TimeInMinutes
+		}
+	}
+	return API.BuildTaskTree(stories, users, loggedMinutes)
+}
+
+func (mirror *Mirror) StreamProjects(perPage int32, emit func(*communicator.Project) error) error {
+	return mirror.mavenlink.StreamProjects(perPage, emit)
+}
+
+func (mirror *Mirror) StreamTasks(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Task) error) error {
+
+	return mirror.mavenlink.StreamTasks(projectKeyOrId, perPage, emit)
+}
+
+func (mirror *Mirror) StreamTimeEntries(projectKeyOrId string, perPage int32,
+	emit func(*communicator.Timeentry) error) error {
+
+	return mirror.mavenlink.StreamTimeEntries(projectKeyOrId, perPage, emit)
+}
+
+func (mirror *Mirror) GetProjectsByIds(ids []string) (map[string]*communicator.Project, []string, error) {
+	if !mirror.fresh() {
+		projects, missing, err := mirror.mavenlink.GetProjectsByIds(ids)
+		if !mirror.fallback(err) {
+			return projects, missing, err
+		}
+	}
+	projects := make(map[string]*communicator.Project)
+	var missing []string
+	for _, id := range ids {
+		if _, ok := projects[id]; ok {
+			continue
+		}
+		workspace, err := mirror.store.Workspace(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if workspace == nil {
+			missing = append(missing, id)
+			continue
+		}
+		projects[id] = API.WorkspaceToProject(workspace)
+	}
+	return projects, missing, nil
+}
+
+func (mirror *Mirror) GetTasksByIds(ids []string) (map[string]*communicator.Task, []string, error) {
+	if !mirror.fresh() {
+		tasks, missing, err := mirror.mavenlink.GetTasksByIds(ids)
+		if !mirror.fallback(err) {
+			return tasks, missing, err
+		}
+	}
+	tasks := make(map[string]*communicator.Task)
+	var missing []string
+	for _, id := range ids {
+		if _, ok := tasks[id]; ok {
+			continue
+		}
+		story, err := mirror.store.Story(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if story == nil {
+			missing = append(missing, id)
+			continue
+		}
+		task := API.StoryToTask(story)
+		for _, assignee := range story.AssigneeIds {
+			user, userErr := mirror.store.User(assignee)
+			if userErr != nil {
+				return nil, nil, userErr
+			}
+			if user != nil {
+				task.User = API.MavenlinkUserToUser(user)
+			}
+		}
+		tasks[id] = task
+	}
+	return tasks, missing, nil
+}
+
+func (mirror *Mirror) GetUsersByIds(ids []string) (map[string]*communicator.User, []string, error) {
+	if !mirror.fresh() {
+		users, missing, err := mirror.mavenlink.GetUsersByIds(ids)
+		if !mirror.fallback(err) {
+			return users, missing, err
+		}
+	}
+	users := make(map[string]*communicator.User)
+	var missing []string
+	for _, id := range ids {
+		if _, ok := users[id]; ok {
+			continue
+		}
+		user, err := mirror.store.User(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil {
+			missing = append(missing, id)
+			continue
+		}
+		users[id] = API.MavenlinkUserToUser(user)
+	}
+	return users, missing, nil
+}
+
+func (mirror *Mirror) GetTimeEntriesByIds(ids []string) (map[string]*communicator.Timeentry, []string, error) {
+	if !mirror.fresh() {
+		timeentries, missing, err := mirror.mavenlink.GetTimeEntriesByIds(ids)
+		if !mirror.fallback(err) {
+			return timeentries, missing, err
+		}
+	}
+	timeentries := make(map[string]*communicator.Timeentry)
+	var missing []string
+	for _, id := range ids {
+		if _, ok := timeentries[id]; ok {
+			continue
+		}
+		timeentry, err := mirror.store.TimeEntry(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if timeentry == nil {
+			missing = append(missing, id)
+			continue
+		}
+		timeentryWithUser := API.TimeEntryToTimeentry(timeentry)
+		user, userErr := mirror.store.User(timeentry.UserId)
+		if userErr != nil {
+			return nil, nil, userErr
+		}
+		if user != nil {
+			timeentryWithUser.User = API.MavenlinkUserToUser(user)
+		}
+		timeentries[id] = timeentryWithUser
+	}
+	return timeentries, missing, nil
+}
+
+// tasksOf returns the mirrored stories of a workspace(param: workspace) accepted by
+// the filter(param: keep) as tasks, with their assignee when requested(param: withAssignee)
+func (mirror *Mirror) tasksOf(workspace string, keep func(*communicator.MavenlinkStory) bool,
+	withAssignee bool) ([]*communicator.Task, error) {
+
+	stories, err := mirror.store.StoriesOfWorkspace(workspace)
+	if err != nil {
+		return nil, err
+	}
+	var participants map[string]*communicator.User
+	if withAssignee {
+		if participants, err = mirror.participantsOf(workspace); err != nil {
+			return nil, err
+		}
+	}
+	var tasks []*communicator.Task
+	for _, story := range stories {
+		if !keep(story) {
+			continue
+		}
+		task := API.StoryToTask(story)
+		for _, assignee := range story.AssigneeIds {
+			if user, ok := participants[assignee]; ok {
+				task.User = user
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// dependenciesOf returns the mirrored story dependencies of a workspace(param: workspace)
+func (mirror *Mirror) dependenciesOf(workspace string) ([]*communicator.TaskDependency, error) {
+	mirrored, err := mirror.store.StoryDependenciesOfWorkspace(workspace)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []*communicator.TaskDependency
+	for _, dependency := range mirrored {
+		dependencies = append(dependencies, API.StoryDependencyToTaskDependency(dependency))
+	}
+	return dependencies, nil
+}
+
+// participantsOf returns the mirrored participants of a workspace(param: workspace) keyed by ID
+func (mirror *Mirror) participantsOf(workspace string) (map[string]*communicator.User, error) {
+	participants, err := mirror.store.Participants(workspace)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]*communicator.User)
+	for _, participant := range participants {
+		users[participant.Id] = API.MavenlinkUserToUser(participant)
+	}
+	return users, nil
+}
+
+func projectOf(workspace *communicator.MavenlinkWorkspace) *communicator.Project {
+	if workspace == nil {
+		return nil
+	}
+	return API.WorkspaceToProject(workspace)
+}
+
+func userOf(user *communicator.MavenlinkUser) *communicator.User {
+	if user == nil {
+		return nil
+	}
+	return API.MavenlinkUserToUser(user)
+}
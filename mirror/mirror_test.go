@@ -0,0 +1,137 @@
+package mirror
+
+import (
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestMirror returns a mirror synced from a fake Mavenlink server. The test(param: t)
+// must call the returned function to close the server and remove the store
+func newTestMirror(t *testing.T) (*Mirror, *Syncer, *mavenlinktest.Server, func()) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := Open(filepath.Join(dir, "mirror.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := mavenlinktest.NewServer()
+	env := server.Config()
+	api := new(API.MavenlinkApi)
+	api.SetEnv(env)
+	syncer := NewSyncer(api, store, env)
+	if err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	return New(api, store, env), syncer, server, func() {
+		server.Close()
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestMirrorServesDependenciesWithoutMavenlink(t *testing.T) {
+	mirror, _, server, closeAll := newTestMirror(t)
+	defer closeAll()
+	server.Reset()
+	server.Fail(mavenlinktest.StoryDependencies, mavenlinktest.Fault{Status: http.StatusServiceUnavailable}, 0)
+	dependencies, err := mirror.GetTaskDependenciesFromProjectId("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependencies) != 2 {
+		t.Errorf("expected the mirrored dependencies, got %v", dependencies)
+	}
+	criticalPath, err := mirror.GetCriticalPathFromProjectId("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(criticalPath.Tasks) != 3 {
+		t.Errorf("expected the critical path of the mirrored tasks, got %v", criticalPath)
+	}
+	if requests := server.Requests(""); len(requests) != 0 {
+		t.Errorf("expected the reads to be served by the mirror, got %v", requests)
+	}
+}
+
+func TestSyncPullsPages(t *testing.T) {
+	_, syncer, server, closeAll := newTestMirror(t)
+	defer closeAll()
+	timeentries, err := syncer.store.TimeEntriesOfWorkspace("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeentries) < 1 {
+		t.Error("expected the time entries to be mirrored")
+	}
+	// A page of the largest size is requested per resource as the fixtures fit in one
+	for _, endpoint := range []string{mavenlinktest.Workspaces, mavenlinktest.Stories, mavenlinktest.Users,
+		mavenlinktest.TimeEntries, mavenlinktest.StoryDependencies} {
+		var pages []mavenlinktest.Request
+		for _, request := range server.Requests(endpoint) {
+			if len(request.Query.Get("participant_in")) < 1 {
+				pages = append(pages, request)
+			}
+		}
+		if len(pages) != 1 || pages[0].Query.Get("per_page") != "200" {
+			t.Errorf("expected a single page of %s, got %v", endpoint, pages)
+		}
+	}
+}
+
+func TestSyncPrunesDeletedRecordsOnFullSyncs(t *testing.T) {
+	mirror, syncer, server, closeAll := newTestMirror(t)
+	defer closeAll()
+	server.Reset()
+	server.Remove(mavenlinktest.Stories, "3005")
+	server.Remove(mavenlinktest.StoryDependencies, "5002")
+	server.Remove(mavenlinktest.TimeEntries, "4003")
+
+	// Incremental syncs only pull the records updated since the last sync
+	if err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	for _, request := range server.Requests(mavenlinktest.Stories) {
+		if len(request.Query.Get("updated_after")) < 1 {
+			t.Errorf("expected an incremental sync, got %v", request.Query)
+		}
+	}
+	if story, err := syncer.store.Story("3005"); err != nil || story == nil {
+		t.Errorf("expected the deleted story to be kept until the next full sync, got %v", err)
+	}
+
+	syncer.fullSyncInterval = 0
+	if err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if story, err := syncer.store.Story("3005"); err != nil || story != nil {
+		t.Errorf("expected the deleted story to be pruned, got %v and %v", story, err)
+	}
+	tasks, err := mirror.GetTasksFromProjectId("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("expected the tasks left in Mavenlink, got %v", tasks)
+	}
+	dependencies, err := mirror.GetTaskDependenciesFromProjectId("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependencies) != 1 || dependencies[0].Id != "5001" {
+		t.Errorf("expected the dependency left in Mavenlink, got %v", dependencies)
+	}
+	timeentries, err := syncer.store.TimeEntriesOfWorkspace("1001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timeentries) != 2 {
+		t.Errorf("expected the time entries left in Mavenlink, got %v", timeentries)
+	}
+}
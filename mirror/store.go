@@ -0,0 +1,423 @@
+package mirror
+
+import (
+	"bytes"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"strings"
+	"time"
+)
+
+// Buckets holding the mirrored records keyed by their ID
+var (
+	workspacesBucket   = []byte("workspaces")
+	storiesBucket      = []byte("stories")
+	usersBucket        = []byte("users")
+	timeEntriesBucket  = []byte("time_entries")
+	dependenciesBucket = []byte("story_dependencies")
+	participantsBucket = []byte("participants")
+	metaBucket         = []byte("meta")
+)
+
+// Buckets indexing stories, time entries and story dependencies by workspace, keyed by "<workspace ID>/<ID>"
+var (
+	storiesByWorkspaceBucket      = []byte("stories_by_workspace")
+	timeEntriesByWorkspaceBucket  = []byte("time_entries_by_workspace")
+	dependenciesByWorkspaceBucket = []byte("story_dependencies_by_workspace")
+)
+
+// indexes maps the buckets of the records indexed by workspace onto their index
+var indexes = map[string][]byte{
+	string(storiesBucket):      storiesByWorkspaceBucket,
+	string(timeEntriesBucket):  timeEntriesByWorkspaceBucket,
+	string(dependenciesBucket): dependenciesByWorkspaceBucket,
+}
+
+// Keys of the meta bucket
+var (
+	lastSyncKey     = []byte("last_sync")
+	lastFullSyncKey = []byte("last_full_sync")
+)
+
+// File used when the configuration does not provide a mirror path
+const defaultPath = "mavenlink-mirror.db"
+
+// Store keeps a local copy of Mavenlink records in a BoltDB file
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens, creating it when missing, the mirror stored at the provided path(param: path)
+func Open(path string) (*Store, error) {
+	if len(path) < 1 {
+		path = defaultPath
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to open the mirror")
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{workspacesBucket, storiesBucket, usersBucket, timeEntriesBucket, dependenciesBucket,
+			participantsBucket, metaBucket, storiesByWorkspaceBucket, timeEntriesByWorkspaceBucket,
+			dependenciesByWorkspaceBucket} {
+			if _, bucketErr := tx.CreateBucketIfNotExists(name); bucketErr != nil {
+				return bucketErr
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "Failed to prepare the mirror")
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the mirror's file
+func (store *Store) Close() error {
+	return store.db.Close()
+}
+
+// LastSync returns the start time of the last successful sync, and of the last successful full sync
+func (store *Store) LastSync() (time.Time, time.Time) {
+	var lastSync, lastFullSync time.Time
+	store.db.View(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		lastSync, _ = time.Parse(time.RFC3339Nano, string(meta.Get(lastSyncKey)))
+		lastFullSync, _ = time.Parse(time.RFC3339Nano, string(meta.Get(lastFullSyncKey)))
+		return nil
+	})
+	return lastSync, lastFullSync
+}
+
+// setLastSync records the start time(param: started) of a successful sync
+func (store *Store) setLastSync(started time.Time, full bool) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		value := []byte(started.UTC().Format(time.RFC3339Nano))
+		if err := meta.Put(lastSyncKey, value); err != nil {
+			return err
+		}
+		if full {
+			return meta.Put(lastFullSyncKey, value)
+		}
+		return nil
+	})
+}
+
+// PutWorkspaces stores or replaces the provided workspaces(param: workspaces)
+func (store *Store) PutWorkspaces(workspaces []*communicator.MavenlinkWorkspace) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(workspacesBucket)
+		for _, workspace := range workspaces {
+			if err := putMessage(bucket, workspace.Id, workspace); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PutStories stores or replaces the provided stories(param: stories)
+func (store *Store) PutStories(stories []*communicator.MavenlinkStory) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(storiesBucket)
+		index := tx.Bucket(storiesByWorkspaceBucket)
+		for _, story := range stories {
+			previous := new(communicator.MavenlinkStory)
+			if found, err := getMessage(bucket, story.Id, previous); err != nil {
+				return err
+			} else if found {
+				index.Delete(indexKey(previous.WorkspaceId, previous.Id))
+			}
+			if err := putMessage(bucket, story.Id, story); err != nil {
+				return err
+			}
+			if err := index.Put(indexKey(story.WorkspaceId, story.Id), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PutUsers stores or replaces the provided users(param: users)
+func (store *Store) PutUsers(users []*communicator.MavenlinkUser) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(usersBucket)
+		for _, user := range users {
+			if err := putMessage(bucket, user.Id, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PutTimeEntries stores or replaces the provided time entries(param: timeentries)
+func (store *Store) PutTimeEntries(timeentries []*communicator.MavenlinkTimeentry) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(timeEntriesBucket)
+		index := tx.Bucket(timeEntriesByWorkspaceBucket)
+		for _, timeentry := range timeentries {
+			previous := new(communicator.MavenlinkTimeentry)
+			if found, err := getMessage(bucket, timeentry.Id, previous); err != nil {
+				return err
+			} else if found {
+				index.Delete(indexKey(previous.WorkspaceId, previous.Id))
+			}
+			if err := putMessage(bucket, timeentry.Id, timeentry); err != nil {
+				return err
+			}
+			if err := index.Put(indexKey(timeentry.WorkspaceId, timeentry.Id), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PutStoryDependencies stores or replaces the provided story dependencies(param: dependencies)
+func (store *Store) PutStoryDependencies(dependencies []*communicator.MavenlinkStoryDependency) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(dependenciesBucket)
+		index := tx.Bucket(dependenciesByWorkspaceBucket)
+		for _, dependency := range dependencies {
+			previous := new(communicator.MavenlinkStoryDependency)
+			if found, err := getMessage(bucket, dependency.Id, previous); err != nil {
+				return err
+			} else if found {
+				index.Delete(indexKey(previous.WorkspaceId, previous.Id))
+			}
+			if err := putMessage(bucket, dependency.Id, dependency); err != nil {
+				return err
+			}
+			if err := index.Put(indexKey(dependency.WorkspaceId, dependency.Id), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// PutParticipants replaces the IDs of the users participating in a workspace(param: workspaceId)
+func (store *Store) PutParticipants(workspaceId string, userIds []string) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(participantsBucket).Put([]byte(workspaceId), []byte(strings.Join(userIds, ",")))
+	})
+}
+
+// Workspace returns a single workspace, or nil when it is not mirrored
+func (store *Store) Workspace(id string) (*communicator.MavenlinkWorkspace, error) {
+	workspace := new(communicator.MavenlinkWorkspace)
+	found, err := store.get(workspacesBucket, id, workspace)
+	if err != nil || !found {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// Workspaces returns every mirrored workspace
+func (store *Store) Workspaces() ([]*communicator.MavenlinkWorkspace, error) {
+	var workspaces []*communicator.MavenlinkWorkspace
+	err := store.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(workspacesBucket).ForEach(func(key []byte, value []byte) error {
+			workspace := new(communicator.MavenlinkWorkspace)
+			if err := proto.Unmarshal(value, workspace); err != nil {
+				return err
+			}
+			workspaces = append(workspaces, workspace)
+			return nil
+		})
+	})
+	return workspaces, err
+}
+
+// Story returns a single story, or nil when it is not mirrored
+func (store *Store) Story(id string) (*communicator.MavenlinkStory, error) {
+	story := new(communicator.MavenlinkStory)
+	found, err := store.get(storiesBucket, id, story)
+	if err != nil || !found {
+		return nil, err
+	}
+	return story, nil
+}
+
+// StoriesOfWorkspace returns every mirrored story of a workspace(param: workspaceId)
+func (store *Store) StoriesOfWorkspace(workspaceId string) ([]*communicator.MavenlinkStory, error) {
+	var stories []*communicator.MavenlinkStory
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(storiesBucket)
+		return forEachIndexed(tx.Bucket(storiesByWorkspaceBucket), workspaceId, func(id string) error {
+			story := new(communicator.MavenlinkStory)
+			found, err := getMessage(bucket, id, story)
+			if found {
+				stories = append(stories, story)
+			}
+			return err
+		})
+	})
+	return stories, err
+}
+
+// User returns a single user, or nil when it is not mirrored
+func (store *Store) User(id string) (*communicator.MavenlinkUser, error) {
+	user := new(communicator.MavenlinkUser)
+	found, err := store.get(usersBucket, id, user)
+	if err != nil || !found {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Participants returns the mirrored users participating in a workspace(param: workspaceId)
+func (store *Store) Participants(workspaceId string) ([]*communicator.MavenlinkUser, error) {
+	var users []*communicator.MavenlinkUser
+	err := store.db.View(func(tx *bolt.Tx) error {
+		ids := string(tx.Bucket(participantsBucket).Get([]byte(workspaceId)))
+		if len(ids) < 1 {
+			return nil
+		}
+		bucket := tx.Bucket(usersBucket)
+		for _, id := range strings.Split(ids, ",") {
+			user := new(communicator.MavenlinkUser)
+			found, err := getMessage(bucket, id, user)
+			if err != nil {
+				return err
+			}
+			if found {
+				users = append(users, user)
+			}
+		}
+		return nil
+	})
+	return users, err
+}
+
+// TimeEntry returns a single time entry, or nil when it is not mirrored
+func (store *Store) TimeEntry(id string) (*communicator.MavenlinkTimeentry, error) {
+	timeentry := new(communicator.MavenlinkTimeentry)
+	found, err := store.get(timeEntriesBucket, id, timeentry)
+	if err != nil || !found {
+		return nil, err
+	}
+	return timeentry, nil
+}
+
+// TimeEntriesOfWorkspace returns every mirrored time entry of a workspace(param: workspaceId)
+func (store *Store) TimeEntriesOfWorkspace(workspaceId string) ([]*communicator.MavenlinkTimeentry, error) {
+	var timeentries []*communicator.MavenlinkTimeentry
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(timeEntriesBucket)
+		return forEachIndexed(tx.Bucket(timeEntriesByWorkspaceBucket), workspaceId, func(id string) error {
+			timeentry := new(communicator.MavenlinkTimeentry)
+			found, err := getMessage(bucket, id, timeentry)
+			if found {
+				timeentries = append(timeentries, timeentry)
+			}
+			return err
+		})
+	})
+	return timeentries, err
+}
+
+// StoryDependenciesOfWorkspace returns every mirrored story dependency of a workspace(param: workspaceId)
+func (store *Store) StoryDependenciesOfWorkspace(workspaceId string) ([]*communicator.MavenlinkStoryDependency, error) {
+	var dependencies []*communicator.MavenlinkStoryDependency
+	err := store.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(dependenciesBucket)
+		return forEachIndexed(tx.Bucket(dependenciesByWorkspaceBucket), workspaceId, func(id string) error {
+			dependency := new(communicator.MavenlinkStoryDependency)
+			found, err := getMessage(bucket, id, dependency)
+			if found {
+				dependencies = append(dependencies, dependency)
+			}
+			return err
+		})
+	})
+	return dependencies, err
+}
+
+// prune removes the records of a bucket(param: name) whose IDs are not in the provided set(param: keep)
+func (store *Store) prune(name []byte, keep map[string]bool) error {
+	return store.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(name)
+		var index *bolt.Bucket
+		if indexName, ok := indexes[string(name)]; ok {
+			index = tx.Bucket(indexName)
+		}
+		var stale [][]byte
+		bucket.ForEach(func(key []byte, value []byte) error {
+			if !keep[string(key)] {
+				stale = append(stale, key)
+			}
+			return nil
+		})
+		for _, key := range stale {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		if index == nil {
+			return nil
+		}
+		stale = nil
+		index.ForEach(func(key []byte, value []byte) error {
+			parts := strings.SplitN(string(key), "/", 2)
+			if len(parts) == 2 && !keep[parts[1]] {
+				stale = append(stale, key)
+			}
+			return nil
+		})
+		for _, key := range stale {
+			if err := index.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (store *Store) get(name []byte, id string, target proto.Message) (bool, error) {
+	var found bool
+	err := store.db.View(func(tx *bolt.Tx) error {
+		var err error
+		found, err = getMessage(tx.Bucket(name), id, target)
+		return err
+	})
+	return found, err
+}
+
+func getMessage(bucket *bolt.Bucket, id string, target proto.Message) (bool, error) {
+	value := bucket.Get([]byte(id))
+	if value == nil {
+		return false, nil
+	}
+	return true, proto.Unmarshal(value, target)
+}
+
+func putMessage(bucket *bolt.Bucket, id string, message proto.Message) error {
+	value, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return bucket.Put([]byte(id), value)
+}
+
+func indexKey(workspaceId string, id string) []byte {
+	return []byte(workspaceId + "/" + id)
+}
+
+// forEachIndexed calls the callback(param: fn) with the ID of every record indexed under a workspace(param: workspaceId)
+func forEachIndexed(index *bolt.Bucket, workspaceId string, fn func(id string) error) error {
+	prefix := []byte(workspaceId + "/")
+	cursor := index.Cursor()
+	for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+		if err := fn(string(key[len(prefix):])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
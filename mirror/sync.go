@@ -0,0 +1,286 @@
+package mirror
+
+import (
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"time"
+)
+
+// Defaults used when the configuration leaves a mirror setting empty
+const (
+	defaultInterval         = time.Minute
+	defaultStaleness        = 10 * time.Minute
+	defaultFullSyncInterval = 24 * time.Hour
+)
+
+// Incremental pulls ask for records updated slightly before the last sync
+// started so that clock drift between us and Mavenlink does not lose updates
+const syncOverlap = time.Minute
+
+// Source is the part of the Mavenlink API the mirror is synced from, handing the records over a page at a time
+type Source interface {
+	GetWorkspacesUpdatedAfter(updatedAfter time.Time, emit func([]*communicator.MavenlinkWorkspace) error) error
+	GetStoriesUpdatedAfter(updatedAfter time.Time, emit func([]*communicator.MavenlinkStory) error) error
+	GetUsersUpdatedAfter(updatedAfter time.Time, emit func([]*communicator.MavenlinkUser) error) error
+	GetTimeEntriesUpdatedAfter(updatedAfter time.Time, emit func([]*communicator.MavenlinkTimeentry) error) error
+	GetStoryDependenciesUpdatedAfter(updatedAfter time.Time, emit func([]*communicator.MavenlinkStoryDependency) error) error
+	GetUsersFromProjectId(projectKeyOrId string) ([]*communicator.User, error)
+}
+
+// Listener is told about the records a sync changed. Previous records are nil
+// for the records which were not mirrored yet. Listeners are not told about
+// the records pulled by the very first sync
+type Listener interface {
+	WorkspaceChanged(previous *communicator.MavenlinkWorkspace, current *communicator.MavenlinkWorkspace) error
+	StoryChanged(previous *communicator.MavenlinkStory, current *communicator.MavenlinkStory) error
+	TimeEntryChanged(previous *communicator.MavenlinkTimeentry, current *communicator.MavenlinkTimeentry) error
+}
+
+// Syncer keeps a Store up to date with Mavenlink, pulling only the records
+// updated since the last sync and periodically pulling everything to drop
+// the records deleted in Mavenlink
+type Syncer struct {
+	source           Source
+	store            *Store
+	interval         time.Duration
+	fullSyncInterval time.Duration
+	listeners        []Listener
+}
+
+// NewSyncer creates a syncer copying records from the Mavenlink API(param: source)
+// into the store(param: store) as often as the configuration(param: configuration) asks
+func NewSyncer(source Source, store *Store, configuration *communicator.EnvironmentConfiguration) *Syncer {
+	syncer := &Syncer{
+		source:           source,
+		store:            store,
+		interval:         defaultInterval,
+		fullSyncInterval: defaultFullSyncInterval,
+	}
+	if configuration != nil {
+		if configuration.MirrorInterval > 0 {
+			syncer.interval = time.Duration(configuration.MirrorInterval) * time.Second
+		}
+		if configuration.MirrorFullSyncInterval > 0 {
+			syncer.fullSyncInterval = time.Duration(configuration.MirrorFullSyncInterval) * time.Second
+		}
+	}
+	return syncer
+}
+
+// Listen registers a listener(param: listener) told about the records changed by every sync
+func (syncer *Syncer) Listen(listener Listener) {
+	syncer.listeners = append(syncer.listeners, listener)
+}
+
+// Run syncs the store right away and then on every interval until the
+// channel(param: stop) is closed. Failed syncs are retried on the next interval
+func (syncer *Syncer) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(syncer.interval)
+	defer ticker.Stop()
+	for {
+		if err := syncer.Sync(); err != nil {
+			LOG.Error("Mirror not synced", LOG.Fields{"error": err})
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync pulls the records updated since the last sync into the store, pulling
+// every record and pruning the others when a full sync is due
+func (syncer *Syncer) Sync() error {
+	started := time.Now()
+	lastSync, lastFullSync := syncer.store.LastSync()
+	full := lastFullSync.IsZero() || started.Sub(lastFullSync) >= syncer.fullSyncInterval
+	var updatedAfter time.Time
+	if !full {
+		updatedAfter = lastSync.Add(-syncOverlap)
+	}
+	notify := !lastSync.IsZero() && len(syncer.listeners) > 0
+
+	// Pull workspaces, remembering which ones changed to refresh their participants.
+	// Every page is written at once, the records it replaces being read beforehand
+	workspaceIds := make(map[string]bool)
+	err := syncer.source.GetWorkspacesUpdatedAfter(updatedAfter, func(workspaces []*communicator.MavenlinkWorkspace) error {
+		previous := make([]*communicator.MavenlinkWorkspace, len(workspaces))
+		for index, workspace := range workspaces {
+			workspaceIds[workspace.Id] = true
+			if notify {
+				var previousErr error
+				if previous[index], previousErr = syncer.store.Workspace(workspace.Id); previousErr != nil {
+					return previousErr
+				}
+			}
+		}
+		if putErr := syncer.store.PutWorkspaces(workspaces); putErr != nil {
+			return putErr
+		}
+		if notify {
+			for index, workspace := range workspaces {
+				for _, listener := range syncer.listeners {
+					syncer.report(listener.WorkspaceChanged(previous[index], workspace))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pull users
+	userIds := make(map[string]bool)
+	err = syncer.source.GetUsersUpdatedAfter(updatedAfter, func(users []*communicator.MavenlinkUser) error {
+		for _, user := range users {
+			userIds[user.Id] = true
+		}
+		return syncer.store.PutUsers(users)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pull stories
+	storyIds := make(map[string]bool)
+	err = syncer.source.GetStoriesUpdatedAfter(updatedAfter, func(stories []*communicator.MavenlinkStory) error {
+		previous := make([]*communicator.MavenlinkStory, len(stories))
+		for index, story := range stories {
+			storyIds[story.Id] = true
+			if notify {
+				var previousErr error
+				if previous[index], previousErr = syncer.store.Story(story.Id); previousErr != nil {
+					return previousErr
+				}
+			}
+		}
+		if putErr := syncer.store.PutStories(stories); putErr != nil {
+			return putErr
+		}
+		if notify {
+			for index, story := range stories {
+				for _, listener := range syncer.listeners {
+					syncer.report(listener.StoryChanged(previous[index], story))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pull time entries
+	timeentryIds := make(map[string]bool)
+	err = syncer.source.GetTimeEntriesUpdatedAfter(updatedAfter, func(timeentries []*communicator.MavenlinkTimeentry) error {
+		previous := make([]*communicator.MavenlinkTimeentry, len(timeentries))
+		for index, timeentry := range timeentries {
+			timeentryIds[timeentry.Id] = true
+			if notify {
+				var previousErr error
+				if previous[index], previousErr = syncer.store.TimeEntry(timeentry.Id); previousErr != nil {
+					return previousErr
+				}
+			}
+		}
+		if putErr := syncer.store.PutTimeEntries(timeentries); putErr != nil {
+			return putErr
+		}
+		if notify {
+			for index, timeentry := range timeentries {
+				for _, listener := range syncer.listeners {
+					syncer.report(listener.TimeEntryChanged(previous[index], timeentry))
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Pull story dependencies, so that tasks and critical paths are served without Mavenlink
+	dependencyIds := make(map[string]bool)
+	err = syncer.source.GetStoryDependenciesUpdatedAfter(updatedAfter, func(dependencies []*communicator.MavenlinkStoryDependency) error {
+		for _, dependency := range dependencies {
+			dependencyIds[dependency.Id] = true
+		}
+		return syncer.store.PutStoryDependencies(dependencies)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Drop the records which no longer exist in Mavenlink
+	if full {
+		for name, keep := range map[string]map[string]bool{
+			string(workspacesBucket):   workspaceIds,
+			string(usersBucket):        userIds,
+			string(storiesBucket):      storyIds,
+			string(timeEntriesBucket):  timeentryIds,
+			string(dependenciesBucket): dependencyIds,
+		} {
+			if err := syncer.store.prune([]byte(name), keep); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Refresh the participants of the workspaces that changed
+	for workspaceId := range workspaceIds {
+		if err := syncer.syncParticipants(workspaceId); err != nil {
+			return err
+		}
+	}
+
+	LOG.Debug("Mirror synced", LOG.Fields{
+		"full":         full,
+		"workspaces":   len(workspaceIds),
+		"stories":      len(storyIds),
+		"users":        len(userIds),
+		"time_entries": len(timeentryIds),
+		"dependencies": len(dependencyIds),
+		"latency_ms":   float64(time.Since(started)) / float64(time.Millisecond),
+	})
+	return syncer.store.setLastSync(started, full)
+}
+
+// report logs the failure(param: err) of a listener, which must not stop the sync
+func (syncer *Syncer) report(err error) {
+	if err != nil {
+		LOG.Error("Mirror listener failed", LOG.Fields{"error": err})
+	}
+}
+
+// syncParticipants replaces the participants of a workspace(param: workspaceId),
+// storing the participants which are not already mirrored as users
+func (syncer *Syncer) syncParticipants(workspaceId string) error {
+	participants, err := syncer.source.GetUsersFromProjectId(workspaceId)
+	if err != nil {
+		return err
+	}
+	var ids []string
+	var missing []*communicator.MavenlinkUser
+	for _, participant := range participants {
+		ids = append(ids, participant.Id)
+		user, userErr := syncer.store.User(participant.Id)
+		if userErr != nil {
+			return userErr
+		}
+		if user == nil {
+			missing = append(missing, &communicator.MavenlinkUser{
+				Id:           participant.Id,
+				FullName:     participant.FullName,
+				EmailAddress: participant.EmailAddress,
+				Headline:     participant.Headline,
+				AccountId:    participant.AccountId,
+			})
+		}
+	}
+	if err := syncer.store.PutUsers(missing); err != nil {
+		return err
+	}
+	return syncer.store.PutParticipants(workspaceId, ids)
+}
@@ -0,0 +1,148 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8060"
+
+// Paths of the endpoints starting the authorization and receiving its outcome
+const (
+	StartPath    = "/oauth/start"
+	CallbackPath = "/oauth/callback"
+)
+
+// How long an authorization may take between its start and its callback
+const stateTTL = 10 * time.Minute
+
+// Cookie holding the state of the authorization started by a browser
+const stateCookie = "mavenlink_oauth_state"
+
+// Handler starts the authorization of the application and exchanges the code
+// Mavenlink redirects back with for a token. Only an administrator may start an
+// authorization, by passing the admin key as the password of basic authentication,
+// and the callback is only accepted from the browser which started it
+type Handler struct {
+	source   *Source
+	adminKey []byte
+	secure   bool
+	mutex    sync.Mutex
+	states   map[string]time.Time
+}
+
+// NewHandler creates a handler authorizing the application for the source(param: source)
+func NewHandler(source *Source, configuration *communicator.EnvironmentConfiguration) *Handler {
+	return &Handler{
+		source:   source,
+		adminKey: []byte(configuration.OauthAdminKey),
+		secure:   strings.HasPrefix(configuration.OauthRedirectUrl, "https://"),
+		states:   make(map[string]time.Time),
+	}
+}
+
+// ListenAndServe serves the authorization endpoints on the address of the
+// configuration(param: configuration) until the listener fails
+func ListenAndServe(source *Source, configuration *communicator.EnvironmentConfiguration) error {
+	address := configuration.OauthAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	return http.ListenAndServe(address, NewHandler(source, configuration))
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.URL.Path {
+	case StartPath:
+		handler.start(w, r)
+	case CallbackPath:
+		handler.callback(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// start redirects to Mavenlink with a state the callback checks to reject forged requests,
+// also kept in a cookie so that the callback must come from the same browser
+func (handler *Handler) start(w http.ResponseWriter, r *http.Request) {
+	_, key, ok := r.BasicAuth()
+	if !ok || len(handler.adminKey) < 1 || subtle.ConstantTimeCompare([]byte(key), handler.adminKey) != 1 {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Mavenlink authorization"`)
+		http.Error(w, "The admin key is required to authorize the application", http.StatusUnauthorized)
+		return
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		http.Error(w, "Failed to start the authorization", http.StatusInternalServerError)
+		return
+	}
+	state := hex.EncodeToString(nonce)
+	handler.mutex.Lock()
+	now := time.Now()
+	for pending, expiry := range handler.states {
+		if now.After(expiry) {
+			delete(handler.states, pending)
+		}
+	}
+	handler.states[state] = now.Add(stateTTL)
+	handler.mutex.Unlock()
+	// Mavenlink redirects back with a top-level navigation, which lax cookies are sent with
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookie,
+		Value:    state,
+		Path:     CallbackPath,
+		MaxAge:   int(stateTTL / time.Second),
+		HttpOnly: true,
+		Secure:   handler.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, handler.source.AuthorizeURL(state), http.StatusFound)
+}
+
+func (handler *Handler) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if denied := query.Get("error"); len(denied) > 0 {
+		http.Error(w, "Mavenlink did not authorize the application: "+denied, http.StatusForbidden)
+		return
+	}
+	state := query.Get("state")
+	cookie, err := r.Cookie(stateCookie)
+	http.SetCookie(w, &http.Cookie{Name: stateCookie, Path: CallbackPath, MaxAge: -1, HttpOnly: true, Secure: handler.secure})
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		http.Error(w, "The authorization was started by another browser", http.StatusBadRequest)
+		return
+	}
+	handler.mutex.Lock()
+	expiry, ok := handler.states[state]
+	delete(handler.states, state)
+	handler.mutex.Unlock()
+	if !ok || time.Now().After(expiry) {
+		http.Error(w, "Unknown or expired authorization state", http.StatusBadRequest)
+		return
+	}
+	code := query.Get("code")
+	if len(code) < 1 {
+		http.Error(w, "Missing authorization code", http.StatusBadRequest)
+		return
+	}
+	if err := handler.source.Exchange(code); err != nil {
+		LOG.Debug("OAuth authorization code not exchanged", LOG.Fields{"path": CallbackPath, "error": err})
+		http.Error(w, "Failed to exchange the authorization code", http.StatusBadGateway)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("Mavenlink access authorized, you can close this page\n"))
+}
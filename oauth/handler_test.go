@@ -0,0 +1,114 @@
+package oauth
+
+import (
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestHandler returns a handler whose source trades codes with a fake Mavenlink token endpoint
+func newTestHandler(t *testing.T) (*Handler, *Source, func()) {
+	mavenlink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "granted", "token_type": "bearer", "refresh_token": "renew", "expires_in": 3600}`))
+	}))
+	dir, err := ioutil.TempDir("", "oauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configuration := &communicator.EnvironmentConfiguration{
+		OauthUrl:           mavenlink.URL,
+		OauthClientId:      "client",
+		OauthClientSecret:  "secret",
+		OauthRedirectUrl:   "https://service.example.com" + CallbackPath,
+		OauthTokenPath:     filepath.Join(dir, "token.enc"),
+		OauthEncryptionKey: "0123456789abcdef0123456789abcdef",
+		OauthAdminKey:      "admin-key",
+	}
+	source, err := NewSource(configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewHandler(source, configuration), source, func() {
+		mavenlink.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+// start starts an authorization with the admin key(param: key), returning the response
+func start(handler *Handler, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", StartPath, nil)
+	if len(key) > 0 {
+		req.SetBasicAuth("admin", key)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// callback calls back with the state(param: state), from the browser holding the cookies(param: cookies)
+func callback(handler *Handler, state string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", CallbackPath+"?"+url.Values{"state": {state}, "code": {"code"}}.Encode(), nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func stateOf(t *testing.T, w *httptest.ResponseRecorder) string {
+	location, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return location.Query().Get("state")
+}
+
+func TestStartRequiresTheAdminKey(t *testing.T) {
+	handler, _, closeAll := newTestHandler(t)
+	defer closeAll()
+	for _, key := range []string{"", "another-key"} {
+		if w := start(handler, key); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected the authorization to be refused with key %q, got %d", key, w.Code)
+		}
+	}
+	if w := start(handler, "admin-key"); w.Code != http.StatusFound {
+		t.Errorf("expected the administrator to be redirected to Mavenlink, got %d", w.Code)
+	}
+}
+
+func TestCallbackRequiresTheBrowserWhichStarted(t *testing.T) {
+	handler, source, closeAll := newTestHandler(t)
+	defer closeAll()
+	started := start(handler, "admin-key")
+	state := stateOf(t, started)
+	cookies := started.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Value != state || !cookies[0].HttpOnly || !cookies[0].Secure {
+		t.Fatalf("expected the state to be kept in a secure cookie, got %v", cookies)
+	}
+	if w := callback(handler, state, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a callback without the cookie to be refused, got %d", w.Code)
+	}
+	other := start(handler, "admin-key").Result().Cookies()
+	if w := callback(handler, state, other); w.Code != http.StatusBadRequest {
+		t.Errorf("expected a callback with the cookie of another authorization to be refused, got %d", w.Code)
+	}
+	if err := source.Authorized(); err != ErrNotAuthorized {
+		t.Fatalf("expected no token before the callback, got %v", err)
+	}
+	if w := callback(handler, state, cookies); w.Code != http.StatusOK {
+		t.Fatalf("expected the callback of the browser which started to be accepted, got %d", w.Code)
+	}
+	if token, err := source.Token(); err != nil || token != "granted" {
+		t.Errorf("expected the granted token, got %q %v", token, err)
+	}
+	if err := source.Authorized(); err != nil {
+		t.Errorf("expected the application to be authorized, got %v", err)
+	}
+}
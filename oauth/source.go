@@ -0,0 +1,216 @@
+package oauth
+
+import (
+	"encoding/json"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults used when the configuration does not provide a value
+const (
+	defaultURL       = "https://app.mavenlink.com"
+	defaultTokenPath = "mavenlink-token.enc"
+)
+
+// Paths of the authorization and token endpoints of Mavenlink
+const (
+	authorizePath = "/oauth/authorize"
+	tokenPath     = "/oauth/token"
+)
+
+// A token is refreshed once it expires within this duration
+const refreshLeeway = 5 * time.Minute
+
+// ErrNotAuthorized is returned until the application has been authorized through the authorization endpoint
+var ErrNotAuthorized = errors.New("Mavenlink access has not been authorized yet")
+
+// Source provides the token granted to the application, refreshing it before
+// it expires. It implements the api package's TokenSource
+type Source struct {
+	configuration *communicator.EnvironmentConfiguration
+	store         *Store
+	client        *http.Client
+	mutex         sync.Mutex
+	token         *Token
+	refreshErr    error
+}
+
+// NewSource creates a source with the OAuth settings of the configuration(param: configuration),
+// starting with the token found in its store if any
+func NewSource(configuration *communicator.EnvironmentConfiguration) (*Source, error) {
+	if len(configuration.OauthClientId) < 1 || len(configuration.OauthClientSecret) < 1 {
+		return nil, errors.New("An OAuth client ID and secret are required")
+	}
+	path := configuration.OauthTokenPath
+	if len(path) < 1 {
+		path = defaultTokenPath
+	}
+	store, err := NewStore(path, configuration.OauthEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	token, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Source{
+		configuration: configuration,
+		store:         store,
+		client:        &http.Client{Timeout: 30 * time.Second},
+		token:         token,
+	}, nil
+}
+
+// Token returns the access token, refreshing it first when it is about to expire
+func (source *Source) Token() (string, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.token == nil {
+		return "", ErrNotAuthorized
+	}
+	if !source.token.Expiry.IsZero() && time.Now().Add(refreshLeeway).After(source.token.Expiry) {
+		if err := source.refresh(); err != nil {
+			return "", err
+		}
+	}
+	return source.token.AccessToken, nil
+}
+
+// Authorized returns the reason no token can be provided, without refreshing it: the
+// application has not been authorized, or its token expired and cannot be refreshed
+func (source *Source) Authorized() error {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.token == nil {
+		return ErrNotAuthorized
+	}
+	if source.token.Expiry.IsZero() || time.Now().Before(source.token.Expiry) {
+		return nil
+	}
+	if len(source.token.RefreshToken) < 1 {
+		return errors.New("The Mavenlink token expired and cannot be refreshed, authorize the application again")
+	}
+	if source.refreshErr != nil {
+		return errors.Wrap(source.refreshErr, "The Mavenlink token expired and could not be refreshed")
+	}
+	return nil
+}
+
+// Refresh renews the access token whatever its expiry
+func (source *Source) Refresh() (string, error) {
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	if source.token == nil {
+		return "", ErrNotAuthorized
+	}
+	if err := source.refresh(); err != nil {
+		return "", err
+	}
+	return source.token.AccessToken, nil
+}
+
+// Exchange trades an authorization code(param: code) for a token and stores it
+func (source *Source) Exchange(code string) error {
+	token, err := source.request(url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {source.configuration.OauthRedirectUrl},
+	})
+	if err != nil {
+		return err
+	}
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+	source.token = token
+	return source.store.Save(token)
+}
+
+// AuthorizeURL returns the Mavenlink page asking the user to authorize the application,
+// redirecting back with the state(param: state)
+func (source *Source) AuthorizeURL(state string) string {
+	values := url.Values{
+		"response_type": {"code"},
+		"client_id":     {source.configuration.OauthClientId},
+		"redirect_uri":  {source.configuration.OauthRedirectUrl},
+		"state":         {state},
+	}
+	return source.baseURL() + authorizePath + "?" + values.Encode()
+}
+
+// refresh renews the token with its refresh token, the caller holding the mutex
+func (source *Source) refresh() error {
+	if len(source.token.RefreshToken) < 1 {
+		return errors.New("The Mavenlink token cannot be refreshed, authorize the application again")
+	}
+	token, err := source.request(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {source.token.RefreshToken},
+	})
+	source.refreshErr = err
+	if err != nil {
+		return err
+	}
+	// Mavenlink may keep the refresh token unchanged and omit it from the response
+	if len(token.RefreshToken) < 1 {
+		token.RefreshToken = source.token.RefreshToken
+	}
+	source.token = token
+	if err := source.store.Save(token); err != nil {
+		LOG.Error("OAuth token not stored", LOG.Fields{"error": err})
+	}
+	return nil
+}
+
+// request calls the token endpoint with the grant(param: grant)
+func (source *Source) request(grant url.Values) (*Token, error) {
+	grant.Set("client_id", source.configuration.OauthClientId)
+	grant.Set("client_secret", source.configuration.OauthClientSecret)
+	httpReq, err := http.NewRequest("POST", source.baseURL()+tokenPath, strings.NewReader(grant.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Accept", "application/json")
+	httpResp, err := source.client.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to reach the Mavenlink token endpoint")
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode >= 400 {
+		return nil, errors.Errorf("The Mavenlink token endpoint answered %s", httpResp.Status)
+	}
+	var granted struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&granted); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the token response")
+	}
+	if len(granted.AccessToken) < 1 {
+		return nil, errors.New("The token response holds no access token")
+	}
+	token := &Token{
+		AccessToken:  granted.AccessToken,
+		TokenType:    granted.TokenType,
+		RefreshToken: granted.RefreshToken,
+	}
+	if granted.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(granted.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+func (source *Source) baseURL() string {
+	if len(source.configuration.OauthUrl) > 0 {
+		return strings.TrimSuffix(source.configuration.OauthUrl, "/")
+	}
+	return defaultURL
+}
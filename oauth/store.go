@@ -0,0 +1,99 @@
+package oauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Token is the set of credentials granted by Mavenlink to the application
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Store keeps the token on disk encrypted with AES-GCM under a key derived
+// from the configured encryption key
+type Store struct {
+	path string
+	aead cipher.AEAD
+}
+
+// NewStore creates a store keeping the token in the file at the path(param: path),
+// encrypted with the key(param: key)
+func NewStore(path string, key string) (*Store, error) {
+	if len(key) < 1 {
+		return nil, errors.New("An encryption key is required to store OAuth tokens")
+	}
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the token cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the token cipher")
+	}
+	return &Store{path: path, aead: aead}, nil
+}
+
+// Load returns the stored token, or nil when none was stored yet
+func (store *Store) Load() (*Token, error) {
+	sealed, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read the token file")
+	}
+	nonceSize := store.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errors.New("The token file is corrupted")
+	}
+	plain, err := store.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to decrypt the token file, was the encryption key changed?")
+	}
+	token := new(Token)
+	if err := json.Unmarshal(plain, token); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the token file")
+	}
+	return token, nil
+}
+
+// Save replaces the stored token with the token(param: token). The file is
+// written aside and renamed so that a failed write never loses the previous token
+func (store *Store) Save(token *Token) error {
+	plain, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, store.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return errors.Wrap(err, "Failed to generate a nonce")
+	}
+	sealed := store.aead.Seal(nonce, nonce, plain, nil)
+	temp, err := ioutil.TempFile(filepath.Dir(store.path), filepath.Base(store.path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "Failed to write the token file")
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(sealed); err != nil {
+		temp.Close()
+		return errors.Wrap(err, "Failed to write the token file")
+	}
+	if err := temp.Close(); err != nil {
+		return errors.Wrap(err, "Failed to write the token file")
+	}
+	return errors.Wrap(os.Rename(temp.Name(), store.path), "Failed to write the token file")
+}
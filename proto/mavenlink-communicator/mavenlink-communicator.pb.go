@@ -47,7 +47,7 @@ func (m *Project) Reset()         { *m = Project{} }
 func (m *Project) String() string { return proto.CompactTextString(m) }
 func (*Project) ProtoMessage()    {}
 func (*Project) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{0}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{0}
 }
 func (m *Project) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Project.Unmarshal(m, b)
@@ -159,31 +159,33 @@ func (m *Project) GetUpdatedAt() string {
 }
 
 type Task struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StoryType            string   `protobuf:"bytes,4,opt,name=story_type,json=storyType,proto3" json:"story_type,omitempty"`
-	Priority             string   `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Archived             bool     `protobuf:"varint,6,opt,name=archived,proto3" json:"archived,omitempty"`
-	WorkspaceId          string   `protobuf:"bytes,7,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
-	CreatorId            string   `protobuf:"bytes,8,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
-	ParentId             string   `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	DueDate              string   `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	State                string   `protobuf:"bytes,11,opt,name=state,proto3" json:"state,omitempty"`
-	StartDate            string   `protobuf:"bytes,12,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	CreatedAt            string   `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            string   `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	User                 *User    `protobuf:"bytes,15,opt,name=user,proto3" json:"user,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                   string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title                string            `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description          string            `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StoryType            string            `protobuf:"bytes,4,opt,name=story_type,json=storyType,proto3" json:"story_type,omitempty"`
+	Priority             string            `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Archived             bool              `protobuf:"varint,6,opt,name=archived,proto3" json:"archived,omitempty"`
+	WorkspaceId          string            `protobuf:"bytes,7,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	CreatorId            string            `protobuf:"bytes,8,opt,name=creator_id,json=creatorId,proto3" json:"creator_id,omitempty"`
+	ParentId             string            `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	DueDate              string            `protobuf:"bytes,10,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	State                string            `protobuf:"bytes,11,opt,name=state,proto3" json:"state,omitempty"`
+	StartDate            string            `protobuf:"bytes,12,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	CreatedAt            string            `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            string            `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	User                 *User             `protobuf:"bytes,15,opt,name=user,proto3" json:"user,omitempty"`
+	Predecessors         []*TaskDependency `protobuf:"bytes,16,rep,name=predecessors,proto3" json:"predecessors,omitempty"`
+	Successors           []*TaskDependency `protobuf:"bytes,17,rep,name=successors,proto3" json:"successors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *Task) Reset()         { *m = Task{} }
 func (m *Task) String() string { return proto.CompactTextString(m) }
 func (*Task) ProtoMessage()    {}
 func (*Task) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{1}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{1}
 }
 func (m *Task) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Task.Unmarshal(m, b)
@@ -308,6 +310,356 @@ func (m *Task) GetUser() *User {
 	return nil
 }
 
+func (m *Task) GetPredecessors() []*TaskDependency {
+	if m != nil {
+		return m.Predecessors
+	}
+	return nil
+}
+
+func (m *Task) GetSuccessors() []*TaskDependency {
+	if m != nil {
+		return m.Successors
+	}
+	return nil
+}
+
+type TaskNode struct {
+	Task                 *Task       `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Assignees            []*User     `protobuf:"bytes,2,rep,name=assignees,proto3" json:"assignees,omitempty"`
+	LoggedMinutes        int32       `protobuf:"varint,3,opt,name=logged_minutes,json=loggedMinutes,proto3" json:"logged_minutes,omitempty"`
+	TotalLoggedMinutes   int32       `protobuf:"varint,4,opt,name=total_logged_minutes,json=totalLoggedMinutes,proto3" json:"total_logged_minutes,omitempty"`
+	Children             []*TaskNode `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *TaskNode) Reset()         { *m = TaskNode{} }
+func (m *TaskNode) String() string { return proto.CompactTextString(m) }
+func (*TaskNode) ProtoMessage()    {}
+func (*TaskNode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{2}
+}
+func (m *TaskNode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskNode.Unmarshal(m, b)
+}
+func (m *TaskNode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskNode.Marshal(b, m, deterministic)
+}
+func (dst *TaskNode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskNode.Merge(dst, src)
+}
+func (m *TaskNode) XXX_Size() int {
+	return xxx_messageInfo_TaskNode.Size(m)
+}
+func (m *TaskNode) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskNode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskNode proto.InternalMessageInfo
+
+func (m *TaskNode) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *TaskNode) GetAssignees() []*User {
+	if m != nil {
+		return m.Assignees
+	}
+	return nil
+}
+
+func (m *TaskNode) GetLoggedMinutes() int32 {
+	if m != nil {
+		return m.LoggedMinutes
+	}
+	return 0
+}
+
+func (m *TaskNode) GetTotalLoggedMinutes() int32 {
+	if m != nil {
+		return m.TotalLoggedMinutes
+	}
+	return 0
+}
+
+func (m *TaskNode) GetChildren() []*TaskNode {
+	if m != nil {
+		return m.Children
+	}
+	return nil
+}
+
+type TaskDependency struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PredecessorId        string   `protobuf:"bytes,2,opt,name=predecessor_id,json=predecessorId,proto3" json:"predecessor_id,omitempty"`
+	SuccessorId          string   `protobuf:"bytes,3,opt,name=successor_id,json=successorId,proto3" json:"successor_id,omitempty"`
+	DependencyType       string   `protobuf:"bytes,4,opt,name=dependency_type,json=dependencyType,proto3" json:"dependency_type,omitempty"`
+	Lag                  int32    `protobuf:"varint,5,opt,name=lag,proto3" json:"lag,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskDependency) Reset()         { *m = TaskDependency{} }
+func (m *TaskDependency) String() string { return proto.CompactTextString(m) }
+func (*TaskDependency) ProtoMessage()    {}
+func (*TaskDependency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{3}
+}
+func (m *TaskDependency) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskDependency.Unmarshal(m, b)
+}
+func (m *TaskDependency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskDependency.Marshal(b, m, deterministic)
+}
+func (dst *TaskDependency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskDependency.Merge(dst, src)
+}
+func (m *TaskDependency) XXX_Size() int {
+	return xxx_messageInfo_TaskDependency.Size(m)
+}
+func (m *TaskDependency) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskDependency.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskDependency proto.InternalMessageInfo
+
+func (m *TaskDependency) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TaskDependency) GetPredecessorId() string {
+	if m != nil {
+		return m.PredecessorId
+	}
+	return ""
+}
+
+func (m *TaskDependency) GetSuccessorId() string {
+	if m != nil {
+		return m.SuccessorId
+	}
+	return ""
+}
+
+func (m *TaskDependency) GetDependencyType() string {
+	if m != nil {
+		return m.DependencyType
+	}
+	return ""
+}
+
+func (m *TaskDependency) GetLag() int32 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
+}
+
+type CriticalPathTask struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartDate            string   `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	DueDate              string   `protobuf:"bytes,4,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	Duration             int32    `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	EarliestStart        int32    `protobuf:"varint,6,opt,name=earliest_start,json=earliestStart,proto3" json:"earliest_start,omitempty"`
+	EarliestFinish       int32    `protobuf:"varint,7,opt,name=earliest_finish,json=earliestFinish,proto3" json:"earliest_finish,omitempty"`
+	LatestStart          int32    `protobuf:"varint,8,opt,name=latest_start,json=latestStart,proto3" json:"latest_start,omitempty"`
+	LatestFinish         int32    `protobuf:"varint,9,opt,name=latest_finish,json=latestFinish,proto3" json:"latest_finish,omitempty"`
+	Slack                int32    `protobuf:"varint,10,opt,name=slack,proto3" json:"slack,omitempty"`
+	Critical             bool     `protobuf:"varint,11,opt,name=critical,proto3" json:"critical,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CriticalPathTask) Reset()         { *m = CriticalPathTask{} }
+func (m *CriticalPathTask) String() string { return proto.CompactTextString(m) }
+func (*CriticalPathTask) ProtoMessage()    {}
+func (*CriticalPathTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{4}
+}
+func (m *CriticalPathTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CriticalPathTask.Unmarshal(m, b)
+}
+func (m *CriticalPathTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CriticalPathTask.Marshal(b, m, deterministic)
+}
+func (dst *CriticalPathTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CriticalPathTask.Merge(dst, src)
+}
+func (m *CriticalPathTask) XXX_Size() int {
+	return xxx_messageInfo_CriticalPathTask.Size(m)
+}
+func (m *CriticalPathTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_CriticalPathTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CriticalPathTask proto.InternalMessageInfo
+
+func (m *CriticalPathTask) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CriticalPathTask) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *CriticalPathTask) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *CriticalPathTask) GetDueDate() string {
+	if m != nil {
+		return m.DueDate
+	}
+	return ""
+}
+
+func (m *CriticalPathTask) GetDuration() int32 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetEarliestStart() int32 {
+	if m != nil {
+		return m.EarliestStart
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetEarliestFinish() int32 {
+	if m != nil {
+		return m.EarliestFinish
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetLatestStart() int32 {
+	if m != nil {
+		return m.LatestStart
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetLatestFinish() int32 {
+	if m != nil {
+		return m.LatestFinish
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetSlack() int32 {
+	if m != nil {
+		return m.Slack
+	}
+	return 0
+}
+
+func (m *CriticalPathTask) GetCritical() bool {
+	if m != nil {
+		return m.Critical
+	}
+	return false
+}
+
+type CriticalPath struct {
+	WorkspaceId          string              `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	StartDate            string              `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	FinishDate           string              `protobuf:"bytes,3,opt,name=finish_date,json=finishDate,proto3" json:"finish_date,omitempty"`
+	Duration             int32               `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	TaskIds              []string            `protobuf:"bytes,5,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	Tasks                []*CriticalPathTask `protobuf:"bytes,6,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *CriticalPath) Reset()         { *m = CriticalPath{} }
+func (m *CriticalPath) String() string { return proto.CompactTextString(m) }
+func (*CriticalPath) ProtoMessage()    {}
+func (*CriticalPath) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{5}
+}
+func (m *CriticalPath) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CriticalPath.Unmarshal(m, b)
+}
+func (m *CriticalPath) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CriticalPath.Marshal(b, m, deterministic)
+}
+func (dst *CriticalPath) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CriticalPath.Merge(dst, src)
+}
+func (m *CriticalPath) XXX_Size() int {
+	return xxx_messageInfo_CriticalPath.Size(m)
+}
+func (m *CriticalPath) XXX_DiscardUnknown() {
+	xxx_messageInfo_CriticalPath.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CriticalPath proto.InternalMessageInfo
+
+func (m *CriticalPath) GetWorkspaceId() string {
+	if m != nil {
+		return m.WorkspaceId
+	}
+	return ""
+}
+
+func (m *CriticalPath) GetStartDate() string {
+	if m != nil {
+		return m.StartDate
+	}
+	return ""
+}
+
+func (m *CriticalPath) GetFinishDate() string {
+	if m != nil {
+		return m.FinishDate
+	}
+	return ""
+}
+
+func (m *CriticalPath) GetDuration() int32 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *CriticalPath) GetTaskIds() []string {
+	if m != nil {
+		return m.TaskIds
+	}
+	return nil
+}
+
+func (m *CriticalPath) GetTasks() []*CriticalPathTask {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
 type Timeentry struct {
 	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	DatePerformed        string   `protobuf:"bytes,2,opt,name=date_performed,json=datePerformed,proto3" json:"date_performed,omitempty"`
@@ -327,7 +679,7 @@ func (m *Timeentry) Reset()         { *m = Timeentry{} }
 func (m *Timeentry) String() string { return proto.CompactTextString(m) }
 func (*Timeentry) ProtoMessage()    {}
 func (*Timeentry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{2}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{6}
 }
 func (m *Timeentry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Timeentry.Unmarshal(m, b)
@@ -425,7 +777,7 @@ func (m *User) Reset()         { *m = User{} }
 func (m *User) String() string { return proto.CompactTextString(m) }
 func (*User) ProtoMessage()    {}
 func (*User) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{3}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{7}
 }
 func (m *User) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_User.Unmarshal(m, b)
@@ -480,65 +832,375 @@ func (m *User) GetAccountId() string {
 	return ""
 }
 
-type MavenlinkResponseResults struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+type TaskCreated struct {
+	Task                 *Task    `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	DetectedAt           string   `protobuf:"bytes,2,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MavenlinkResponseResults) Reset()         { *m = MavenlinkResponseResults{} }
-func (m *MavenlinkResponseResults) String() string { return proto.CompactTextString(m) }
-func (*MavenlinkResponseResults) ProtoMessage()    {}
-func (*MavenlinkResponseResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{4}
+func (m *TaskCreated) Reset()         { *m = TaskCreated{} }
+func (m *TaskCreated) String() string { return proto.CompactTextString(m) }
+func (*TaskCreated) ProtoMessage()    {}
+func (*TaskCreated) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{8}
 }
-func (m *MavenlinkResponseResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MavenlinkResponseResults.Unmarshal(m, b)
+func (m *TaskCreated) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskCreated.Unmarshal(m, b)
 }
-func (m *MavenlinkResponseResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MavenlinkResponseResults.Marshal(b, m, deterministic)
+func (m *TaskCreated) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskCreated.Marshal(b, m, deterministic)
 }
-func (dst *MavenlinkResponseResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MavenlinkResponseResults.Merge(dst, src)
+func (dst *TaskCreated) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskCreated.Merge(dst, src)
 }
-func (m *MavenlinkResponseResults) XXX_Size() int {
-	return xxx_messageInfo_MavenlinkResponseResults.Size(m)
+func (m *TaskCreated) XXX_Size() int {
+	return xxx_messageInfo_TaskCreated.Size(m)
 }
-func (m *MavenlinkResponseResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_MavenlinkResponseResults.DiscardUnknown(m)
+func (m *TaskCreated) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskCreated.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MavenlinkResponseResults proto.InternalMessageInfo
+var xxx_messageInfo_TaskCreated proto.InternalMessageInfo
 
-func (m *MavenlinkResponseResults) GetKey() string {
+func (m *TaskCreated) GetTask() *Task {
 	if m != nil {
-		return m.Key
+		return m.Task
 	}
-	return ""
+	return nil
 }
 
-func (m *MavenlinkResponseResults) GetId() string {
+func (m *TaskCreated) GetDetectedAt() string {
 	if m != nil {
-		return m.Id
+		return m.DetectedAt
 	}
 	return ""
 }
 
-type MavenlinkWorkspace struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	AccessLevel          string   `protobuf:"bytes,4,opt,name=access_level,json=accessLevel,proto3" json:"access_level,omitempty"`
-	AccountId            int32    `protobuf:"varint,5,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Archived             bool     `protobuf:"varint,6,opt,name=archived,proto3" json:"archived,omitempty"`
-	Currency             string   `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
-	CurrencySymbol       string   `protobuf:"bytes,8,opt,name=currency_symbol,json=currencySymbol,proto3" json:"currency_symbol,omitempty"`
-	DueDate              string   `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	EffectiveDueDate     string   `protobuf:"bytes,10,opt,name=effective_due_date,json=effectiveDueDate,proto3" json:"effective_due_date,omitempty"`
-	StartDate            string   `protobuf:"bytes,11,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	CreatedAt            string   `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+type TaskStateChanged struct {
+	Task                 *Task    `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	PreviousState        string   `protobuf:"bytes,2,opt,name=previous_state,json=previousState,proto3" json:"previous_state,omitempty"`
+	State                string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	DetectedAt           string   `protobuf:"bytes,4,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaskStateChanged) Reset()         { *m = TaskStateChanged{} }
+func (m *TaskStateChanged) String() string { return proto.CompactTextString(m) }
+func (*TaskStateChanged) ProtoMessage()    {}
+func (*TaskStateChanged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{9}
+}
+func (m *TaskStateChanged) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskStateChanged.Unmarshal(m, b)
+}
+func (m *TaskStateChanged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskStateChanged.Marshal(b, m, deterministic)
+}
+func (dst *TaskStateChanged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskStateChanged.Merge(dst, src)
+}
+func (m *TaskStateChanged) XXX_Size() int {
+	return xxx_messageInfo_TaskStateChanged.Size(m)
+}
+func (m *TaskStateChanged) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskStateChanged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskStateChanged proto.InternalMessageInfo
+
+func (m *TaskStateChanged) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *TaskStateChanged) GetPreviousState() string {
+	if m != nil {
+		return m.PreviousState
+	}
+	return ""
+}
+
+func (m *TaskStateChanged) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *TaskStateChanged) GetDetectedAt() string {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return ""
+}
+
+type TimeEntryLogged struct {
+	TimeEntry            *Timeentry `protobuf:"bytes,1,opt,name=time_entry,json=timeEntry,proto3" json:"time_entry,omitempty"`
+	DetectedAt           string     `protobuf:"bytes,2,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *TimeEntryLogged) Reset()         { *m = TimeEntryLogged{} }
+func (m *TimeEntryLogged) String() string { return proto.CompactTextString(m) }
+func (*TimeEntryLogged) ProtoMessage()    {}
+func (*TimeEntryLogged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{10}
+}
+func (m *TimeEntryLogged) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeEntryLogged.Unmarshal(m, b)
+}
+func (m *TimeEntryLogged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeEntryLogged.Marshal(b, m, deterministic)
+}
+func (dst *TimeEntryLogged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeEntryLogged.Merge(dst, src)
+}
+func (m *TimeEntryLogged) XXX_Size() int {
+	return xxx_messageInfo_TimeEntryLogged.Size(m)
+}
+func (m *TimeEntryLogged) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeEntryLogged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeEntryLogged proto.InternalMessageInfo
+
+func (m *TimeEntryLogged) GetTimeEntry() *Timeentry {
+	if m != nil {
+		return m.TimeEntry
+	}
+	return nil
+}
+
+func (m *TimeEntryLogged) GetDetectedAt() string {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return ""
+}
+
+type ProjectArchived struct {
+	Project              *Project `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	DetectedAt           string   `protobuf:"bytes,2,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProjectArchived) Reset()         { *m = ProjectArchived{} }
+func (m *ProjectArchived) String() string { return proto.CompactTextString(m) }
+func (*ProjectArchived) ProtoMessage()    {}
+func (*ProjectArchived) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{11}
+}
+func (m *ProjectArchived) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProjectArchived.Unmarshal(m, b)
+}
+func (m *ProjectArchived) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProjectArchived.Marshal(b, m, deterministic)
+}
+func (dst *ProjectArchived) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProjectArchived.Merge(dst, src)
+}
+func (m *ProjectArchived) XXX_Size() int {
+	return xxx_messageInfo_ProjectArchived.Size(m)
+}
+func (m *ProjectArchived) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProjectArchived.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProjectArchived proto.InternalMessageInfo
+
+func (m *ProjectArchived) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ProjectArchived) GetDetectedAt() string {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return ""
+}
+
+type ResourceChanged struct {
+	EventId              string     `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType            string     `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Resource             string     `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Id                   string     `protobuf:"bytes,4,opt,name=id,proto3" json:"id,omitempty"`
+	WorkspaceId          string     `protobuf:"bytes,5,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	OccurredAt           string     `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Project              *Project   `protobuf:"bytes,7,opt,name=project,proto3" json:"project,omitempty"`
+	Task                 *Task      `protobuf:"bytes,8,opt,name=task,proto3" json:"task,omitempty"`
+	TimeEntry            *Timeentry `protobuf:"bytes,9,opt,name=time_entry,json=timeEntry,proto3" json:"time_entry,omitempty"`
+	User                 *User      `protobuf:"bytes,10,opt,name=user,proto3" json:"user,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ResourceChanged) Reset()         { *m = ResourceChanged{} }
+func (m *ResourceChanged) String() string { return proto.CompactTextString(m) }
+func (*ResourceChanged) ProtoMessage()    {}
+func (*ResourceChanged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{12}
+}
+func (m *ResourceChanged) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceChanged.Unmarshal(m, b)
+}
+func (m *ResourceChanged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceChanged.Marshal(b, m, deterministic)
+}
+func (dst *ResourceChanged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceChanged.Merge(dst, src)
+}
+func (m *ResourceChanged) XXX_Size() int {
+	return xxx_messageInfo_ResourceChanged.Size(m)
+}
+func (m *ResourceChanged) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceChanged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceChanged proto.InternalMessageInfo
+
+func (m *ResourceChanged) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetWorkspaceId() string {
+	if m != nil {
+		return m.WorkspaceId
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetOccurredAt() string {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return ""
+}
+
+func (m *ResourceChanged) GetProject() *Project {
+	if m != nil {
+		return m.Project
+	}
+	return nil
+}
+
+func (m *ResourceChanged) GetTask() *Task {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *ResourceChanged) GetTimeEntry() *Timeentry {
+	if m != nil {
+		return m.TimeEntry
+	}
+	return nil
+}
+
+func (m *ResourceChanged) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+type MavenlinkResponseResults struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MavenlinkResponseResults) Reset()         { *m = MavenlinkResponseResults{} }
+func (m *MavenlinkResponseResults) String() string { return proto.CompactTextString(m) }
+func (*MavenlinkResponseResults) ProtoMessage()    {}
+func (*MavenlinkResponseResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{13}
+}
+func (m *MavenlinkResponseResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MavenlinkResponseResults.Unmarshal(m, b)
+}
+func (m *MavenlinkResponseResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MavenlinkResponseResults.Marshal(b, m, deterministic)
+}
+func (dst *MavenlinkResponseResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MavenlinkResponseResults.Merge(dst, src)
+}
+func (m *MavenlinkResponseResults) XXX_Size() int {
+	return xxx_messageInfo_MavenlinkResponseResults.Size(m)
+}
+func (m *MavenlinkResponseResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_MavenlinkResponseResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MavenlinkResponseResults proto.InternalMessageInfo
+
+func (m *MavenlinkResponseResults) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MavenlinkResponseResults) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type MavenlinkWorkspace struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title                string   `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description          string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	AccessLevel          string   `protobuf:"bytes,4,opt,name=access_level,json=accessLevel,proto3" json:"access_level,omitempty"`
+	AccountId            int32    `protobuf:"varint,5,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Archived             bool     `protobuf:"varint,6,opt,name=archived,proto3" json:"archived,omitempty"`
+	Currency             string   `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	CurrencySymbol       string   `protobuf:"bytes,8,opt,name=currency_symbol,json=currencySymbol,proto3" json:"currency_symbol,omitempty"`
+	DueDate              string   `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	EffectiveDueDate     string   `protobuf:"bytes,10,opt,name=effective_due_date,json=effectiveDueDate,proto3" json:"effective_due_date,omitempty"`
+	StartDate            string   `protobuf:"bytes,11,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt            string   `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -549,7 +1211,7 @@ func (m *MavenlinkWorkspace) Reset()         { *m = MavenlinkWorkspace{} }
 func (m *MavenlinkWorkspace) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkWorkspace) ProtoMessage()    {}
 func (*MavenlinkWorkspace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{5}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{14}
 }
 func (m *MavenlinkWorkspace) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkWorkspace.Unmarshal(m, b)
@@ -685,7 +1347,7 @@ func (m *MavenlinkStory) Reset()         { *m = MavenlinkStory{} }
 func (m *MavenlinkStory) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkStory) ProtoMessage()    {}
 func (*MavenlinkStory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{6}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{15}
 }
 func (m *MavenlinkStory) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkStory.Unmarshal(m, b)
@@ -835,7 +1497,7 @@ func (m *MavenlinkTimeentry) Reset()         { *m = MavenlinkTimeentry{} }
 func (m *MavenlinkTimeentry) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkTimeentry) ProtoMessage()    {}
 func (*MavenlinkTimeentry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{7}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{16}
 }
 func (m *MavenlinkTimeentry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkTimeentry.Unmarshal(m, b)
@@ -979,7 +1641,7 @@ func (m *MavenlinkUser) Reset()         { *m = MavenlinkUser{} }
 func (m *MavenlinkUser) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkUser) ProtoMessage()    {}
 func (*MavenlinkUser) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{8}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{17}
 }
 func (m *MavenlinkUser) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkUser.Unmarshal(m, b)
@@ -1062,84 +1724,178 @@ func (m *MavenlinkUser) GetAccountId() string {
 	return ""
 }
 
-type MavenlinkResponseMeta struct {
-	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	PageCount            int32    `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
-	PageNumber           int32    `protobuf:"varint,3,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
-	Page_Size            int32    `protobuf:"varint,4,opt,name=page_Size,json=pageSize,proto3" json:"page_Size,omitempty"`
+type MavenlinkStoryDependency struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceId             string   `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId             string   `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	DependencyType       string   `protobuf:"bytes,4,opt,name=dependency_type,json=dependencyType,proto3" json:"dependency_type,omitempty"`
+	Lag                  int32    `protobuf:"varint,5,opt,name=lag,proto3" json:"lag,omitempty"`
+	WorkspaceId          string   `protobuf:"bytes,6,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	CreatedAt            string   `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt            string   `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *MavenlinkResponseMeta) Reset()         { *m = MavenlinkResponseMeta{} }
-func (m *MavenlinkResponseMeta) String() string { return proto.CompactTextString(m) }
-func (*MavenlinkResponseMeta) ProtoMessage()    {}
-func (*MavenlinkResponseMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{9}
+func (m *MavenlinkStoryDependency) Reset()         { *m = MavenlinkStoryDependency{} }
+func (m *MavenlinkStoryDependency) String() string { return proto.CompactTextString(m) }
+func (*MavenlinkStoryDependency) ProtoMessage()    {}
+func (*MavenlinkStoryDependency) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{18}
 }
-func (m *MavenlinkResponseMeta) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MavenlinkResponseMeta.Unmarshal(m, b)
+func (m *MavenlinkStoryDependency) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MavenlinkStoryDependency.Unmarshal(m, b)
 }
-func (m *MavenlinkResponseMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MavenlinkResponseMeta.Marshal(b, m, deterministic)
+func (m *MavenlinkStoryDependency) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MavenlinkStoryDependency.Marshal(b, m, deterministic)
 }
-func (dst *MavenlinkResponseMeta) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MavenlinkResponseMeta.Merge(dst, src)
+func (dst *MavenlinkStoryDependency) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MavenlinkStoryDependency.Merge(dst, src)
 }
-func (m *MavenlinkResponseMeta) XXX_Size() int {
-	return xxx_messageInfo_MavenlinkResponseMeta.Size(m)
+func (m *MavenlinkStoryDependency) XXX_Size() int {
+	return xxx_messageInfo_MavenlinkStoryDependency.Size(m)
 }
-func (m *MavenlinkResponseMeta) XXX_DiscardUnknown() {
-	xxx_messageInfo_MavenlinkResponseMeta.DiscardUnknown(m)
+func (m *MavenlinkStoryDependency) XXX_DiscardUnknown() {
+	xxx_messageInfo_MavenlinkStoryDependency.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MavenlinkResponseMeta proto.InternalMessageInfo
+var xxx_messageInfo_MavenlinkStoryDependency proto.InternalMessageInfo
 
-func (m *MavenlinkResponseMeta) GetCount() int32 {
+func (m *MavenlinkStoryDependency) GetId() string {
 	if m != nil {
-		return m.Count
+		return m.Id
 	}
-	return 0
+	return ""
 }
 
-func (m *MavenlinkResponseMeta) GetPageCount() int32 {
+func (m *MavenlinkStoryDependency) GetSourceId() string {
 	if m != nil {
-		return m.PageCount
+		return m.SourceId
 	}
-	return 0
+	return ""
 }
 
-func (m *MavenlinkResponseMeta) GetPageNumber() int32 {
+func (m *MavenlinkStoryDependency) GetTargetId() string {
 	if m != nil {
-		return m.PageNumber
+		return m.TargetId
 	}
-	return 0
+	return ""
 }
 
-func (m *MavenlinkResponseMeta) GetPage_Size() int32 {
+func (m *MavenlinkStoryDependency) GetDependencyType() string {
 	if m != nil {
-		return m.Page_Size
+		return m.DependencyType
 	}
-	return 0
+	return ""
 }
 
-type MavenlinkWorkspacesResponse struct {
-	Count                int32                          `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
-	Meta                 *MavenlinkResponseMeta         `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
-	Results              []*MavenlinkResponseResults    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
-	Workspaces           map[string]*MavenlinkWorkspace `protobuf:"bytes,4,rep,name=workspaces,proto3" json:"workspaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Stories              map[string]*MavenlinkStory     `protobuf:"bytes,5,rep,name=stories,proto3" json:"stories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
-	XXX_unrecognized     []byte                         `json:"-"`
-	XXX_sizecache        int32                          `json:"-"`
+func (m *MavenlinkStoryDependency) GetLag() int32 {
+	if m != nil {
+		return m.Lag
+	}
+	return 0
 }
 
-func (m *MavenlinkWorkspacesResponse) Reset()         { *m = MavenlinkWorkspacesResponse{} }
-func (m *MavenlinkWorkspacesResponse) String() string { return proto.CompactTextString(m) }
+func (m *MavenlinkStoryDependency) GetWorkspaceId() string {
+	if m != nil {
+		return m.WorkspaceId
+	}
+	return ""
+}
+
+func (m *MavenlinkStoryDependency) GetCreatedAt() string {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return ""
+}
+
+func (m *MavenlinkStoryDependency) GetUpdatedAt() string {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return ""
+}
+
+type MavenlinkResponseMeta struct {
+	Count                int32    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	PageCount            int32    `protobuf:"varint,2,opt,name=page_count,json=pageCount,proto3" json:"page_count,omitempty"`
+	PageNumber           int32    `protobuf:"varint,3,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	Page_Size            int32    `protobuf:"varint,4,opt,name=page_Size,json=pageSize,proto3" json:"page_Size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MavenlinkResponseMeta) Reset()         { *m = MavenlinkResponseMeta{} }
+func (m *MavenlinkResponseMeta) String() string { return proto.CompactTextString(m) }
+func (*MavenlinkResponseMeta) ProtoMessage()    {}
+func (*MavenlinkResponseMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{19}
+}
+func (m *MavenlinkResponseMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MavenlinkResponseMeta.Unmarshal(m, b)
+}
+func (m *MavenlinkResponseMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MavenlinkResponseMeta.Marshal(b, m, deterministic)
+}
+func (dst *MavenlinkResponseMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MavenlinkResponseMeta.Merge(dst, src)
+}
+func (m *MavenlinkResponseMeta) XXX_Size() int {
+	return xxx_messageInfo_MavenlinkResponseMeta.Size(m)
+}
+func (m *MavenlinkResponseMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_MavenlinkResponseMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MavenlinkResponseMeta proto.InternalMessageInfo
+
+func (m *MavenlinkResponseMeta) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *MavenlinkResponseMeta) GetPageCount() int32 {
+	if m != nil {
+		return m.PageCount
+	}
+	return 0
+}
+
+func (m *MavenlinkResponseMeta) GetPageNumber() int32 {
+	if m != nil {
+		return m.PageNumber
+	}
+	return 0
+}
+
+func (m *MavenlinkResponseMeta) GetPage_Size() int32 {
+	if m != nil {
+		return m.Page_Size
+	}
+	return 0
+}
+
+type MavenlinkWorkspacesResponse struct {
+	Count                int32                          `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Meta                 *MavenlinkResponseMeta         `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	Results              []*MavenlinkResponseResults    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	Workspaces           map[string]*MavenlinkWorkspace `protobuf:"bytes,4,rep,name=workspaces,proto3" json:"workspaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Stories              map[string]*MavenlinkStory     `protobuf:"bytes,5,rep,name=stories,proto3" json:"stories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *MavenlinkWorkspacesResponse) Reset()         { *m = MavenlinkWorkspacesResponse{} }
+func (m *MavenlinkWorkspacesResponse) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkWorkspacesResponse) ProtoMessage()    {}
 func (*MavenlinkWorkspacesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{10}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{20}
 }
 func (m *MavenlinkWorkspacesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkWorkspacesResponse.Unmarshal(m, b)
@@ -1199,6 +1955,7 @@ type MavenlinkStoriesResponse struct {
 	Meta                 *MavenlinkResponseMeta      `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
 	Results              []*MavenlinkResponseResults `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
 	Stories              map[string]*MavenlinkStory  `protobuf:"bytes,4,rep,name=stories,proto3" json:"stories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Users                map[string]*MavenlinkUser   `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
 	XXX_unrecognized     []byte                      `json:"-"`
 	XXX_sizecache        int32                       `json:"-"`
@@ -1208,7 +1965,7 @@ func (m *MavenlinkStoriesResponse) Reset()         { *m = MavenlinkStoriesRespon
 func (m *MavenlinkStoriesResponse) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkStoriesResponse) ProtoMessage()    {}
 func (*MavenlinkStoriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{11}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{21}
 }
 func (m *MavenlinkStoriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkStoriesResponse.Unmarshal(m, b)
@@ -1256,11 +2013,19 @@ func (m *MavenlinkStoriesResponse) GetStories() map[string]*MavenlinkStory {
 	return nil
 }
 
+func (m *MavenlinkStoriesResponse) GetUsers() map[string]*MavenlinkUser {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
 type MavenlinkTimeEntriesResponse struct {
 	Count                int32                          `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	Meta                 *MavenlinkResponseMeta         `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
 	Results              []*MavenlinkResponseResults    `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
 	TimeEntries          map[string]*MavenlinkTimeentry `protobuf:"bytes,4,rep,name=time_entries,json=timeEntries,proto3" json:"time_entries,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Users                map[string]*MavenlinkUser      `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
 	XXX_unrecognized     []byte                         `json:"-"`
 	XXX_sizecache        int32                          `json:"-"`
@@ -1270,7 +2035,7 @@ func (m *MavenlinkTimeEntriesResponse) Reset()         { *m = MavenlinkTimeEntri
 func (m *MavenlinkTimeEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkTimeEntriesResponse) ProtoMessage()    {}
 func (*MavenlinkTimeEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{12}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{22}
 }
 func (m *MavenlinkTimeEntriesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkTimeEntriesResponse.Unmarshal(m, b)
@@ -1318,6 +2083,13 @@ func (m *MavenlinkTimeEntriesResponse) GetTimeEntries() map[string]*MavenlinkTim
 	return nil
 }
 
+func (m *MavenlinkTimeEntriesResponse) GetUsers() map[string]*MavenlinkUser {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
 type MavenlinkUsersResponse struct {
 	Count                int32                       `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 	Meta                 *MavenlinkResponseMeta      `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
@@ -1332,7 +2104,7 @@ func (m *MavenlinkUsersResponse) Reset()         { *m = MavenlinkUsersResponse{}
 func (m *MavenlinkUsersResponse) String() string { return proto.CompactTextString(m) }
 func (*MavenlinkUsersResponse) ProtoMessage()    {}
 func (*MavenlinkUsersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{13}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{23}
 }
 func (m *MavenlinkUsersResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MavenlinkUsersResponse.Unmarshal(m, b)
@@ -1380,6 +2152,68 @@ func (m *MavenlinkUsersResponse) GetUsers() map[string]*MavenlinkUser {
 	return nil
 }
 
+type MavenlinkStoryDependenciesResponse struct {
+	Count                int32                                `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Meta                 *MavenlinkResponseMeta               `protobuf:"bytes,2,opt,name=meta,proto3" json:"meta,omitempty"`
+	Results              []*MavenlinkResponseResults          `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	StoryDependencies    map[string]*MavenlinkStoryDependency `protobuf:"bytes,4,rep,name=story_dependencies,json=storyDependencies,proto3" json:"story_dependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *MavenlinkStoryDependenciesResponse) Reset()         { *m = MavenlinkStoryDependenciesResponse{} }
+func (m *MavenlinkStoryDependenciesResponse) String() string { return proto.CompactTextString(m) }
+func (*MavenlinkStoryDependenciesResponse) ProtoMessage()    {}
+func (*MavenlinkStoryDependenciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{24}
+}
+func (m *MavenlinkStoryDependenciesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MavenlinkStoryDependenciesResponse.Unmarshal(m, b)
+}
+func (m *MavenlinkStoryDependenciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MavenlinkStoryDependenciesResponse.Marshal(b, m, deterministic)
+}
+func (dst *MavenlinkStoryDependenciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MavenlinkStoryDependenciesResponse.Merge(dst, src)
+}
+func (m *MavenlinkStoryDependenciesResponse) XXX_Size() int {
+	return xxx_messageInfo_MavenlinkStoryDependenciesResponse.Size(m)
+}
+func (m *MavenlinkStoryDependenciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MavenlinkStoryDependenciesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MavenlinkStoryDependenciesResponse proto.InternalMessageInfo
+
+func (m *MavenlinkStoryDependenciesResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *MavenlinkStoryDependenciesResponse) GetMeta() *MavenlinkResponseMeta {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *MavenlinkStoryDependenciesResponse) GetResults() []*MavenlinkResponseResults {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+func (m *MavenlinkStoryDependenciesResponse) GetStoryDependencies() map[string]*MavenlinkStoryDependency {
+	if m != nil {
+		return m.StoryDependencies
+	}
+	return nil
+}
+
 type Error struct {
 	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
@@ -1392,7 +2226,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{14}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{25}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1432,6 +2266,11 @@ type Request struct {
 	Task                 string   `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
 	SubTask              string   `protobuf:"bytes,4,opt,name=subTask,proto3" json:"subTask,omitempty"`
 	IssueTask            string   `protobuf:"bytes,5,opt,name=issueTask,proto3" json:"issueTask,omitempty"`
+	IncludeLoggedTime    bool     `protobuf:"varint,6,opt,name=includeLoggedTime,proto3" json:"includeLoggedTime,omitempty"`
+	PerPage              int32    `protobuf:"varint,7,opt,name=perPage,proto3" json:"perPage,omitempty"`
+	Ids                  []string `protobuf:"bytes,8,rep,name=ids,proto3" json:"ids,omitempty"`
+	BypassCache          bool     `protobuf:"varint,9,opt,name=bypassCache,proto3" json:"bypassCache,omitempty"`
+	IncludeDependencies  bool     `protobuf:"varint,10,opt,name=includeDependencies,proto3" json:"includeDependencies,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1441,7 +2280,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{15}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{26}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Request.Unmarshal(m, b)
@@ -1496,26 +2335,68 @@ func (m *Request) GetIssueTask() string {
 	return ""
 }
 
+func (m *Request) GetIncludeLoggedTime() bool {
+	if m != nil {
+		return m.IncludeLoggedTime
+	}
+	return false
+}
+
+func (m *Request) GetPerPage() int32 {
+	if m != nil {
+		return m.PerPage
+	}
+	return 0
+}
+
+func (m *Request) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *Request) GetBypassCache() bool {
+	if m != nil {
+		return m.BypassCache
+	}
+	return false
+}
+
+func (m *Request) GetIncludeDependencies() bool {
+	if m != nil {
+		return m.IncludeDependencies
+	}
+	return false
+}
+
 type Response struct {
-	Project              *Project     `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
-	Projects             []*Project   `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
-	Task                 *Task        `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
-	Tasks                []*Task      `protobuf:"bytes,5,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	Timeentry            *Timeentry   `protobuf:"bytes,6,opt,name=timeentry,proto3" json:"timeentry,omitempty"`
-	Timeentries          []*Timeentry `protobuf:"bytes,7,rep,name=timeentries,proto3" json:"timeentries,omitempty"`
-	User                 *User        `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
-	Users                []*User      `protobuf:"bytes,9,rep,name=users,proto3" json:"users,omitempty"`
-	Error                *Error       `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Project              *Project              `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	Projects             []*Project            `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+	Task                 *Task                 `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"`
+	Tasks                []*Task               `protobuf:"bytes,5,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Timeentry            *Timeentry            `protobuf:"bytes,6,opt,name=timeentry,proto3" json:"timeentry,omitempty"`
+	Timeentries          []*Timeentry          `protobuf:"bytes,7,rep,name=timeentries,proto3" json:"timeentries,omitempty"`
+	User                 *User                 `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
+	Users                []*User               `protobuf:"bytes,9,rep,name=users,proto3" json:"users,omitempty"`
+	Error                *Error                `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	CriticalPath         *CriticalPath         `protobuf:"bytes,11,opt,name=criticalPath,proto3" json:"criticalPath,omitempty"`
+	TaskTree             []*TaskNode           `protobuf:"bytes,12,rep,name=taskTree,proto3" json:"taskTree,omitempty"`
+	ProjectsById         map[string]*Project   `protobuf:"bytes,13,rep,name=projectsById,proto3" json:"projectsById,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TasksById            map[string]*Task      `protobuf:"bytes,14,rep,name=tasksById,proto3" json:"tasksById,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UsersById            map[string]*User      `protobuf:"bytes,15,rep,name=usersById,proto3" json:"usersById,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TimeentriesById      map[string]*Timeentry `protobuf:"bytes,16,rep,name=timeentriesById,proto3" json:"timeentriesById,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	NotFound             []string              `protobuf:"bytes,17,rep,name=notFound,proto3" json:"notFound,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{16}
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{27}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -1598,84 +2479,844 @@ func (m *Response) GetError() *Error {
 	return nil
 }
 
-type EnvironmentConfiguration struct {
-	Debug                bool     `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
-	Url                  string   `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
-	Token                string   `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+func (m *Response) GetCriticalPath() *CriticalPath {
+	if m != nil {
+		return m.CriticalPath
+	}
+	return nil
+}
+
+func (m *Response) GetTaskTree() []*TaskNode {
+	if m != nil {
+		return m.TaskTree
+	}
+	return nil
+}
+
+func (m *Response) GetProjectsById() map[string]*Project {
+	if m != nil {
+		return m.ProjectsById
+	}
+	return nil
+}
+
+func (m *Response) GetTasksById() map[string]*Task {
+	if m != nil {
+		return m.TasksById
+	}
+	return nil
+}
+
+func (m *Response) GetUsersById() map[string]*User {
+	if m != nil {
+		return m.UsersById
+	}
+	return nil
+}
+
+func (m *Response) GetTimeentriesById() map[string]*Timeentry {
+	if m != nil {
+		return m.TimeentriesById
+	}
+	return nil
+}
+
+func (m *Response) GetNotFound() []string {
+	if m != nil {
+		return m.NotFound
+	}
+	return nil
+}
+
+type HealthRequest struct {
+	Probe                bool     `protobuf:"varint,1,opt,name=probe,proto3" json:"probe,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EnvironmentConfiguration) Reset()         { *m = EnvironmentConfiguration{} }
-func (m *EnvironmentConfiguration) String() string { return proto.CompactTextString(m) }
-func (*EnvironmentConfiguration) ProtoMessage()    {}
-func (*EnvironmentConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_mavenlink_communicator_a9af29e9786aea96, []int{17}
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{28}
 }
-func (m *EnvironmentConfiguration) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EnvironmentConfiguration.Unmarshal(m, b)
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
 }
-func (m *EnvironmentConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EnvironmentConfiguration.Marshal(b, m, deterministic)
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
 }
-func (dst *EnvironmentConfiguration) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EnvironmentConfiguration.Merge(dst, src)
+func (dst *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(dst, src)
 }
-func (m *EnvironmentConfiguration) XXX_Size() int {
-	return xxx_messageInfo_EnvironmentConfiguration.Size(m)
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
 }
-func (m *EnvironmentConfiguration) XXX_DiscardUnknown() {
-	xxx_messageInfo_EnvironmentConfiguration.DiscardUnknown(m)
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EnvironmentConfiguration proto.InternalMessageInfo
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
 
-func (m *EnvironmentConfiguration) GetDebug() bool {
+func (m *HealthRequest) GetProbe() bool {
 	if m != nil {
-		return m.Debug
+		return m.Probe
 	}
 	return false
 }
 
-func (m *EnvironmentConfiguration) GetUrl() string {
+type HealthCheck struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               string   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Detail               string   `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthCheck) Reset()         { *m = HealthCheck{} }
+func (m *HealthCheck) String() string { return proto.CompactTextString(m) }
+func (*HealthCheck) ProtoMessage()    {}
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{29}
+}
+func (m *HealthCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthCheck.Unmarshal(m, b)
+}
+func (m *HealthCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthCheck.Marshal(b, m, deterministic)
+}
+func (dst *HealthCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthCheck.Merge(dst, src)
+}
+func (m *HealthCheck) XXX_Size() int {
+	return xxx_messageInfo_HealthCheck.Size(m)
+}
+func (m *HealthCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthCheck proto.InternalMessageInfo
+
+func (m *HealthCheck) GetName() string {
 	if m != nil {
-		return m.Url
+		return m.Name
 	}
 	return ""
 }
 
-func (m *EnvironmentConfiguration) GetToken() string {
+func (m *HealthCheck) GetStatus() string {
 	if m != nil {
-		return m.Token
+		return m.Status
 	}
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Project)(nil), "costrategix.service.mavenlink.communicator.Project")
-	proto.RegisterType((*Task)(nil), "costrategix.service.mavenlink.communicator.Task")
-	proto.RegisterType((*Timeentry)(nil), "costrategix.service.mavenlink.communicator.Timeentry")
-	proto.RegisterType((*User)(nil), "costrategix.service.mavenlink.communicator.User")
-	proto.RegisterType((*MavenlinkResponseResults)(nil), "costrategix.service.mavenlink.communicator.MavenlinkResponseResults")
-	proto.RegisterType((*MavenlinkWorkspace)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspace")
-	proto.RegisterType((*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStory")
-	proto.RegisterType((*MavenlinkTimeentry)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeentry")
-	proto.RegisterType((*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUser")
-	proto.RegisterType((*MavenlinkResponseMeta)(nil), "costrategix.service.mavenlink.communicator.MavenlinkResponseMeta")
-	proto.RegisterType((*MavenlinkWorkspacesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse")
-	proto.RegisterMapType((map[string]*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse.StoriesEntry")
-	proto.RegisterMapType((map[string]*MavenlinkWorkspace)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse.WorkspacesEntry")
-	proto.RegisterType((*MavenlinkStoriesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoriesResponse")
-	proto.RegisterMapType((map[string]*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoriesResponse.StoriesEntry")
-	proto.RegisterType((*MavenlinkTimeEntriesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeEntriesResponse")
-	proto.RegisterMapType((map[string]*MavenlinkTimeentry)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeEntriesResponse.TimeEntriesEntry")
-	proto.RegisterType((*MavenlinkUsersResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUsersResponse")
-	proto.RegisterMapType((map[string]*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUsersResponse.UsersEntry")
-	proto.RegisterType((*Error)(nil), "costrategix.service.mavenlink.communicator.Error")
-	proto.RegisterType((*Request)(nil), "costrategix.service.mavenlink.communicator.Request")
-	proto.RegisterType((*Response)(nil), "costrategix.service.mavenlink.communicator.Response")
-	proto.RegisterType((*EnvironmentConfiguration)(nil), "costrategix.service.mavenlink.communicator.EnvironmentConfiguration")
+func (m *HealthCheck) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type CacheStatus struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Hits                 uint64   `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	Misses               uint64   `protobuf:"varint,3,opt,name=misses,proto3" json:"misses,omitempty"`
+	Entries              int32    `protobuf:"varint,4,opt,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CacheStatus) Reset()         { *m = CacheStatus{} }
+func (m *CacheStatus) String() string { return proto.CompactTextString(m) }
+func (*CacheStatus) ProtoMessage()    {}
+func (*CacheStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{30}
+}
+func (m *CacheStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CacheStatus.Unmarshal(m, b)
+}
+func (m *CacheStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CacheStatus.Marshal(b, m, deterministic)
+}
+func (dst *CacheStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CacheStatus.Merge(dst, src)
+}
+func (m *CacheStatus) XXX_Size() int {
+	return xxx_messageInfo_CacheStatus.Size(m)
+}
+func (m *CacheStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_CacheStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CacheStatus proto.InternalMessageInfo
+
+func (m *CacheStatus) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *CacheStatus) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetMisses() uint64 {
+	if m != nil {
+		return m.Misses
+	}
+	return 0
+}
+
+func (m *CacheStatus) GetEntries() int32 {
+	if m != nil {
+		return m.Entries
+	}
+	return 0
+}
+
+type SyncStatus struct {
+	Enabled              bool     `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	LastSync             string   `protobuf:"bytes,2,opt,name=lastSync,proto3" json:"lastSync,omitempty"`
+	LastFullSync         string   `protobuf:"bytes,3,opt,name=lastFullSync,proto3" json:"lastFullSync,omitempty"`
+	Stale                bool     `protobuf:"varint,4,opt,name=stale,proto3" json:"stale,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
+func (m *SyncStatus) String() string { return proto.CompactTextString(m) }
+func (*SyncStatus) ProtoMessage()    {}
+func (*SyncStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{31}
+}
+func (m *SyncStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStatus.Unmarshal(m, b)
+}
+func (m *SyncStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStatus.Marshal(b, m, deterministic)
+}
+func (dst *SyncStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStatus.Merge(dst, src)
+}
+func (m *SyncStatus) XXX_Size() int {
+	return xxx_messageInfo_SyncStatus.Size(m)
+}
+func (m *SyncStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStatus proto.InternalMessageInfo
+
+func (m *SyncStatus) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *SyncStatus) GetLastSync() string {
+	if m != nil {
+		return m.LastSync
+	}
+	return ""
+}
+
+func (m *SyncStatus) GetLastFullSync() string {
+	if m != nil {
+		return m.LastFullSync
+	}
+	return ""
+}
+
+func (m *SyncStatus) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+type HealthResponse struct {
+	Status               string         `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Checks               []*HealthCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+	Cache                *CacheStatus   `protobuf:"bytes,3,opt,name=cache,proto3" json:"cache,omitempty"`
+	Sync                 *SyncStatus    `protobuf:"bytes,4,opt,name=sync,proto3" json:"sync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{32}
+}
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (dst *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(dst, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *HealthResponse) GetChecks() []*HealthCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+func (m *HealthResponse) GetCache() *CacheStatus {
+	if m != nil {
+		return m.Cache
+	}
+	return nil
+}
+
+func (m *HealthResponse) GetSync() *SyncStatus {
+	if m != nil {
+		return m.Sync
+	}
+	return nil
+}
+
+type EnvironmentConfiguration struct {
+	Debug                  bool              `protobuf:"varint,1,opt,name=debug,proto3" json:"debug,omitempty"`
+	Url                    string            `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Token                  string            `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	CacheDisabled          bool              `protobuf:"varint,4,opt,name=cache_disabled,json=cacheDisabled,proto3" json:"cache_disabled,omitempty"`
+	CacheSize              int32             `protobuf:"varint,5,opt,name=cache_size,json=cacheSize,proto3" json:"cache_size,omitempty"`
+	CacheProjectsTtl       int32             `protobuf:"varint,6,opt,name=cache_projects_ttl,json=cacheProjectsTtl,proto3" json:"cache_projects_ttl,omitempty"`
+	CacheTasksTtl          int32             `protobuf:"varint,7,opt,name=cache_tasks_ttl,json=cacheTasksTtl,proto3" json:"cache_tasks_ttl,omitempty"`
+	CacheUsersTtl          int32             `protobuf:"varint,8,opt,name=cache_users_ttl,json=cacheUsersTtl,proto3" json:"cache_users_ttl,omitempty"`
+	CacheTimeentriesTtl    int32             `protobuf:"varint,9,opt,name=cache_timeentries_ttl,json=cacheTimeentriesTtl,proto3" json:"cache_timeentries_ttl,omitempty"`
+	MirrorEnabled          bool              `protobuf:"varint,10,opt,name=mirror_enabled,json=mirrorEnabled,proto3" json:"mirror_enabled,omitempty"`
+	MirrorPath             string            `protobuf:"bytes,11,opt,name=mirror_path,json=mirrorPath,proto3" json:"mirror_path,omitempty"`
+	MirrorInterval         int32             `protobuf:"varint,12,opt,name=mirror_interval,json=mirrorInterval,proto3" json:"mirror_interval,omitempty"`
+	MirrorStaleness        int32             `protobuf:"varint,13,opt,name=mirror_staleness,json=mirrorStaleness,proto3" json:"mirror_staleness,omitempty"`
+	MirrorFullSyncInterval int32             `protobuf:"varint,14,opt,name=mirror_full_sync_interval,json=mirrorFullSyncInterval,proto3" json:"mirror_full_sync_interval,omitempty"`
+	EventsEnabled          bool              `protobuf:"varint,15,opt,name=events_enabled,json=eventsEnabled,proto3" json:"events_enabled,omitempty"`
+	WebhookEnabled         bool              `protobuf:"varint,16,opt,name=webhook_enabled,json=webhookEnabled,proto3" json:"webhook_enabled,omitempty"`
+	WebhookAddress         string            `protobuf:"bytes,17,opt,name=webhook_address,json=webhookAddress,proto3" json:"webhook_address,omitempty"`
+	WebhookSecret          string            `protobuf:"bytes,18,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`
+	GatewayEnabled         bool              `protobuf:"varint,19,opt,name=gateway_enabled,json=gatewayEnabled,proto3" json:"gateway_enabled,omitempty"`
+	GatewayAddress         string            `protobuf:"bytes,20,opt,name=gateway_address,json=gatewayAddress,proto3" json:"gateway_address,omitempty"`
+	GraphqlEnabled         bool              `protobuf:"varint,21,opt,name=graphql_enabled,json=graphqlEnabled,proto3" json:"graphql_enabled,omitempty"`
+	GraphqlAddress         string            `protobuf:"bytes,22,opt,name=graphql_address,json=graphqlAddress,proto3" json:"graphql_address,omitempty"`
+	TenantTokens           map[string]string `protobuf:"bytes,23,rep,name=tenant_tokens,json=tenantTokens,proto3" json:"tenant_tokens,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	TenantRequired         bool              `protobuf:"varint,24,opt,name=tenant_required,json=tenantRequired,proto3" json:"tenant_required,omitempty"`
+	OauthEnabled           bool              `protobuf:"varint,25,opt,name=oauth_enabled,json=oauthEnabled,proto3" json:"oauth_enabled,omitempty"`
+	OauthUrl               string            `protobuf:"bytes,26,opt,name=oauth_url,json=oauthUrl,proto3" json:"oauth_url,omitempty"`
+	OauthClientId          string            `protobuf:"bytes,27,opt,name=oauth_client_id,json=oauthClientId,proto3" json:"oauth_client_id,omitempty"`
+	OauthClientSecret      string            `protobuf:"bytes,28,opt,name=oauth_client_secret,json=oauthClientSecret,proto3" json:"oauth_client_secret,omitempty"`
+	OauthRedirectUrl       string            `protobuf:"bytes,29,opt,name=oauth_redirect_url,json=oauthRedirectUrl,proto3" json:"oauth_redirect_url,omitempty"`
+	OauthAddress           string            `protobuf:"bytes,30,opt,name=oauth_address,json=oauthAddress,proto3" json:"oauth_address,omitempty"`
+	OauthTokenPath         string            `protobuf:"bytes,31,opt,name=oauth_token_path,json=oauthTokenPath,proto3" json:"oauth_token_path,omitempty"`
+	OauthEncryptionKey     string            `protobuf:"bytes,32,opt,name=oauth_encryption_key,json=oauthEncryptionKey,proto3" json:"oauth_encryption_key,omitempty"`
+	AuthEnabled            bool              `protobuf:"varint,33,opt,name=auth_enabled,json=authEnabled,proto3" json:"auth_enabled,omitempty"`
+	AuthJwtSecret          string            `protobuf:"bytes,34,opt,name=auth_jwt_secret,json=authJwtSecret,proto3" json:"auth_jwt_secret,omitempty"`
+	AuthJwtIssuer          string            `protobuf:"bytes,35,opt,name=auth_jwt_issuer,json=authJwtIssuer,proto3" json:"auth_jwt_issuer,omitempty"`
+	AuthJwtAudience        string            `protobuf:"bytes,36,opt,name=auth_jwt_audience,json=authJwtAudience,proto3" json:"auth_jwt_audience,omitempty"`
+	AuthApiKeys            map[string]string `protobuf:"bytes,37,rep,name=auth_api_keys,json=authApiKeys,proto3" json:"auth_api_keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	AuthPolicyPath         string            `protobuf:"bytes,38,opt,name=auth_policy_path,json=authPolicyPath,proto3" json:"auth_policy_path,omitempty"`
+	LogLevel               string            `protobuf:"bytes,39,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	MetricsEnabled         bool              `protobuf:"varint,40,opt,name=metrics_enabled,json=metricsEnabled,proto3" json:"metrics_enabled,omitempty"`
+	MetricsAddress         string            `protobuf:"bytes,41,opt,name=metrics_address,json=metricsAddress,proto3" json:"metrics_address,omitempty"`
+	TraceExporter          string            `protobuf:"bytes,42,opt,name=trace_exporter,json=traceExporter,proto3" json:"trace_exporter,omitempty"`
+	TraceOtlpEndpoint      string            `protobuf:"bytes,43,opt,name=trace_otlp_endpoint,json=traceOtlpEndpoint,proto3" json:"trace_otlp_endpoint,omitempty"`
+	HealthEnabled          bool              `protobuf:"varint,44,opt,name=health_enabled,json=healthEnabled,proto3" json:"health_enabled,omitempty"`
+	HealthAddress          string            `protobuf:"bytes,45,opt,name=health_address,json=healthAddress,proto3" json:"health_address,omitempty"`
+	HealthProbe            bool              `protobuf:"varint,46,opt,name=health_probe,json=healthProbe,proto3" json:"health_probe,omitempty"`
+	ConfigWatchInterval    int32             `protobuf:"varint,47,opt,name=config_watch_interval,json=configWatchInterval,proto3" json:"config_watch_interval,omitempty"`
+	RequestTimeout         int32             `protobuf:"varint,48,opt,name=request_timeout,json=requestTimeout,proto3" json:"request_timeout,omitempty"`
+	HttpMode               string            `protobuf:"bytes,49,opt,name=http_mode,json=httpMode,proto3" json:"http_mode,omitempty"`
+	CassetteDir            string            `protobuf:"bytes,50,opt,name=cassette_dir,json=cassetteDir,proto3" json:"cassette_dir,omitempty"`
+	MemoryEnabled          bool              `protobuf:"varint,51,opt,name=memory_enabled,json=memoryEnabled,proto3" json:"memory_enabled,omitempty"`
+	MemorySeedDir          string            `protobuf:"bytes,52,opt,name=memory_seed_dir,json=memorySeedDir,proto3" json:"memory_seed_dir,omitempty"`
+	MemoryAddress          string            `protobuf:"bytes,53,opt,name=memory_address,json=memoryAddress,proto3" json:"memory_address,omitempty"`
+	OauthAdminKey          string            `protobuf:"bytes,54,opt,name=oauth_admin_key,json=oauthAdminKey,proto3" json:"oauth_admin_key,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}          `json:"-"`
+	XXX_unrecognized       []byte            `json:"-"`
+	XXX_sizecache          int32             `json:"-"`
+}
+
+func (m *EnvironmentConfiguration) Reset()         { *m = EnvironmentConfiguration{} }
+func (m *EnvironmentConfiguration) String() string { return proto.CompactTextString(m) }
+func (*EnvironmentConfiguration) ProtoMessage()    {}
+func (*EnvironmentConfiguration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_mavenlink_communicator_b00a811418c7678f, []int{33}
+}
+func (m *EnvironmentConfiguration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EnvironmentConfiguration.Unmarshal(m, b)
+}
+func (m *EnvironmentConfiguration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EnvironmentConfiguration.Marshal(b, m, deterministic)
+}
+func (dst *EnvironmentConfiguration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EnvironmentConfiguration.Merge(dst, src)
+}
+func (m *EnvironmentConfiguration) XXX_Size() int {
+	return xxx_messageInfo_EnvironmentConfiguration.Size(m)
+}
+func (m *EnvironmentConfiguration) XXX_DiscardUnknown() {
+	xxx_messageInfo_EnvironmentConfiguration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EnvironmentConfiguration proto.InternalMessageInfo
+
+func (m *EnvironmentConfiguration) GetDebug() bool {
+	if m != nil {
+		return m.Debug
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetCacheDisabled() bool {
+	if m != nil {
+		return m.CacheDisabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetCacheSize() int32 {
+	if m != nil {
+		return m.CacheSize
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetCacheProjectsTtl() int32 {
+	if m != nil {
+		return m.CacheProjectsTtl
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetCacheTasksTtl() int32 {
+	if m != nil {
+		return m.CacheTasksTtl
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetCacheUsersTtl() int32 {
+	if m != nil {
+		return m.CacheUsersTtl
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetCacheTimeentriesTtl() int32 {
+	if m != nil {
+		return m.CacheTimeentriesTtl
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetMirrorEnabled() bool {
+	if m != nil {
+		return m.MirrorEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetMirrorPath() string {
+	if m != nil {
+		return m.MirrorPath
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetMirrorInterval() int32 {
+	if m != nil {
+		return m.MirrorInterval
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetMirrorStaleness() int32 {
+	if m != nil {
+		return m.MirrorStaleness
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetMirrorFullSyncInterval() int32 {
+	if m != nil {
+		return m.MirrorFullSyncInterval
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetEventsEnabled() bool {
+	if m != nil {
+		return m.EventsEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetWebhookEnabled() bool {
+	if m != nil {
+		return m.WebhookEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetWebhookAddress() string {
+	if m != nil {
+		return m.WebhookAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetWebhookSecret() string {
+	if m != nil {
+		return m.WebhookSecret
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetGatewayEnabled() bool {
+	if m != nil {
+		return m.GatewayEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetGatewayAddress() string {
+	if m != nil {
+		return m.GatewayAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetGraphqlEnabled() bool {
+	if m != nil {
+		return m.GraphqlEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetGraphqlAddress() string {
+	if m != nil {
+		return m.GraphqlAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetTenantTokens() map[string]string {
+	if m != nil {
+		return m.TenantTokens
+	}
+	return nil
+}
+
+func (m *EnvironmentConfiguration) GetTenantRequired() bool {
+	if m != nil {
+		return m.TenantRequired
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetOauthEnabled() bool {
+	if m != nil {
+		return m.OauthEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetOauthUrl() string {
+	if m != nil {
+		return m.OauthUrl
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthClientId() string {
+	if m != nil {
+		return m.OauthClientId
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthClientSecret() string {
+	if m != nil {
+		return m.OauthClientSecret
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthRedirectUrl() string {
+	if m != nil {
+		return m.OauthRedirectUrl
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthAddress() string {
+	if m != nil {
+		return m.OauthAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthTokenPath() string {
+	if m != nil {
+		return m.OauthTokenPath
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthEncryptionKey() string {
+	if m != nil {
+		return m.OauthEncryptionKey
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetAuthEnabled() bool {
+	if m != nil {
+		return m.AuthEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetAuthJwtSecret() string {
+	if m != nil {
+		return m.AuthJwtSecret
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetAuthJwtIssuer() string {
+	if m != nil {
+		return m.AuthJwtIssuer
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetAuthJwtAudience() string {
+	if m != nil {
+		return m.AuthJwtAudience
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetAuthApiKeys() map[string]string {
+	if m != nil {
+		return m.AuthApiKeys
+	}
+	return nil
+}
+
+func (m *EnvironmentConfiguration) GetAuthPolicyPath() string {
+	if m != nil {
+		return m.AuthPolicyPath
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetLogLevel() string {
+	if m != nil {
+		return m.LogLevel
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetMetricsEnabled() bool {
+	if m != nil {
+		return m.MetricsEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetMetricsAddress() string {
+	if m != nil {
+		return m.MetricsAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetTraceExporter() string {
+	if m != nil {
+		return m.TraceExporter
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetTraceOtlpEndpoint() string {
+	if m != nil {
+		return m.TraceOtlpEndpoint
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetHealthEnabled() bool {
+	if m != nil {
+		return m.HealthEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetHealthAddress() string {
+	if m != nil {
+		return m.HealthAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetHealthProbe() bool {
+	if m != nil {
+		return m.HealthProbe
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetConfigWatchInterval() int32 {
+	if m != nil {
+		return m.ConfigWatchInterval
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetRequestTimeout() int32 {
+	if m != nil {
+		return m.RequestTimeout
+	}
+	return 0
+}
+
+func (m *EnvironmentConfiguration) GetHttpMode() string {
+	if m != nil {
+		return m.HttpMode
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetCassetteDir() string {
+	if m != nil {
+		return m.CassetteDir
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetMemoryEnabled() bool {
+	if m != nil {
+		return m.MemoryEnabled
+	}
+	return false
+}
+
+func (m *EnvironmentConfiguration) GetMemorySeedDir() string {
+	if m != nil {
+		return m.MemorySeedDir
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetMemoryAddress() string {
+	if m != nil {
+		return m.MemoryAddress
+	}
+	return ""
+}
+
+func (m *EnvironmentConfiguration) GetOauthAdminKey() string {
+	if m != nil {
+		return m.OauthAdminKey
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Project)(nil), "costrategix.service.mavenlink.communicator.Project")
+	proto.RegisterType((*Task)(nil), "costrategix.service.mavenlink.communicator.Task")
+	proto.RegisterType((*TaskNode)(nil), "costrategix.service.mavenlink.communicator.TaskNode")
+	proto.RegisterType((*TaskDependency)(nil), "costrategix.service.mavenlink.communicator.TaskDependency")
+	proto.RegisterType((*CriticalPathTask)(nil), "costrategix.service.mavenlink.communicator.CriticalPathTask")
+	proto.RegisterType((*CriticalPath)(nil), "costrategix.service.mavenlink.communicator.CriticalPath")
+	proto.RegisterType((*Timeentry)(nil), "costrategix.service.mavenlink.communicator.Timeentry")
+	proto.RegisterType((*User)(nil), "costrategix.service.mavenlink.communicator.User")
+	proto.RegisterType((*TaskCreated)(nil), "costrategix.service.mavenlink.communicator.TaskCreated")
+	proto.RegisterType((*TaskStateChanged)(nil), "costrategix.service.mavenlink.communicator.TaskStateChanged")
+	proto.RegisterType((*TimeEntryLogged)(nil), "costrategix.service.mavenlink.communicator.TimeEntryLogged")
+	proto.RegisterType((*ProjectArchived)(nil), "costrategix.service.mavenlink.communicator.ProjectArchived")
+	proto.RegisterType((*ResourceChanged)(nil), "costrategix.service.mavenlink.communicator.ResourceChanged")
+	proto.RegisterType((*MavenlinkResponseResults)(nil), "costrategix.service.mavenlink.communicator.MavenlinkResponseResults")
+	proto.RegisterType((*MavenlinkWorkspace)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspace")
+	proto.RegisterType((*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStory")
+	proto.RegisterType((*MavenlinkTimeentry)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeentry")
+	proto.RegisterType((*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUser")
+	proto.RegisterType((*MavenlinkStoryDependency)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoryDependency")
+	proto.RegisterType((*MavenlinkResponseMeta)(nil), "costrategix.service.mavenlink.communicator.MavenlinkResponseMeta")
+	proto.RegisterType((*MavenlinkWorkspacesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse")
+	proto.RegisterMapType((map[string]*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse.StoriesEntry")
+	proto.RegisterMapType((map[string]*MavenlinkWorkspace)(nil), "costrategix.service.mavenlink.communicator.MavenlinkWorkspacesResponse.WorkspacesEntry")
+	proto.RegisterType((*MavenlinkStoriesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoriesResponse")
+	proto.RegisterMapType((map[string]*MavenlinkStory)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoriesResponse.StoriesEntry")
+	proto.RegisterMapType((map[string]*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoriesResponse.UsersEntry")
+	proto.RegisterType((*MavenlinkTimeEntriesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeEntriesResponse")
+	proto.RegisterMapType((map[string]*MavenlinkTimeentry)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeEntriesResponse.TimeEntriesEntry")
+	proto.RegisterMapType((map[string]*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkTimeEntriesResponse.UsersEntry")
+	proto.RegisterType((*MavenlinkUsersResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUsersResponse")
+	proto.RegisterMapType((map[string]*MavenlinkUser)(nil), "costrategix.service.mavenlink.communicator.MavenlinkUsersResponse.UsersEntry")
+	proto.RegisterType((*MavenlinkStoryDependenciesResponse)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoryDependenciesResponse")
+	proto.RegisterMapType((map[string]*MavenlinkStoryDependency)(nil), "costrategix.service.mavenlink.communicator.MavenlinkStoryDependenciesResponse.StoryDependenciesEntry")
+	proto.RegisterType((*Error)(nil), "costrategix.service.mavenlink.communicator.Error")
+	proto.RegisterType((*Request)(nil), "costrategix.service.mavenlink.communicator.Request")
+	proto.RegisterType((*Response)(nil), "costrategix.service.mavenlink.communicator.Response")
+	proto.RegisterMapType((map[string]*Project)(nil), "costrategix.service.mavenlink.communicator.Response.ProjectsByIdEntry")
+	proto.RegisterMapType((map[string]*Task)(nil), "costrategix.service.mavenlink.communicator.Response.TasksByIdEntry")
+	proto.RegisterMapType((map[string]*Timeentry)(nil), "costrategix.service.mavenlink.communicator.Response.TimeentriesByIdEntry")
+	proto.RegisterMapType((map[string]*User)(nil), "costrategix.service.mavenlink.communicator.Response.UsersByIdEntry")
+	proto.RegisterType((*HealthRequest)(nil), "costrategix.service.mavenlink.communicator.HealthRequest")
+	proto.RegisterType((*HealthCheck)(nil), "costrategix.service.mavenlink.communicator.HealthCheck")
+	proto.RegisterType((*CacheStatus)(nil), "costrategix.service.mavenlink.communicator.CacheStatus")
+	proto.RegisterType((*SyncStatus)(nil), "costrategix.service.mavenlink.communicator.SyncStatus")
+	proto.RegisterType((*HealthResponse)(nil), "costrategix.service.mavenlink.communicator.HealthResponse")
+	proto.RegisterType((*EnvironmentConfiguration)(nil), "costrategix.service.mavenlink.communicator.EnvironmentConfiguration")
+	proto.RegisterMapType((map[string]string)(nil), "costrategix.service.mavenlink.communicator.EnvironmentConfiguration.AuthApiKeysEntry")
+	proto.RegisterMapType((map[string]string)(nil), "costrategix.service.mavenlink.communicator.EnvironmentConfiguration.TenantTokensEntry")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1694,6 +3335,16 @@ type MavenlinkCommunicatorClient interface {
 	GetTimeentries(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
 	GetUsers(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
 	GetUser(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	GetCriticalPathByProjectId(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	GetTaskTree(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	StreamProjects(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamProjectsClient, error)
+	StreamTasks(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamTasksClient, error)
+	StreamTimeEntries(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamTimeEntriesClient, error)
+	GetProjectsByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	GetTasksByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	GetUsersByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	GetTimeentriesByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...client.CallOption) (*HealthResponse, error)
 }
 
 type mavenlinkCommunicatorClient struct {
@@ -1794,6 +3445,208 @@ func (c *mavenlinkCommunicatorClient) GetUser(ctx context.Context, in *Request,
 	return out, nil
 }
 
+func (c *mavenlinkCommunicatorClient) GetCriticalPathByProjectId(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetCriticalPathByProjectId", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) GetTaskTree(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetTaskTree", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) StreamProjects(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamProjectsClient, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.StreamProjects", &Request{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &mavenlinkCommunicatorStreamProjectsClient{stream}, nil
+}
+
+type MavenlinkCommunicator_StreamProjectsClient interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Project, error)
+}
+
+type mavenlinkCommunicatorStreamProjectsClient struct {
+	stream client.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsClient) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsClient) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsClient) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsClient) Recv() (*Project, error) {
+	m := new(Project)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mavenlinkCommunicatorClient) StreamTasks(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamTasksClient, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.StreamTasks", &Request{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &mavenlinkCommunicatorStreamTasksClient{stream}, nil
+}
+
+type MavenlinkCommunicator_StreamTasksClient interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Task, error)
+}
+
+type mavenlinkCommunicatorStreamTasksClient struct {
+	stream client.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamTasksClient) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamTasksClient) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTasksClient) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTasksClient) Recv() (*Task, error) {
+	m := new(Task)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mavenlinkCommunicatorClient) StreamTimeEntries(ctx context.Context, in *Request, opts ...client.CallOption) (MavenlinkCommunicator_StreamTimeEntriesClient, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.StreamTimeEntries", &Request{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &mavenlinkCommunicatorStreamTimeEntriesClient{stream}, nil
+}
+
+type MavenlinkCommunicator_StreamTimeEntriesClient interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*Timeentry, error)
+}
+
+type mavenlinkCommunicatorStreamTimeEntriesClient struct {
+	stream client.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesClient) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesClient) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesClient) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesClient) Recv() (*Timeentry, error) {
+	m := new(Timeentry)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mavenlinkCommunicatorClient) GetProjectsByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetProjectsByIds", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) GetTasksByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetTasksByIds", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) GetUsersByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetUsersByIds", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) GetTimeentriesByIds(ctx context.Context, in *Request, opts ...client.CallOption) (*Response, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.GetTimeentriesByIds", in)
+	out := new(Response)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mavenlinkCommunicatorClient) Health(ctx context.Context, in *HealthRequest, opts ...client.CallOption) (*HealthResponse, error) {
+	req := c.c.NewRequest(c.serviceName, "MavenlinkCommunicator.Health", in)
+	out := new(HealthResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for MavenlinkCommunicator service
 
 type MavenlinkCommunicatorHandler interface {
@@ -1805,6 +3658,16 @@ type MavenlinkCommunicatorHandler interface {
 	GetTimeentries(context.Context, *Request, *Response) error
 	GetUsers(context.Context, *Request, *Response) error
 	GetUser(context.Context, *Request, *Response) error
+	GetCriticalPathByProjectId(context.Context, *Request, *Response) error
+	GetTaskTree(context.Context, *Request, *Response) error
+	StreamProjects(context.Context, *Request, MavenlinkCommunicator_StreamProjectsStream) error
+	StreamTasks(context.Context, *Request, MavenlinkCommunicator_StreamTasksStream) error
+	StreamTimeEntries(context.Context, *Request, MavenlinkCommunicator_StreamTimeEntriesStream) error
+	GetProjectsByIds(context.Context, *Request, *Response) error
+	GetTasksByIds(context.Context, *Request, *Response) error
+	GetUsersByIds(context.Context, *Request, *Response) error
+	GetTimeentriesByIds(context.Context, *Request, *Response) error
+	Health(context.Context, *HealthRequest, *HealthResponse) error
 }
 
 func RegisterMavenlinkCommunicatorHandler(s server.Server, hdlr MavenlinkCommunicatorHandler, opts ...server.HandlerOption) {
@@ -1847,119 +3710,385 @@ func (h *MavenlinkCommunicator) GetUser(ctx context.Context, in *Request, out *R
 	return h.MavenlinkCommunicatorHandler.GetUser(ctx, in, out)
 }
 
+func (h *MavenlinkCommunicator) GetCriticalPathByProjectId(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetCriticalPathByProjectId(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) GetTaskTree(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetTaskTree(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) StreamProjects(ctx context.Context, stream server.Stream) error {
+	m := new(Request)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.MavenlinkCommunicatorHandler.StreamProjects(ctx, m, &mavenlinkCommunicatorStreamProjectsStream{stream})
+}
+
+type MavenlinkCommunicator_StreamProjectsStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Project) error
+}
+
+type mavenlinkCommunicatorStreamProjectsStream struct {
+	stream server.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamProjectsStream) Send(m *Project) error {
+	return x.stream.Send(m)
+}
+
+func (h *MavenlinkCommunicator) StreamTasks(ctx context.Context, stream server.Stream) error {
+	m := new(Request)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.MavenlinkCommunicatorHandler.StreamTasks(ctx, m, &mavenlinkCommunicatorStreamTasksStream{stream})
+}
+
+type MavenlinkCommunicator_StreamTasksStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Task) error
+}
+
+type mavenlinkCommunicatorStreamTasksStream struct {
+	stream server.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamTasksStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamTasksStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTasksStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTasksStream) Send(m *Task) error {
+	return x.stream.Send(m)
+}
+
+func (h *MavenlinkCommunicator) StreamTimeEntries(ctx context.Context, stream server.Stream) error {
+	m := new(Request)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.MavenlinkCommunicatorHandler.StreamTimeEntries(ctx, m, &mavenlinkCommunicatorStreamTimeEntriesStream{stream})
+}
+
+type MavenlinkCommunicator_StreamTimeEntriesStream interface {
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*Timeentry) error
+}
+
+type mavenlinkCommunicatorStreamTimeEntriesStream struct {
+	stream server.Stream
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *mavenlinkCommunicatorStreamTimeEntriesStream) Send(m *Timeentry) error {
+	return x.stream.Send(m)
+}
+
+func (h *MavenlinkCommunicator) GetProjectsByIds(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetProjectsByIds(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) GetTasksByIds(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetTasksByIds(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) GetUsersByIds(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetUsersByIds(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) GetTimeentriesByIds(ctx context.Context, in *Request, out *Response) error {
+	return h.MavenlinkCommunicatorHandler.GetTimeentriesByIds(ctx, in, out)
+}
+
+func (h *MavenlinkCommunicator) Health(ctx context.Context, in *HealthRequest, out *HealthResponse) error {
+	return h.MavenlinkCommunicatorHandler.Health(ctx, in, out)
+}
+
 func init() {
-	proto.RegisterFile("proto/mavenlink-communicator/mavenlink-communicator.proto", fileDescriptor_mavenlink_communicator_a9af29e9786aea96)
-}
-
-var fileDescriptor_mavenlink_communicator_a9af29e9786aea96 = []byte{
-	// 1731 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x59, 0x5f, 0x6f, 0x23, 0x49,
-	0x11, 0xc7, 0x7f, 0x66, 0x3d, 0x53, 0x76, 0xfe, 0xa8, 0x59, 0x60, 0x2e, 0xbb, 0x2b, 0x72, 0x3e,
-	0x71, 0x17, 0x4e, 0x87, 0x81, 0x1c, 0x48, 0xdc, 0x09, 0x90, 0xb2, 0xc9, 0x12, 0x59, 0x22, 0xb7,
-	0x61, 0x92, 0xd5, 0xc2, 0x0b, 0xa3, 0xf6, 0x4c, 0x25, 0x69, 0x3c, 0x9e, 0x31, 0xdd, 0x3d, 0x5e,
-	0x8c, 0x38, 0x1e, 0x78, 0x44, 0xe2, 0x01, 0x90, 0x78, 0xe2, 0x95, 0xef, 0xc0, 0x0b, 0x3a, 0x89,
-	0x47, 0xc4, 0x07, 0xe0, 0x23, 0xc0, 0xb7, 0x40, 0xfd, 0x67, 0xc6, 0x13, 0x3b, 0x9b, 0x3b, 0x3b,
-	0x21, 0x77, 0x5a, 0xf1, 0x14, 0xd7, 0xaf, 0xbb, 0xab, 0xaa, 0xeb, 0x57, 0x55, 0xdd, 0xd3, 0x81,
-	0xf7, 0xc6, 0x3c, 0x93, 0xd9, 0xd7, 0x47, 0x74, 0x82, 0x69, 0xc2, 0xd2, 0xe1, 0xd7, 0xa2, 0x6c,
-	0x34, 0xca, 0x53, 0x16, 0x51, 0x99, 0xf1, 0x97, 0xc0, 0x3d, 0xbd, 0x86, 0xbc, 0x1d, 0x65, 0x42,
-	0x72, 0x2a, 0xf1, 0x9c, 0xfd, 0xa2, 0x27, 0x90, 0x4f, 0x58, 0x84, 0xbd, 0x72, 0x45, 0xaf, 0xba,
-	0xa2, 0xfb, 0xfb, 0x06, 0xb4, 0x8e, 0x79, 0xf6, 0x33, 0x8c, 0x24, 0x59, 0x87, 0x3a, 0x8b, 0xfd,
-	0xda, 0x76, 0x6d, 0xc7, 0x0b, 0xea, 0x2c, 0x26, 0xf7, 0xc1, 0x91, 0x4c, 0x26, 0xe8, 0xd7, 0x35,
-	0x64, 0x04, 0xb2, 0x0d, 0xed, 0x18, 0x45, 0xc4, 0xd9, 0x58, 0xb2, 0x2c, 0xf5, 0x1b, 0x7a, 0xac,
-	0x0a, 0xa9, 0x19, 0x34, 0x8a, 0x50, 0x88, 0x1f, 0xe2, 0x04, 0x13, 0xbf, 0x69, 0x66, 0x54, 0x20,
-	0xf2, 0x10, 0x3c, 0x1a, 0x45, 0x59, 0x9e, 0xca, 0x7e, 0xec, 0x3b, 0xdb, 0xb5, 0x1d, 0x27, 0x98,
-	0x01, 0x64, 0x0b, 0x5c, 0xca, 0xa3, 0x0b, 0x36, 0xc1, 0xd8, 0xbf, 0xb7, 0x5d, 0xdb, 0x71, 0x83,
-	0x52, 0x56, 0x63, 0x51, 0xce, 0x39, 0xa6, 0xd1, 0xd4, 0x6f, 0x69, 0xc5, 0xa5, 0x4c, 0xde, 0x84,
-	0xf5, 0xe2, 0xf7, 0xc9, 0x74, 0x34, 0xc8, 0x12, 0xdf, 0xd5, 0x33, 0xe6, 0x50, 0xe2, 0x43, 0x2b,
-	0xce, 0xf1, 0x80, 0x4a, 0xf4, 0x3d, 0x3d, 0xa1, 0x10, 0xc9, 0xdb, 0xb0, 0x89, 0x67, 0x67, 0x18,
-	0x49, 0x36, 0xc1, 0x03, 0x3b, 0x05, 0xf4, 0x94, 0x05, 0x5c, 0xed, 0x41, 0x48, 0xca, 0xa5, 0x9e,
-	0xd4, 0xd6, 0x93, 0x66, 0x80, 0x1a, 0x8d, 0x38, 0x52, 0x89, 0xf1, 0x9e, 0xf4, 0x3b, 0x66, 0xb4,
-	0x04, 0xd4, 0x68, 0x3e, 0x8e, 0xed, 0xe8, 0x9a, 0x19, 0x2d, 0x81, 0xee, 0xbf, 0x1a, 0xd0, 0x3c,
-	0xa5, 0x62, 0x78, 0x6b, 0x84, 0x3c, 0x02, 0x10, 0x32, 0xe3, 0xd3, 0x50, 0x4e, 0xc7, 0x68, 0xf9,
-	0xf0, 0x34, 0x72, 0x3a, 0x1d, 0xa3, 0x8a, 0xe9, 0x98, 0xb3, 0x8c, 0x33, 0x39, 0xd5, 0x64, 0x78,
-	0x41, 0x29, 0x5f, 0xcb, 0xc5, 0xeb, 0xd0, 0x79, 0x91, 0xf1, 0xa1, 0x18, 0xd3, 0x08, 0x43, 0x16,
-	0x5b, 0x3e, 0xda, 0x25, 0xd6, 0x8f, 0x95, 0x65, 0xbd, 0xeb, 0x8c, 0xab, 0x09, 0x6e, 0x25, 0x0e,
-	0x19, 0xef, 0xc7, 0xe4, 0x01, 0x78, 0x63, 0xca, 0x31, 0x95, 0x6a, 0xd4, 0xb3, 0xa6, 0x35, 0xd0,
-	0x8f, 0xc9, 0x6b, 0xe0, 0xc6, 0x39, 0x86, 0xf1, 0x8c, 0x84, 0x92, 0xa7, 0xfb, 0xe0, 0x08, 0x39,
-	0x8b, 0xbb, 0x11, 0xcc, 0x36, 0x29, 0x97, 0x66, 0x49, 0x67, 0x9e, 0x92, 0xc2, 0x17, 0x8c, 0x43,
-	0x5a, 0x46, 0x7d, 0xc6, 0xc9, 0x23, 0x00, 0x4b, 0x81, 0x1a, 0x5e, 0x9f, 0x23, 0x85, 0x1c, 0x40,
-	0x33, 0x17, 0xc8, 0xfd, 0x8d, 0xed, 0xda, 0x4e, 0x7b, 0xf7, 0x1b, 0xbd, 0x4f, 0x5e, 0x63, 0xbd,
-	0x67, 0x02, 0x79, 0xa0, 0x57, 0x77, 0xff, 0x5e, 0x07, 0xef, 0x94, 0x8d, 0x10, 0x53, 0xc9, 0xa7,
-	0x0b, 0xfc, 0x7e, 0x05, 0xd6, 0x95, 0xb9, 0x70, 0x8c, 0xfc, 0x2c, 0xe3, 0x23, 0x8c, 0x2d, 0xd1,
-	0x6b, 0x0a, 0x3d, 0x2e, 0x40, 0xf2, 0x26, 0x6c, 0x48, 0x36, 0xc2, 0x90, 0xa5, 0xe1, 0x88, 0xa5,
-	0xb9, 0x44, 0xa1, 0x49, 0x77, 0x82, 0x35, 0x05, 0xf7, 0xd3, 0x23, 0x03, 0xaa, 0x28, 0xa5, 0x99,
-	0x1a, 0x35, 0x8c, 0x1b, 0x61, 0x81, 0x35, 0x67, 0x91, 0xb5, 0xd7, 0xc0, 0x35, 0xf9, 0xc2, 0x0c,
-	0xe9, 0x5e, 0xd0, 0xd2, 0x72, 0x85, 0x50, 0x13, 0xa5, 0xd6, 0xf5, 0x41, 0x74, 0x5f, 0x16, 0x44,
-	0xef, 0x46, 0x41, 0xfc, 0x63, 0x0d, 0x9a, 0x4a, 0x5c, 0x88, 0xdf, 0x03, 0xf0, 0xce, 0xf2, 0x24,
-	0x09, 0x53, 0x3a, 0x2a, 0x6a, 0xc4, 0x55, 0xc0, 0x07, 0x74, 0x84, 0xe4, 0x0d, 0x58, 0xc3, 0x11,
-	0x65, 0x49, 0x48, 0xe3, 0x98, 0xa3, 0x10, 0xb6, 0x50, 0x3a, 0x1a, 0xdc, 0x33, 0x98, 0x4a, 0xf7,
-	0x0b, 0xa4, 0x71, 0xc2, 0xd2, 0xa2, 0x4e, 0x4a, 0x59, 0xed, 0xcd, 0xf6, 0xa8, 0x59, 0xd8, 0x66,
-	0x5d, 0xab, 0xfb, 0x5d, 0xf0, 0x8f, 0x0a, 0xd7, 0x03, 0x14, 0xe3, 0x2c, 0x15, 0x18, 0xa0, 0xc8,
-	0x13, 0x29, 0xc8, 0x26, 0x34, 0x86, 0x38, 0xb5, 0x9e, 0xaa, 0x9f, 0xd6, 0xf5, 0x7a, 0xe1, 0x7a,
-	0xf7, 0x2f, 0x0d, 0x20, 0xe5, 0xf2, 0xe7, 0x05, 0x17, 0xb7, 0xd6, 0x01, 0x5e, 0x87, 0x8e, 0xe9,
-	0xbf, 0x61, 0xf2, 0xb2, 0x9e, 0xbc, 0xb8, 0xbd, 0x5b, 0x69, 0xca, 0x6f, 0xc1, 0x46, 0xf1, 0x3b,
-	0x14, 0xd7, 0x75, 0xe5, 0x6a, 0xb9, 0xcf, 0xb5, 0xe5, 0x77, 0x80, 0x94, 0xed, 0x37, 0x9c, 0xeb,
-	0x09, 0x8b, 0x8d, 0xf9, 0x72, 0x1b, 0x68, 0x5f, 0xdf, 0x06, 0x3a, 0xd7, 0x67, 0xf0, 0x42, 0x6f,
-	0xfe, 0xa8, 0x01, 0xeb, 0x25, 0x4f, 0x27, 0xaa, 0x28, 0xfe, 0xdf, 0xa5, 0x3f, 0x43, 0x5d, 0x5a,
-	0xe5, 0xb9, 0x10, 0xec, 0x3c, 0x45, 0xb5, 0x57, 0xe1, 0x6f, 0x6c, 0x37, 0x74, 0x9e, 0x5b, 0xac,
-	0x1f, 0x8b, 0xee, 0xbf, 0xab, 0x95, 0x76, 0x67, 0xbd, 0xb8, 0x0b, 0x6b, 0xaa, 0xd1, 0xa9, 0x79,
-	0x11, 0xa6, 0xd2, 0xf4, 0x64, 0x27, 0x68, 0x2b, 0xb0, 0x9f, 0xee, 0x2b, 0x68, 0xd6, 0xaf, 0x9d,
-	0x6a, 0xbf, 0xde, 0x02, 0x77, 0xc0, 0x92, 0x84, 0x0e, 0x12, 0x2c, 0xb8, 0x2d, 0xe4, 0x4f, 0xc2,
-	0x6d, 0xb5, 0x97, 0xbb, 0x97, 0x7b, 0x79, 0xb5, 0x6c, 0xbd, 0xb9, 0xb2, 0x7d, 0x07, 0x48, 0x59,
-	0xb6, 0x03, 0x2a, 0x30, 0xcc, 0x53, 0x26, 0x35, 0xc1, 0x4e, 0xb0, 0x59, 0x8c, 0x3c, 0xa6, 0x02,
-	0x9f, 0xa5, 0x4c, 0xaa, 0xdd, 0xa9, 0xce, 0x1c, 0x46, 0x34, 0x0d, 0x31, 0x66, 0x52, 0x33, 0xee,
-	0x06, 0x6d, 0x05, 0xee, 0xd3, 0xf4, 0x49, 0xcc, 0xa4, 0xce, 0xd1, 0xf1, 0x98, 0x67, 0x2a, 0x47,
-	0x3b, 0x36, 0x47, 0xad, 0x4c, 0xbe, 0x04, 0x2d, 0xbd, 0x9e, 0xc5, 0x96, 0xf1, 0x7b, 0x4a, 0x5c,
-	0x38, 0x6e, 0xd6, 0xaf, 0xcf, 0x86, 0x8d, 0xf9, 0x62, 0xfd, 0x73, 0x1d, 0xd6, 0x4a, 0xaa, 0x97,
-	0x3f, 0x31, 0x1e, 0x01, 0x8c, 0x2f, 0x32, 0x99, 0x85, 0x63, 0x2a, 0x2f, 0x6c, 0xc5, 0x7a, 0x1a,
-	0x39, 0xa6, 0xf2, 0x62, 0xf1, 0x40, 0x69, 0x7e, 0xcc, 0x81, 0xe2, 0xcc, 0x1d, 0x28, 0x3e, 0xb4,
-	0xce, 0x31, 0x45, 0xce, 0x22, 0x4b, 0x6c, 0x21, 0xaa, 0x55, 0x31, 0x13, 0x8a, 0x62, 0xc3, 0xa9,
-	0x1b, 0x94, 0x32, 0xf9, 0x2a, 0x6c, 0x9a, 0x1d, 0x86, 0x2f, 0x2e, 0x98, 0xc4, 0x84, 0x09, 0x75,
-	0xd0, 0xaa, 0x34, 0xdf, 0x30, 0xf8, 0xf3, 0x02, 0x9e, 0x6b, 0xe9, 0xde, 0xfc, 0x89, 0xf5, 0xdb,
-	0x1a, 0x7c, 0x61, 0xe1, 0xc8, 0x3a, 0x42, 0x49, 0x55, 0x26, 0xea, 0x49, 0x3a, 0x52, 0x4e, 0x60,
-	0x04, 0x1d, 0x0f, 0x7a, 0x8e, 0xa1, 0x19, 0xaa, 0x9b, 0x13, 0x42, 0x21, 0xfb, 0x7a, 0xf8, 0xcb,
-	0xd0, 0xd6, 0xc3, 0x69, 0x3e, 0x1a, 0x20, 0xb7, 0x65, 0xa0, 0x57, 0x7c, 0xa0, 0x11, 0xd3, 0x47,
-	0xce, 0x31, 0x3c, 0x61, 0xbf, 0x44, 0x9b, 0xff, 0xae, 0x02, 0x94, 0xdc, 0xfd, 0x87, 0x03, 0x0f,
-	0x16, 0x0f, 0x40, 0x51, 0xb8, 0xf5, 0x12, 0x97, 0x9e, 0x41, 0x73, 0x84, 0x92, 0x6a, 0x67, 0xda,
-	0xbb, 0x7b, 0xcb, 0x5c, 0x28, 0xae, 0xdc, 0x79, 0xa0, 0xd5, 0x91, 0x9f, 0x42, 0x8b, 0x9b, 0xa3,
-	0xdb, 0x6f, 0x6c, 0x37, 0x76, 0xda, 0xbb, 0x07, 0x37, 0xd2, 0x6c, 0xaf, 0x01, 0x41, 0xa1, 0x94,
-	0xbc, 0x00, 0x28, 0x6b, 0x54, 0xe5, 0x8d, 0x32, 0xf1, 0x7c, 0x25, 0x13, 0x8b, 0x91, 0xea, 0xcd,
-	0xa0, 0x27, 0xaa, 0xb3, 0x05, 0x15, 0x53, 0x24, 0x05, 0x5d, 0xfd, 0x4c, 0x37, 0x19, 0x65, 0xf5,
-	0xf4, 0xb6, 0xac, 0x9e, 0x18, 0xb5, 0xc6, 0x64, 0x61, 0x64, 0xeb, 0x43, 0xd8, 0x98, 0x73, 0xe7,
-	0x8a, 0xbb, 0xd0, 0x29, 0x38, 0x13, 0x9a, 0xe4, 0x68, 0x59, 0xfc, 0xfe, 0xcd, 0x5c, 0x0a, 0x8c,
-	0xb2, 0xf7, 0xeb, 0xdf, 0xa9, 0x6d, 0x4d, 0xa0, 0x53, 0xf5, 0xeb, 0x0a, 0xdb, 0xc7, 0x97, 0x6d,
-	0xbf, 0xbf, 0x92, 0x6d, 0x7d, 0x0f, 0xa8, 0xd8, 0xed, 0xfe, 0xb3, 0x51, 0xb9, 0x0c, 0x5a, 0x0f,
-	0x5e, 0xcd, 0x4c, 0x1e, 0xce, 0x12, 0xca, 0xa4, 0xf1, 0x8f, 0x56, 0x8e, 0x20, 0xfb, 0xd8, 0x6c,
-	0xfa, 0xb4, 0xe8, 0xfc, 0x4f, 0x03, 0x1e, 0x5e, 0xba, 0x32, 0x28, 0xf3, 0xaf, 0x2c, 0xa5, 0xbf,
-	0x82, 0x8e, 0xbe, 0xd2, 0xa0, 0xd9, 0xa4, 0xe5, 0xf5, 0x27, 0x2b, 0x19, 0xb9, 0x22, 0x58, 0xbd,
-	0x0a, 0x66, 0xf8, 0x6d, 0xcb, 0x19, 0xb2, 0xf5, 0x6b, 0xd8, 0x9c, 0x9f, 0xf0, 0xbf, 0x6a, 0x19,
-	0xe5, 0xe5, 0xaf, 0xca, 0xf5, 0x47, 0x0d, 0xf8, 0xe2, 0xa5, 0x3b, 0xc3, 0x2b, 0xca, 0x72, 0x04,
-	0x8e, 0xba, 0x63, 0x15, 0xf4, 0x1e, 0xad, 0xa4, 0xfd, 0x52, 0x7c, 0xf4, 0x27, 0xba, 0xa5, 0xd4,
-	0xe8, 0xde, 0x12, 0x00, 0x33, 0xf0, 0x0a, 0x1a, 0x9f, 0x5e, 0xa6, 0xf1, 0xbd, 0x95, 0x9d, 0xa8,
-	0x32, 0xf8, 0x3d, 0x70, 0x9e, 0x70, 0x9e, 0x71, 0x42, 0xa0, 0x19, 0x65, 0x31, 0x5a, 0xba, 0xf4,
-	0xef, 0xf9, 0xcf, 0xb0, 0xfa, 0xc2, 0x67, 0x58, 0xf7, 0x77, 0x35, 0x68, 0x05, 0xf8, 0xf3, 0x1c,
-	0x85, 0x54, 0x37, 0xb4, 0x21, 0x4e, 0x9f, 0xf2, 0x7e, 0x71, 0x67, 0x2c, 0x44, 0xf2, 0x10, 0xbc,
-	0xf2, 0x58, 0xb5, 0x5a, 0x66, 0x80, 0xb2, 0x2c, 0xa9, 0x18, 0xda, 0x3b, 0xa3, 0xfe, 0xad, 0x74,
-	0x89, 0x7c, 0x70, 0xaa, 0xe0, 0xa6, 0xbd, 0x87, 0x1b, 0x51, 0xe9, 0x62, 0x42, 0xe4, 0xa8, 0xc7,
-	0xec, 0xbb, 0x42, 0x09, 0x74, 0xff, 0xe6, 0x80, 0x5b, 0xa6, 0xe0, 0x11, 0xb4, 0xc6, 0xe6, 0xb5,
-	0x56, 0x3b, 0xd4, 0xde, 0x7d, 0x77, 0x99, 0x90, 0xd9, 0x87, 0xde, 0xa0, 0xd0, 0x41, 0x9e, 0xaa,
-	0x6f, 0x4a, 0xfd, 0x53, 0xf8, 0x75, 0x9d, 0x07, 0x2b, 0xe9, 0x2b, 0x95, 0x90, 0x03, 0xbb, 0xf1,
-	0xe6, 0xf2, 0x0f, 0x3c, 0x6a, 0xb3, 0x36, 0x54, 0x3f, 0x00, 0x47, 0xfd, 0x2d, 0xee, 0x28, 0xcb,
-	0xab, 0x31, 0xcb, 0xc9, 0x09, 0x78, 0xb2, 0xa8, 0x71, 0x7d, 0xc5, 0x6e, 0xef, 0x7e, 0x7b, 0x29,
-	0x5d, 0x65, 0x83, 0x98, 0xe9, 0x21, 0xcf, 0xa1, 0x5d, 0x08, 0xaa, 0x3b, 0xb6, 0xb4, 0x8b, 0x2b,
-	0xaa, 0xad, 0x6a, 0x2a, 0x1f, 0xc7, 0xdc, 0x9b, 0x3c, 0x8e, 0xa9, 0xd8, 0x99, 0xba, 0xf6, 0x96,
-	0x8f, 0x9d, 0xa9, 0x24, 0xbd, 0x9c, 0x1c, 0x82, 0x83, 0xaa, 0x8a, 0xf4, 0x37, 0x5f, 0x7b, 0xf7,
-	0x9b, 0xcb, 0xe8, 0xd1, 0xe5, 0x17, 0x98, 0xf5, 0xdd, 0x1f, 0x83, 0xff, 0x24, 0x9d, 0x30, 0x9e,
-	0xa5, 0x23, 0x4c, 0xe5, 0x7e, 0x96, 0x9e, 0xb1, 0xf3, 0x9c, 0x53, 0xfd, 0xe4, 0x71, 0x1f, 0x9c,
-	0x18, 0x07, 0xf9, 0xb9, 0x4e, 0x66, 0x37, 0x30, 0x82, 0xea, 0x13, 0x39, 0x4f, 0x6c, 0x55, 0xa9,
-	0x9f, 0xfa, 0x49, 0x25, 0x1b, 0x62, 0xf1, 0x6c, 0x62, 0x84, 0xdd, 0xbf, 0xba, 0x95, 0xef, 0x97,
-	0xfd, 0x8a, 0x03, 0xe4, 0x43, 0x58, 0x3f, 0x44, 0xb9, 0x97, 0x24, 0xc7, 0x45, 0x62, 0x2e, 0x95,
-	0xd7, 0xb6, 0xfc, 0xb7, 0xbe, 0xb5, 0xdc, 0x22, 0x53, 0xa3, 0xdd, 0xcf, 0x59, 0xf3, 0xd6, 0xf6,
-	0x63, 0xf5, 0xa9, 0x7d, 0xa7, 0xe6, 0x7f, 0x53, 0x83, 0xcf, 0x1f, 0xa2, 0x54, 0x95, 0x20, 0x1e,
-	0x4f, 0xad, 0x1b, 0x77, 0xed, 0xc4, 0x1f, 0x6a, 0xf0, 0xc6, 0x21, 0xca, 0x13, 0xd3, 0xe3, 0x94,
-	0x1f, 0xfa, 0xc5, 0x48, 0x09, 0x7b, 0x69, 0xfc, 0x29, 0x39, 0xf5, 0xa7, 0x1a, 0xbc, 0x35, 0x8b,
-	0x8c, 0xf5, 0xed, 0xb3, 0xe0, 0x98, 0xc9, 0x98, 0xd3, 0x4a, 0x37, 0xb8, 0x53, 0xf3, 0x2f, 0xc0,
-	0x3d, 0x44, 0xa9, 0x8f, 0xea, 0xbb, 0x35, 0x3c, 0x81, 0x96, 0x35, 0x7c, 0xa7, 0x76, 0x07, 0xf7,
-	0xf4, 0x7f, 0x4a, 0xdf, 0xfd, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x0c, 0x3b, 0x1c, 0xe8, 0x66,
-	0x1d, 0x00, 0x00,
+	proto.RegisterFile("proto/mavenlink-communicator/mavenlink-communicator.proto", fileDescriptor_mavenlink_communicator_b00a811418c7678f)
+}
+
+var fileDescriptor_mavenlink_communicator_b00a811418c7678f = []byte{
+	// 3863 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5c, 0x4f, 0x73, 0x23, 0xc7,
+	0x75, 0x37, 0x48, 0x80, 0x04, 0x1e, 0x48, 0x80, 0x9c, 0xa5, 0xd6, 0xb3, 0x5c, 0x29, 0xcb, 0x85,
+	0xb2, 0xda, 0xb5, 0xb2, 0xa6, 0xd7, 0x2b, 0xd9, 0xb1, 0x54, 0x8e, 0x53, 0x34, 0xf7, 0x4f, 0x60,
+	0x79, 0xb5, 0xf4, 0x90, 0x9b, 0xad, 0xb8, 0x52, 0x46, 0x0d, 0x67, 0x7a, 0x89, 0x11, 0x07, 0x33,
+	0x50, 0x77, 0x83, 0x14, 0x1c, 0xd9, 0x89, 0x92, 0x4b, 0xe2, 0x9b, 0x13, 0x25, 0xa7, 0x5c, 0x5d,
+	0x95, 0x4f, 0x90, 0x4b, 0x0e, 0xae, 0xca, 0x21, 0x55, 0x49, 0x3e, 0x45, 0x6e, 0x39, 0xe4, 0x23,
+	0xe4, 0x90, 0x7a, 0xef, 0x75, 0xcf, 0x0c, 0x00, 0x92, 0x2b, 0x80, 0x0c, 0xd7, 0xa5, 0xf2, 0x0d,
+	0xfd, 0xeb, 0x37, 0xef, 0x75, 0xbf, 0x7f, 0xfd, 0xfa, 0x4f, 0x01, 0xde, 0xeb, 0xcb, 0x54, 0xa7,
+	0xdf, 0xe8, 0xf9, 0x47, 0x22, 0x89, 0xa3, 0xe4, 0xf0, 0xeb, 0x41, 0xda, 0xeb, 0x0d, 0x92, 0x28,
+	0xf0, 0x75, 0x2a, 0x4f, 0x81, 0x37, 0xe9, 0x1b, 0xe7, 0xed, 0x20, 0x55, 0x5a, 0xfa, 0x5a, 0x1c,
+	0x44, 0x9f, 0x6c, 0x2a, 0x21, 0x8f, 0xa2, 0x40, 0x6c, 0x66, 0x5f, 0x6c, 0x16, 0xbf, 0x68, 0xfd,
+	0x72, 0x1e, 0x16, 0x77, 0x64, 0xfa, 0x91, 0x08, 0xb4, 0xd3, 0x80, 0xb9, 0x28, 0x74, 0x4b, 0x1b,
+	0xa5, 0x3b, 0x35, 0x6f, 0x2e, 0x0a, 0x9d, 0x35, 0xa8, 0xe8, 0x48, 0xc7, 0xc2, 0x9d, 0x23, 0x88,
+	0x1b, 0xce, 0x06, 0xd4, 0x43, 0xa1, 0x02, 0x19, 0xf5, 0x75, 0x94, 0x26, 0xee, 0x3c, 0xf5, 0x15,
+	0x21, 0xa4, 0xf0, 0x83, 0x40, 0x28, 0xf5, 0x43, 0x71, 0x24, 0x62, 0xb7, 0xcc, 0x14, 0x05, 0xc8,
+	0x79, 0x1d, 0x6a, 0x7e, 0x10, 0xa4, 0x83, 0x44, 0xb7, 0x43, 0xb7, 0xb2, 0x51, 0xba, 0x53, 0xf1,
+	0x72, 0xc0, 0x59, 0x87, 0xaa, 0x2f, 0x83, 0x6e, 0x74, 0x24, 0x42, 0x77, 0x61, 0xa3, 0x74, 0xa7,
+	0xea, 0x65, 0x6d, 0xec, 0x0b, 0x06, 0x52, 0x8a, 0x24, 0x18, 0xba, 0x8b, 0xc4, 0x38, 0x6b, 0x3b,
+	0x6f, 0x41, 0xc3, 0xfe, 0xde, 0x1d, 0xf6, 0xf6, 0xd3, 0xd8, 0xad, 0x12, 0xc5, 0x18, 0xea, 0xb8,
+	0xb0, 0x18, 0x0e, 0xc4, 0x03, 0x5f, 0x0b, 0xb7, 0x46, 0x04, 0xb6, 0xe9, 0xbc, 0x0d, 0x2b, 0xe2,
+	0xc5, 0x0b, 0x11, 0xe8, 0xe8, 0x48, 0x3c, 0x30, 0x24, 0x40, 0x24, 0x13, 0x38, 0xce, 0x41, 0x69,
+	0x5f, 0x6a, 0x22, 0xaa, 0x13, 0x51, 0x0e, 0x60, 0x6f, 0x20, 0x85, 0xaf, 0x45, 0xb8, 0xa5, 0xdd,
+	0x25, 0xee, 0xcd, 0x00, 0xec, 0x1d, 0xf4, 0x43, 0xd3, 0xbb, 0xcc, 0xbd, 0x19, 0xd0, 0xfa, 0xbc,
+	0x02, 0xe5, 0x3d, 0x5f, 0x1d, 0x5e, 0x98, 0x41, 0xde, 0x00, 0x50, 0x3a, 0x95, 0xc3, 0x8e, 0x1e,
+	0xf6, 0x85, 0xb1, 0x47, 0x8d, 0x90, 0xbd, 0x61, 0x5f, 0xa0, 0x4e, 0xfb, 0x32, 0x4a, 0x65, 0xa4,
+	0x87, 0x64, 0x8c, 0x9a, 0x97, 0xb5, 0xcf, 0xb4, 0xc5, 0x4d, 0x58, 0x3a, 0x4e, 0xe5, 0xa1, 0xea,
+	0xfb, 0x81, 0xe8, 0x44, 0xa1, 0xb1, 0x47, 0x3d, 0xc3, 0xda, 0x21, 0x4a, 0xa6, 0x59, 0xa7, 0x12,
+	0x09, 0xaa, 0x05, 0x3d, 0xa4, 0xb2, 0x1d, 0x3a, 0xd7, 0xa1, 0xd6, 0xf7, 0xa5, 0x48, 0x34, 0xf6,
+	0xd6, 0x8c, 0x68, 0x02, 0xda, 0xa1, 0x73, 0x0d, 0xaa, 0xe1, 0x40, 0x74, 0xc2, 0xdc, 0x08, 0x99,
+	0x9d, 0xd6, 0xa0, 0xa2, 0x74, 0xae, 0x77, 0x6e, 0xf0, 0x34, 0x7d, 0xa9, 0xf9, 0x93, 0xa5, 0x71,
+	0x93, 0xd8, 0xb1, 0x88, 0xb0, 0xe3, 0x67, 0x5a, 0xcf, 0x6d, 0xf2, 0x06, 0x80, 0x31, 0x01, 0x76,
+	0x37, 0xc6, 0x8c, 0xe2, 0x3c, 0x80, 0xf2, 0x40, 0x09, 0xe9, 0x36, 0x37, 0x4a, 0x77, 0xea, 0xf7,
+	0xef, 0x6d, 0x7e, 0xf1, 0x18, 0xdb, 0x7c, 0xa6, 0x84, 0xf4, 0xe8, 0x6b, 0xe7, 0x27, 0xb0, 0xd4,
+	0x97, 0x22, 0x14, 0x18, 0x0a, 0xa9, 0x54, 0xee, 0xca, 0xc6, 0xfc, 0x9d, 0xfa, 0xfd, 0xf7, 0xa7,
+	0xe1, 0x86, 0x9e, 0xf1, 0x40, 0xf4, 0x45, 0x12, 0xa2, 0x4b, 0x7b, 0x23, 0xfc, 0x9c, 0x1f, 0x03,
+	0xa8, 0x41, 0x60, 0xb9, 0xaf, 0x9e, 0x9b, 0x7b, 0x81, 0x5b, 0xeb, 0x3f, 0xe7, 0xa0, 0x8a, 0xdd,
+	0x1f, 0xa6, 0xa1, 0x40, 0x75, 0x68, 0x5f, 0x1d, 0xba, 0xa5, 0xe9, 0xd5, 0x81, 0x3c, 0x3c, 0xfa,
+	0xda, 0xf9, 0x10, 0x6a, 0xbe, 0x52, 0xd1, 0x41, 0x22, 0x84, 0x72, 0xe7, 0x36, 0xe6, 0xa7, 0x65,
+	0x45, 0x9a, 0xcd, 0x59, 0x38, 0xb7, 0xa0, 0x11, 0xa7, 0x07, 0x07, 0x22, 0xec, 0xf4, 0xa2, 0x64,
+	0xa0, 0x85, 0xa2, 0x68, 0xa8, 0x78, 0xcb, 0x8c, 0x3e, 0x61, 0xd0, 0xb9, 0x07, 0x6b, 0x3a, 0xd5,
+	0x7e, 0xdc, 0x19, 0x23, 0x2e, 0x13, 0xb1, 0x43, 0x7d, 0x3f, 0x1c, 0xf9, 0x62, 0x07, 0xaa, 0x41,
+	0x37, 0x8a, 0x43, 0x29, 0x12, 0xb7, 0x42, 0xe3, 0x7c, 0x77, 0xda, 0x29, 0xa3, 0xda, 0xbc, 0x8c,
+	0x4b, 0xeb, 0x57, 0x25, 0x68, 0x8c, 0x2a, 0x7b, 0x22, 0xdc, 0x6f, 0x41, 0xa3, 0x60, 0x5c, 0x0c,
+	0x11, 0x8e, 0xfb, 0xe5, 0x02, 0xda, 0xa6, 0x30, 0xcc, 0xac, 0x84, 0x44, 0x26, 0x01, 0x64, 0x58,
+	0x3b, 0x74, 0x6e, 0x43, 0x33, 0xcc, 0xe4, 0x14, 0xb3, 0x40, 0x23, 0x87, 0x29, 0x15, 0xac, 0xc0,
+	0x7c, 0xec, 0x1f, 0x98, 0x94, 0x8c, 0x3f, 0x5b, 0xff, 0x35, 0x07, 0x2b, 0xdb, 0x32, 0xd2, 0x51,
+	0xe0, 0xc7, 0x3b, 0xbe, 0xee, 0x52, 0x62, 0xfa, 0x2a, 0x2c, 0xa2, 0xfd, 0x3a, 0xd9, 0x70, 0x17,
+	0xb0, 0xd9, 0x3e, 0x2d, 0x43, 0x8d, 0x06, 0xe6, 0xfc, 0x78, 0x60, 0x16, 0x03, 0xbd, 0x3c, 0x1a,
+	0xe8, 0xeb, 0xd8, 0x25, 0x7d, 0x4a, 0x6c, 0x3c, 0xa8, 0xac, 0x8d, 0xea, 0x11, 0xbe, 0x8c, 0x23,
+	0xa1, 0x74, 0x87, 0x98, 0x51, 0x82, 0xaa, 0x78, 0xcb, 0x16, 0xdd, 0x45, 0x10, 0xe7, 0x9e, 0x91,
+	0xbd, 0x88, 0x92, 0x48, 0x75, 0x29, 0x51, 0x55, 0xbc, 0xec, 0xeb, 0x47, 0x84, 0xa2, 0x1e, 0x63,
+	0x5f, 0xe7, 0xdc, 0xaa, 0x44, 0x55, 0x67, 0x8c, 0x79, 0xbd, 0x09, 0xcb, 0x86, 0xc4, 0x70, 0xaa,
+	0x11, 0x8d, 0xf9, 0xce, 0xf0, 0xc1, 0xe4, 0x14, 0xfb, 0xc1, 0x21, 0x25, 0xad, 0x8a, 0xc7, 0x0d,
+	0x5a, 0xb8, 0x8c, 0x1a, 0x29, 0x6b, 0x55, 0xbd, 0xac, 0xdd, 0xfa, 0xdf, 0x12, 0x2c, 0x15, 0x75,
+	0x3c, 0x91, 0x59, 0x4b, 0x27, 0x66, 0xd6, 0x82, 0x4e, 0xe7, 0xc6, 0x75, 0x7a, 0x03, 0xea, 0x3c,
+	0xc4, 0xa2, 0xce, 0x81, 0xa1, 0x09, 0xcd, 0x96, 0xc7, 0x34, 0x7b, 0x0d, 0xaa, 0xc6, 0xbc, 0x8a,
+	0xbc, 0xbd, 0xe6, 0x2d, 0xb2, 0x7d, 0x95, 0xe3, 0x41, 0x05, 0x7f, 0x2a, 0x77, 0x81, 0xa2, 0xe0,
+	0xbb, 0xd3, 0x44, 0xc1, 0xb8, 0x1b, 0x79, 0xcc, 0xaa, 0xf5, 0xaf, 0x73, 0x50, 0xdb, 0x8b, 0x7a,
+	0x42, 0x24, 0x5a, 0x9e, 0x18, 0x05, 0x38, 0x85, 0x4e, 0x5f, 0xc8, 0x17, 0xa9, 0xec, 0x89, 0x2c,
+	0x0a, 0x10, 0xdd, 0xb1, 0xa0, 0xf3, 0x16, 0x34, 0x75, 0xd4, 0x13, 0x9d, 0x28, 0x19, 0x8f, 0x7d,
+	0x84, 0xdb, 0x89, 0x8d, 0xe4, 0x35, 0xa8, 0x24, 0xa9, 0x0d, 0xf6, 0x9a, 0xc7, 0x8d, 0x09, 0x85,
+	0x57, 0x26, 0x15, 0x7e, 0x0d, 0xaa, 0xbc, 0x88, 0x46, 0xbc, 0x12, 0xd6, 0xbc, 0x45, 0x6a, 0x17,
+	0x56, 0x39, 0x5e, 0x3a, 0x16, 0xcf, 0x5e, 0x59, 0xaa, 0xa7, 0xad, 0x2c, 0xb5, 0xf3, 0xac, 0x2c,
+	0xad, 0xbf, 0x2b, 0x41, 0x19, 0x9b, 0x13, 0xfa, 0xbb, 0x0e, 0xb5, 0x17, 0x83, 0x38, 0xee, 0x24,
+	0x7e, 0xcf, 0xfa, 0x49, 0x15, 0x81, 0x0f, 0xfd, 0x9e, 0x40, 0x87, 0x16, 0x3d, 0x3f, 0x8a, 0x3b,
+	0x7e, 0x18, 0x4a, 0xa1, 0x94, 0x71, 0x94, 0x25, 0x02, 0xb7, 0x18, 0x43, 0x57, 0xe9, 0x0a, 0x3f,
+	0x8c, 0xa3, 0xc4, 0xc6, 0x67, 0xd6, 0xc6, 0xb9, 0x99, 0xc2, 0x2d, 0x57, 0x5b, 0x5e, 0xca, 0xb5,
+	0x34, 0xd4, 0xd1, 0xd2, 0xdb, 0xac, 0x8b, 0x0b, 0x5a, 0x35, 0x6e, 0x60, 0xc1, 0xa3, 0x45, 0x60,
+	0x14, 0xca, 0x73, 0x02, 0x0b, 0x6d, 0xe9, 0xd6, 0x3f, 0x97, 0x60, 0x05, 0xe9, 0x77, 0xb5, 0xaf,
+	0xc5, 0x76, 0xd7, 0x4f, 0x0e, 0x2e, 0x4c, 0x36, 0xe7, 0xe4, 0xa3, 0x28, 0x1d, 0xa8, 0x0e, 0x97,
+	0x20, 0x79, 0x4e, 0x26, 0x94, 0x64, 0xe6, 0x05, 0xca, 0x7c, 0xb1, 0x40, 0x19, 0x1b, 0x78, 0x79,
+	0x62, 0xe0, 0x7f, 0x5d, 0x82, 0x26, 0x46, 0xc2, 0x43, 0x8c, 0x04, 0x5e, 0x81, 0x9c, 0x3d, 0x00,
+	0x72, 0x6c, 0x8a, 0x0e, 0x33, 0xfa, 0x6f, 0x4d, 0x35, 0x7a, 0x1b, 0x5a, 0x5e, 0x4d, 0x5b, 0xde,
+	0x2f, 0xd7, 0xe1, 0x67, 0x25, 0x68, 0x9a, 0x8d, 0xc1, 0x96, 0x2d, 0xf8, 0x9e, 0xc0, 0x62, 0x9f,
+	0x21, 0x33, 0x8e, 0x77, 0xa6, 0x19, 0x87, 0xe1, 0xe6, 0x59, 0x1e, 0x2f, 0x1f, 0xc3, 0xff, 0xcc,
+	0x43, 0xd3, 0x13, 0x2a, 0x1d, 0xc8, 0x20, 0x33, 0xe3, 0x35, 0xa8, 0x8a, 0x23, 0x53, 0x31, 0xb2,
+	0x93, 0x2f, 0x52, 0x9b, 0xc3, 0x90, 0xbb, 0x68, 0x81, 0x33, 0x29, 0x91, 0x10, 0x5b, 0xe6, 0x4a,
+	0xc3, 0xcc, 0x98, 0x25, 0x6b, 0x9b, 0xa0, 0x29, 0x67, 0x41, 0xf3, 0x05, 0xf2, 0xc1, 0x0d, 0xa8,
+	0xa7, 0x01, 0xed, 0x2c, 0x68, 0xf4, 0x9c, 0x12, 0xc0, 0x42, 0x5b, 0xba, 0xa8, 0xad, 0xc5, 0x0b,
+	0xd0, 0x96, 0xf5, 0xdf, 0xea, 0xb9, 0xfc, 0x77, 0xd4, 0x9b, 0x6a, 0x17, 0xe4, 0x4d, 0x36, 0x85,
+	0xc1, 0xb9, 0x52, 0xd8, 0x77, 0xc1, 0x7d, 0x62, 0x89, 0x3c, 0xa1, 0xfa, 0x69, 0xa2, 0x84, 0x27,
+	0xd4, 0x20, 0xd6, 0x0a, 0x0b, 0x93, 0x43, 0x31, 0x34, 0x16, 0xc7, 0x9f, 0xc6, 0x64, 0x73, 0xd6,
+	0x64, 0xad, 0x5f, 0xcd, 0x83, 0x93, 0x7d, 0xfe, 0xdc, 0x1a, 0xea, 0xc2, 0xf6, 0x50, 0x37, 0x61,
+	0x89, 0x77, 0xb0, 0x9d, 0xf8, 0xb4, 0x5d, 0xed, 0x64, 0x2e, 0xbc, 0x90, 0x6d, 0xed, 0x6d, 0x68,
+	0xda, 0xdf, 0x1d, 0x75, 0xd6, 0xbe, 0xb6, 0x58, 0x47, 0x8d, 0x6d, 0x6c, 0xef, 0x82, 0x93, 0x6d,
+	0x60, 0x3b, 0x63, 0xbb, 0xaa, 0xc9, 0xad, 0xed, 0x68, 0x6d, 0x51, 0x3f, 0x7b, 0x23, 0xb5, 0x74,
+	0xf6, 0x72, 0x37, 0xb1, 0xbb, 0xfd, 0xf5, 0x3c, 0x34, 0x32, 0x3b, 0xed, 0xe2, 0x0a, 0xfa, 0xdb,
+	0x7d, 0xee, 0x6f, 0xd0, 0x3e, 0x17, 0xfd, 0xdc, 0xec, 0xa7, 0xa8, 0xfe, 0x6b, 0x52, 0xfd, 0x57,
+	0xb7, 0x58, 0x3b, 0x54, 0xad, 0xff, 0x2e, 0x46, 0xda, 0xa5, 0x15, 0x6e, 0x2d, 0x58, 0x96, 0xc8,
+	0x2e, 0x4a, 0x3a, 0x81, 0x48, 0xb4, 0xdd, 0xad, 0xd5, 0x11, 0x6c, 0x27, 0xdb, 0x08, 0xe5, 0xc5,
+	0x5d, 0xa5, 0x58, 0xdc, 0xad, 0x43, 0x75, 0x3f, 0x8a, 0x63, 0x7f, 0x3f, 0x16, 0xd6, 0xb6, 0xb6,
+	0xfd, 0x45, 0x6c, 0x5b, 0x2c, 0xfc, 0xaa, 0xa3, 0x85, 0x5f, 0x31, 0x6c, 0x6b, 0x63, 0x61, 0x7b,
+	0x17, 0x9c, 0x2c, 0x6c, 0xf7, 0x7d, 0x25, 0x3a, 0x83, 0x24, 0xd2, 0x66, 0x4f, 0xb0, 0x62, 0x7b,
+	0xbe, 0xef, 0x2b, 0xf1, 0x2c, 0x89, 0x34, 0xce, 0x0e, 0x73, 0x60, 0x27, 0xf0, 0x93, 0x8e, 0x08,
+	0x23, 0x6d, 0xf6, 0x08, 0x75, 0x04, 0xb7, 0xfd, 0xe4, 0x61, 0x18, 0x69, 0xf2, 0xd1, 0x7e, 0x5f,
+	0xa6, 0xe8, 0xa3, 0x4b, 0xc6, 0x47, 0x4d, 0x1b, 0x77, 0x64, 0xf4, 0x7d, 0x14, 0x1a, 0x8b, 0x2f,
+	0x60, 0x73, 0xa2, 0x36, 0x6d, 0x9c, 0xed, 0x0d, 0xcd, 0xf1, 0x60, 0xfd, 0xc7, 0x39, 0x58, 0xce,
+	0x4c, 0x3d, 0x7d, 0x79, 0xf9, 0x06, 0x40, 0xbf, 0x9b, 0xea, 0xb4, 0xd3, 0xf7, 0x75, 0xd7, 0x6e,
+	0xfc, 0x08, 0xa1, 0x6d, 0xce, 0x44, 0xf5, 0x59, 0x7e, 0x49, 0xf5, 0x59, 0x19, 0xab, 0x3e, 0x5d,
+	0x58, 0x3c, 0x10, 0x89, 0x90, 0x51, 0x60, 0x0c, 0x6b, 0x9b, 0xf8, 0x55, 0x18, 0x29, 0x34, 0x31,
+	0xdb, 0xb4, 0xea, 0x65, 0x6d, 0xe7, 0x6b, 0xb0, 0xc2, 0x33, 0xec, 0x1c, 0x77, 0x23, 0x2d, 0xe2,
+	0x48, 0x61, 0x55, 0x8e, 0x6e, 0xde, 0x64, 0xfc, 0xb9, 0x85, 0xc7, 0x52, 0x7a, 0x6d, 0xbc, 0xbc,
+	0xfd, 0x8b, 0x39, 0x70, 0x47, 0x73, 0xd9, 0x19, 0xdb, 0xf9, 0xeb, 0x50, 0xe3, 0x6a, 0x23, 0xdf,
+	0xc9, 0x57, 0x19, 0xe0, 0x0c, 0xa1, 0x7d, 0x79, 0x20, 0x74, 0xbe, 0x83, 0xaf, 0x32, 0x70, 0xae,
+	0xed, 0xfb, 0x84, 0x7f, 0x2f, 0x9c, 0x9e, 0xbb, 0x66, 0xda, 0xbd, 0xb4, 0x7e, 0x51, 0x82, 0xd7,
+	0x26, 0x56, 0xed, 0x27, 0x42, 0xfb, 0x18, 0x8c, 0xa4, 0x27, 0x52, 0x41, 0xc5, 0xe3, 0x06, 0xb9,
+	0x84, 0x7f, 0x20, 0x3a, 0xdc, 0x35, 0x47, 0x5d, 0x35, 0x44, 0xb6, 0xa9, 0xfb, 0x06, 0xd4, 0xa9,
+	0x3b, 0x19, 0xf4, 0xf6, 0x85, 0x34, 0x99, 0x80, 0xbe, 0xf8, 0x90, 0x10, 0x4e, 0xa5, 0x07, 0xa2,
+	0xb3, 0x1b, 0xfd, 0x54, 0xd8, 0x8d, 0x2b, 0x02, 0xd8, 0x6e, 0xfd, 0x47, 0x05, 0xae, 0x4f, 0xd6,
+	0x00, 0xca, 0x0e, 0xeb, 0x94, 0x21, 0x3d, 0x83, 0x72, 0x4f, 0x68, 0x9f, 0x06, 0x53, 0xbf, 0xbf,
+	0x35, 0x4d, 0xf5, 0x72, 0xe2, 0xcc, 0x3d, 0x62, 0xe7, 0xfc, 0x04, 0x16, 0x25, 0x57, 0x2f, 0xee,
+	0x3c, 0x6d, 0x96, 0x1f, 0x9c, 0x8b, 0xb3, 0xa9, 0x84, 0x3c, 0xcb, 0xd4, 0x39, 0x06, 0xc8, 0xcc,
+	0x88, 0xa1, 0x83, 0x22, 0x9e, 0xcf, 0x24, 0x62, 0x52, 0x53, 0x9b, 0x39, 0x44, 0x15, 0x9e, 0x57,
+	0x10, 0xe5, 0x24, 0x40, 0x09, 0x30, 0x12, 0xca, 0x9c, 0x85, 0xed, 0x5d, 0x94, 0xd4, 0x5d, 0x66,
+	0xcb, 0x22, 0xad, 0x90, 0xf5, 0x9f, 0x41, 0x73, 0x6c, 0x38, 0x27, 0x94, 0x83, 0x7b, 0x50, 0x39,
+	0xf2, 0xe3, 0x81, 0x30, 0x56, 0xfc, 0xde, 0xf9, 0x86, 0xe4, 0x31, 0xb3, 0xf7, 0xe7, 0xbe, 0x53,
+	0x5a, 0x3f, 0x82, 0xa5, 0xe2, 0xb8, 0x4e, 0x90, 0xbd, 0x33, 0x2a, 0xfb, 0xfd, 0x99, 0x64, 0x53,
+	0xfa, 0x28, 0xc8, 0x6d, 0xfd, 0x53, 0x65, 0x2c, 0xb9, 0x44, 0x5f, 0x56, 0x4f, 0x3e, 0xcc, 0x1d,
+	0x8a, 0xdd, 0xf8, 0x47, 0x33, 0x6b, 0x30, 0x7a, 0x99, 0x37, 0x39, 0x02, 0x2a, 0xb8, 0x34, 0x5a,
+	0xdf, 0x7d, 0x7a, 0x21, 0xa2, 0x70, 0x69, 0x34, 0x82, 0x98, 0xfb, 0xab, 0xf2, 0x9a, 0x75, 0x05,
+	0x90, 0x0f, 0xe6, 0x04, 0xa9, 0x4f, 0x47, 0xa5, 0xbe, 0x37, 0x93, 0x54, 0xda, 0xb4, 0x15, 0x5c,
+	0xf5, 0xdf, 0x2b, 0xf0, 0xfa, 0x48, 0x45, 0x88, 0xd2, 0xbf, 0xb4, 0xee, 0xfa, 0x29, 0x2c, 0x65,
+	0x7b, 0xe8, 0xdc, 0x67, 0xff, 0x64, 0x26, 0x21, 0x27, 0x28, 0x6b, 0xb3, 0x80, 0xb1, 0x4b, 0xd5,
+	0x75, 0x8e, 0x38, 0xd1, 0xa8, 0xff, 0xee, 0x5e, 0x98, 0xd8, 0x49, 0x1f, 0xfe, 0x39, 0xac, 0x8c,
+	0x8f, 0xe5, 0xff, 0x2b, 0xf3, 0xe6, 0xc7, 0x0a, 0xaf, 0xda, 0x97, 0x7f, 0x3d, 0x0f, 0x57, 0x47,
+	0x3a, 0xbf, 0xa4, 0x5e, 0x1c, 0x58, 0x3f, 0x62, 0xf7, 0x7d, 0x32, 0xb3, 0xf2, 0xce, 0xf2, 0xa0,
+	0x57, 0x62, 0xc1, 0xbf, 0x2f, 0x43, 0xeb, 0x94, 0xaa, 0xfc, 0x4b, 0x9b, 0x93, 0x3e, 0x2f, 0x81,
+	0xc3, 0xbb, 0xd4, 0xb0, 0x30, 0x57, 0x63, 0x5b, 0x31, 0xfb, 0xd2, 0x72, 0x92, 0xe6, 0x36, 0x27,
+	0x7a, 0xd8, 0xe6, 0xab, 0x6a, 0x1c, 0x5f, 0xff, 0x45, 0x09, 0xae, 0x9e, 0x4c, 0x7d, 0x82, 0x33,
+	0xfc, 0x78, 0xd4, 0x19, 0x1e, 0x5c, 0xc0, 0xa8, 0x47, 0x0a, 0xaa, 0x3f, 0x80, 0xca, 0x43, 0x29,
+	0x53, 0xe9, 0x38, 0x50, 0x0e, 0xd2, 0x50, 0x18, 0xc3, 0xd3, 0xef, 0xf1, 0xd3, 0xa5, 0xb9, 0x89,
+	0xd3, 0xa5, 0xd6, 0xbf, 0xcc, 0xc1, 0xa2, 0x27, 0x3e, 0x1e, 0x08, 0xa5, 0x71, 0xe3, 0x79, 0x28,
+	0x86, 0x4f, 0x65, 0x3b, 0x3b, 0x84, 0x36, 0x4d, 0x7c, 0xda, 0x91, 0x95, 0xca, 0x86, 0x4b, 0x0e,
+	0xa0, 0x64, 0x3a, 0xc4, 0xe5, 0x1d, 0x1e, 0xfd, 0x46, 0x5e, 0x6a, 0xb0, 0x8f, 0x67, 0xb4, 0xf6,
+	0xf6, 0xd3, 0x34, 0x91, 0x57, 0xa4, 0xd4, 0x40, 0x50, 0x9f, 0xb9, 0x5b, 0xc9, 0x00, 0xe7, 0x2e,
+	0xac, 0x46, 0x49, 0x10, 0x0f, 0x42, 0xc1, 0x37, 0x05, 0x98, 0x42, 0xcd, 0x36, 0x78, 0xb2, 0x03,
+	0xa5, 0xf4, 0x85, 0xdc, 0xf1, 0x0f, 0x84, 0xb9, 0xfe, 0xb4, 0x4d, 0x34, 0x04, 0x1e, 0xf4, 0xf0,
+	0x0e, 0x18, 0x7f, 0xa2, 0x2e, 0xf6, 0x87, 0x7d, 0x5f, 0xa9, 0x6d, 0x3f, 0xe8, 0xf2, 0x59, 0x62,
+	0xd5, 0x2b, 0x42, 0xce, 0x3d, 0xb8, 0x62, 0x44, 0x14, 0x0d, 0x4b, 0xa7, 0x1b, 0x55, 0xef, 0xa4,
+	0xae, 0xd6, 0x67, 0x0d, 0xa8, 0x66, 0xa1, 0x77, 0xc1, 0x17, 0x09, 0x4f, 0xa1, 0x6a, 0x7e, 0xda,
+	0x57, 0x04, 0x33, 0xf1, 0xcb, 0x98, 0x64, 0x67, 0xed, 0xe5, 0x73, 0x9d, 0xb5, 0x3f, 0xb2, 0x77,
+	0xa5, 0x95, 0x8d, 0xf9, 0x99, 0xd8, 0xf0, 0xe7, 0xce, 0x2e, 0xd4, 0xb4, 0x5d, 0x1e, 0xdd, 0x85,
+	0x73, 0x1f, 0xd9, 0xd3, 0x4f, 0xe7, 0x39, 0xd4, 0x6d, 0x03, 0x2d, 0xb7, 0xb8, 0x31, 0x3f, 0x3b,
+	0xdb, 0x22, 0xa7, 0xec, 0x2e, 0xa0, 0x7a, 0xae, 0x87, 0x32, 0x8f, 0xec, 0xea, 0x54, 0x9b, 0xf1,
+	0x55, 0x08, 0x7f, 0xee, 0x3c, 0x86, 0x8a, 0xc0, 0x98, 0x37, 0x57, 0x13, 0xdf, 0x9c, 0x86, 0x0f,
+	0x25, 0x0b, 0x8f, 0xbf, 0x77, 0xfe, 0x14, 0x96, 0x82, 0xc2, 0xfd, 0x35, 0x9d, 0xcf, 0xd5, 0xef,
+	0x7f, 0x67, 0xd6, 0xfb, 0x6f, 0x6f, 0x84, 0x1b, 0xbe, 0x2f, 0x41, 0x5b, 0xef, 0x49, 0x81, 0x07,
+	0xba, 0xe7, 0x78, 0x5f, 0x62, 0xb9, 0x38, 0x1f, 0xc1, 0x92, 0x75, 0xe7, 0xef, 0x0f, 0xdb, 0x78,
+	0x2a, 0x88, 0x5c, 0x1f, 0x4d, 0xc3, 0x35, 0xcb, 0xf7, 0x3b, 0x05, 0x46, 0x9c, 0xea, 0x47, 0x78,
+	0x3b, 0x3e, 0x1e, 0x5e, 0xa9, 0x43, 0x16, 0xd4, 0x20, 0x41, 0xdb, 0x33, 0x09, 0xda, 0xb3, 0x5c,
+	0x1e, 0x1a, 0x77, 0xb5, 0x6d, 0x14, 0x41, 0x06, 0x25, 0x11, 0xcd, 0x73, 0x88, 0x78, 0x66, 0xb9,
+	0x18, 0x11, 0x19, 0x57, 0x47, 0x41, 0xb3, 0xe0, 0xc7, 0x24, 0x88, 0x9f, 0x67, 0xb5, 0x67, 0x9b,
+	0xcb, 0x28, 0x2f, 0x16, 0x37, 0x2e, 0x01, 0xcf, 0x29, 0x93, 0x54, 0x3f, 0x4a, 0x07, 0x49, 0x48,
+	0xcf, 0xb5, 0x6a, 0x5e, 0xd6, 0x5e, 0xd7, 0xb0, 0x3a, 0xa1, 0xf9, 0x13, 0x96, 0xcd, 0xf6, 0xe8,
+	0xb2, 0x39, 0x53, 0xea, 0x2b, 0x14, 0xdd, 0x09, 0xbf, 0x4b, 0x3a, 0x53, 0xe4, 0xa3, 0x51, 0x91,
+	0x33, 0x64, 0xb6, 0x11, 0x79, 0xa3, 0x36, 0xb9, 0x60, 0x79, 0x63, 0xd5, 0xe1, 0xfa, 0x10, 0xd6,
+	0x4e, 0x32, 0xcd, 0x09, 0x52, 0x3f, 0x18, 0x95, 0x3a, 0x63, 0x72, 0x2c, 0x14, 0x20, 0xb7, 0x60,
+	0xf9, 0x8f, 0x84, 0x1f, 0xeb, 0xae, 0x2d, 0x23, 0xd6, 0xa0, 0xd2, 0x97, 0xe9, 0x3e, 0x57, 0x22,
+	0x55, 0x8f, 0x1b, 0xad, 0x1f, 0x41, 0x9d, 0xc9, 0xb6, 0xbb, 0x22, 0x38, 0xc4, 0x9a, 0x81, 0x0e,
+	0xd7, 0x79, 0x64, 0xf4, 0xdb, 0xb9, 0x0a, 0x0b, 0x4a, 0xfb, 0x7a, 0xa0, 0x4c, 0x89, 0x61, 0x5a,
+	0x88, 0x87, 0x42, 0xfb, 0x51, 0x6c, 0x2a, 0x0c, 0xd3, 0x6a, 0xf5, 0xa0, 0x4e, 0x0b, 0xf7, 0x2e,
+	0x93, 0xb9, 0xb0, 0x28, 0x12, 0x3e, 0x1c, 0x67, 0xc9, 0xb6, 0x89, 0xc2, 0xba, 0x91, 0x66, 0xb6,
+	0x65, 0x8f, 0x7e, 0x23, 0xd3, 0x5e, 0xa4, 0x94, 0xb9, 0x98, 0x29, 0x7b, 0xa6, 0xc5, 0x5c, 0xec,
+	0x16, 0x98, 0x4a, 0x0a, 0xd3, 0x6c, 0x7d, 0x0a, 0xb0, 0x3b, 0x4c, 0x82, 0x97, 0x4a, 0x5b, 0x87,
+	0x6a, 0xec, 0x2b, 0x8d, 0xb4, 0xf6, 0x44, 0xdc, 0xb6, 0x9d, 0x16, 0x3e, 0xc7, 0x52, 0xfa, 0xd1,
+	0x20, 0x8e, 0xa9, 0xdf, 0xbc, 0x4c, 0x29, 0x62, 0xe6, 0x7e, 0x2c, 0xe6, 0x83, 0xe0, 0xaa, 0xc7,
+	0x8d, 0xd6, 0xe7, 0x73, 0xd0, 0xb0, 0x7a, 0x36, 0x05, 0x47, 0xae, 0xaf, 0xd2, 0x88, 0xbe, 0x9e,
+	0xc2, 0x42, 0x80, 0x4a, 0xb6, 0x75, 0xc3, 0xef, 0x4f, 0x63, 0xe3, 0x82, 0x91, 0x3c, 0xc3, 0xc6,
+	0x79, 0x02, 0x95, 0x80, 0x8a, 0xa6, 0xf9, 0x8d, 0xd2, 0xb4, 0xfc, 0x0a, 0x16, 0xf2, 0x98, 0x8b,
+	0xf3, 0x03, 0x28, 0x2b, 0x9c, 0x3c, 0x17, 0x22, 0xdf, 0x9e, 0x86, 0x5b, 0x6e, 0x00, 0x8f, 0x78,
+	0xb4, 0x7e, 0x79, 0x05, 0xdc, 0x87, 0xc9, 0x51, 0x24, 0xd3, 0xa4, 0x27, 0x12, 0xbd, 0x9d, 0x26,
+	0x2f, 0xa2, 0x03, 0xfb, 0xe4, 0x6b, 0x0d, 0x2a, 0xa1, 0xd8, 0x1f, 0x1c, 0x58, 0x4f, 0xa4, 0x06,
+	0xc6, 0xc4, 0x40, 0xc6, 0xc6, 0x34, 0xf8, 0x13, 0xe9, 0x74, 0x7a, 0x28, 0xec, 0xf5, 0x2b, 0x37,
+	0xf0, 0xaa, 0x8f, 0xc6, 0xdb, 0xc9, 0xee, 0x5c, 0xd8, 0x20, 0xcb, 0x84, 0x3e, 0x30, 0x20, 0xdd,
+	0x34, 0x10, 0x99, 0xc2, 0xc3, 0x7b, 0x73, 0x41, 0x4e, 0x08, 0x9e, 0xde, 0xd3, 0x8d, 0x19, 0x75,
+	0xdb, 0xc5, 0xa5, 0xa3, 0x75, 0x6c, 0x1e, 0xf5, 0xad, 0x50, 0x8f, 0x4d, 0x87, 0x7b, 0x3a, 0xc6,
+	0x7b, 0x43, 0xa6, 0xa6, 0x45, 0x82, 0x48, 0xb9, 0xb0, 0x65, 0xa1, 0x94, 0xc3, 0x46, 0xe8, 0x28,
+	0xd3, 0x13, 0x5d, 0xb5, 0x40, 0x47, 0xb9, 0x07, 0xe9, 0xee, 0xc3, 0x6b, 0x86, 0x5f, 0x9e, 0x1d,
+	0x88, 0x9a, 0xdf, 0xf8, 0x5d, 0x61, 0xae, 0x79, 0x1f, 0x7e, 0x73, 0x0b, 0x1a, 0xbd, 0x48, 0xca,
+	0x54, 0x76, 0xac, 0x83, 0x73, 0x05, 0xbc, 0xcc, 0xe8, 0x43, 0xe3, 0xe6, 0x37, 0xa0, 0x6e, 0xc8,
+	0xfa, 0xb6, 0x74, 0xa8, 0x79, 0xc0, 0x10, 0x2d, 0xff, 0xb7, 0xa1, 0x69, 0x08, 0xa2, 0x44, 0x0b,
+	0x79, 0xe4, 0xc7, 0x74, 0xc1, 0x57, 0xf1, 0x0c, 0xfb, 0xb6, 0x41, 0xf1, 0xea, 0xca, 0x10, 0x92,
+	0xab, 0x27, 0x42, 0x29, 0xba, 0xef, 0xab, 0x78, 0x86, 0xc1, 0xae, 0x85, 0x9d, 0xf7, 0xe0, 0x9a,
+	0x21, 0xa5, 0xfb, 0x39, 0x74, 0x81, 0x9c, 0x7b, 0x83, 0xbe, 0xb9, 0xca, 0x04, 0x36, 0x9c, 0x32,
+	0x29, 0xf8, 0xb2, 0xf2, 0x48, 0x24, 0x5a, 0x65, 0xd3, 0x6a, 0xf2, 0xb4, 0x18, 0xb5, 0xd3, 0xba,
+	0x0d, 0xcd, 0x63, 0xb1, 0xdf, 0x4d, 0xd3, 0xc3, 0x8c, 0x6e, 0x85, 0xe8, 0x1a, 0x06, 0x3e, 0x81,
+	0xd0, 0xde, 0xf4, 0xad, 0xf2, 0xfd, 0x95, 0x81, 0xed, 0x5d, 0xdf, 0x2d, 0xb0, 0x48, 0x47, 0x89,
+	0x40, 0x0a, 0xed, 0x3a, 0x7c, 0x65, 0x6c, 0xd0, 0x5d, 0x02, 0x91, 0xdf, 0x81, 0xaf, 0xc5, 0xb1,
+	0x3f, 0xcc, 0x04, 0x5f, 0x61, 0xc1, 0x06, 0x2e, 0x08, 0xb6, 0x84, 0x56, 0xf0, 0x1a, 0x0b, 0x36,
+	0xb0, 0x15, 0x8c, 0x84, 0xd2, 0xef, 0x77, 0x3f, 0x8e, 0x33, 0x8e, 0xaf, 0x19, 0x8e, 0x0c, 0x17,
+	0x39, 0x1a, 0x42, 0xcb, 0xf1, 0xaa, 0xe1, 0xc8, 0xb0, 0xe5, 0xf8, 0x67, 0xb0, 0xac, 0x45, 0xe2,
+	0xe3, 0x6b, 0x24, 0x0c, 0x11, 0xe5, 0x7e, 0x95, 0x12, 0xcc, 0x1f, 0x4f, 0x55, 0x80, 0x9e, 0x12,
+	0xad, 0x9b, 0x7b, 0xc4, 0x79, 0x8f, 0x18, 0x9b, 0x82, 0x4c, 0x17, 0x20, 0x1c, 0xa5, 0x11, 0x2e,
+	0xc5, 0xc7, 0x83, 0x48, 0x8a, 0xd0, 0x75, 0x79, 0x3a, 0x0c, 0x7b, 0x06, 0xc5, 0x1b, 0xd8, 0xd4,
+	0x1f, 0xe8, 0x6e, 0x36, 0xeb, 0x6b, 0x44, 0xb6, 0x44, 0xa0, 0x9d, 0xf3, 0x75, 0xa8, 0x31, 0x11,
+	0xe6, 0x82, 0x75, 0x4e, 0xd3, 0x04, 0x3c, 0x93, 0x14, 0x5e, 0xdc, 0x19, 0xc4, 0x91, 0x79, 0xe0,
+	0x70, 0x9d, 0x6d, 0x46, 0xf0, 0x36, 0xa1, 0xed, 0xd0, 0xd9, 0x84, 0x2b, 0x23, 0x74, 0xc6, 0xbe,
+	0xaf, 0x13, 0xed, 0x6a, 0x81, 0xd6, 0xd8, 0xf8, 0x2e, 0x38, 0x4c, 0x2f, 0x45, 0x18, 0x49, 0x11,
+	0x68, 0x92, 0xfe, 0x06, 0xbf, 0x58, 0xa1, 0x1e, 0xcf, 0x74, 0xe0, 0x28, 0xb2, 0x79, 0x58, 0xa3,
+	0xfc, 0x0e, 0xaf, 0x16, 0x04, 0x5a, 0x93, 0xdc, 0x01, 0xfe, 0x90, 0x2d, 0xc2, 0xb1, 0x78, 0x83,
+	0x8d, 0x47, 0x38, 0x29, 0x8f, 0xe2, 0xf1, 0x1e, 0xac, 0x59, 0xb5, 0x04, 0x72, 0x48, 0xdb, 0xff,
+	0x0e, 0x16, 0x07, 0x1b, 0x44, 0xed, 0x18, 0xed, 0xd8, 0xae, 0x0f, 0xc4, 0x90, 0x9e, 0x4d, 0x14,
+	0xf5, 0x78, 0x93, 0xf7, 0xcc, 0x45, 0x35, 0xbe, 0x05, 0x4d, 0x22, 0xf9, 0xe8, 0x38, 0x9b, 0x7d,
+	0x8b, 0x35, 0x85, 0xf0, 0x0f, 0x8e, 0xed, 0xcc, 0x8b, 0x74, 0xb4, 0xdb, 0x97, 0xee, 0x9b, 0x23,
+	0x74, 0x6d, 0x02, 0x9d, 0xb7, 0x61, 0x35, 0xa3, 0xf3, 0x07, 0x61, 0x24, 0x92, 0x40, 0xb8, 0xbf,
+	0x4b, 0x94, 0x4d, 0x43, 0xb9, 0x65, 0x60, 0x67, 0x08, 0xcb, 0xac, 0x9e, 0x7e, 0x84, 0x13, 0x51,
+	0xee, 0x2d, 0xf2, 0xc6, 0x67, 0x17, 0xe2, 0x8d, 0x5b, 0xa8, 0xe3, 0x7e, 0xf4, 0x81, 0x18, 0xda,
+	0xf3, 0x6a, 0x3f, 0x47, 0x50, 0xeb, 0x24, 0xba, 0x9f, 0xc6, 0x51, 0x30, 0x64, 0xad, 0xbf, 0xc5,
+	0x5a, 0x47, 0x7c, 0x87, 0x60, 0xd2, 0xfa, 0x75, 0xa8, 0xc5, 0xe9, 0x81, 0x79, 0x5f, 0x75, 0xdb,
+	0x94, 0x03, 0xe9, 0x01, 0x3f, 0xae, 0xc2, 0x14, 0x29, 0xb4, 0x8c, 0x82, 0x3c, 0x29, 0xdd, 0x61,
+	0x97, 0x36, 0x70, 0x21, 0x42, 0x2d, 0xa1, 0x75, 0x86, 0xaf, 0xb1, 0x38, 0x03, 0x17, 0x92, 0x8d,
+	0x96, 0x78, 0x2d, 0x2e, 0x3e, 0xe9, 0xa7, 0x52, 0x0b, 0xe9, 0xbe, 0xcd, 0x6a, 0x26, 0xf4, 0xa1,
+	0x01, 0xd1, 0x71, 0x99, 0x2c, 0xd5, 0x71, 0xbf, 0x23, 0x92, 0xb0, 0x9f, 0x46, 0x89, 0x76, 0x7f,
+	0x8f, 0x1d, 0x97, 0xba, 0x9e, 0xea, 0xb8, 0xff, 0xd0, 0x74, 0x20, 0xdb, 0x2e, 0x15, 0x06, 0xd9,
+	0x38, 0xef, 0x72, 0xf2, 0x64, 0xd4, 0x0e, 0x33, 0x27, 0xb3, 0xa3, 0xfc, 0x3a, 0x4b, 0x67, 0xd4,
+	0x0e, 0xf2, 0x26, 0x2c, 0x19, 0x32, 0x2e, 0x14, 0x37, 0xd9, 0xaf, 0x18, 0xdb, 0x41, 0x88, 0x16,
+	0x2e, 0xb2, 0x47, 0xe7, 0xd8, 0xd7, 0x41, 0x37, 0x4f, 0xf2, 0xdf, 0x30, 0x0b, 0x17, 0x75, 0x3e,
+	0xc7, 0xbe, 0x2c, 0xc3, 0xdf, 0x86, 0xa6, 0xe4, 0x1a, 0x94, 0x96, 0xbb, 0x74, 0xa0, 0xdd, 0x7b,
+	0xbc, 0xe0, 0x18, 0x78, 0x8f, 0x51, 0xb4, 0x49, 0x57, 0xeb, 0x7e, 0xa7, 0x87, 0xe7, 0x65, 0xdf,
+	0x34, 0xcf, 0x2f, 0xb4, 0xee, 0x3f, 0xc1, 0x33, 0xb3, 0x9b, 0xb0, 0x14, 0xf8, 0x4a, 0x09, 0xad,
+	0x71, 0xe5, 0x97, 0xee, 0x7d, 0xea, 0xaf, 0x5b, 0xec, 0x41, 0x24, 0x69, 0x85, 0x14, 0x3d, 0x3c,
+	0x97, 0xb4, 0xda, 0x78, 0xc7, 0xac, 0x90, 0x84, 0x16, 0x62, 0xc3, 0x90, 0x29, 0x21, 0x42, 0x62,
+	0xf6, 0x2e, 0xab, 0x83, 0xe1, 0x5d, 0x21, 0xc2, 0x51, 0x76, 0x56, 0x6b, 0xdf, 0x2a, 0x92, 0x59,
+	0xad, 0x65, 0x49, 0xc9, 0x0f, 0x7b, 0x11, 0x87, 0xee, 0xb7, 0x0b, 0x49, 0x69, 0x0b, 0xd1, 0x0f,
+	0xc4, 0x70, 0xfd, 0x0f, 0x61, 0x75, 0x22, 0x95, 0x9e, 0xb0, 0x11, 0x58, 0x2b, 0x6e, 0x04, 0x6a,
+	0xc5, 0xcd, 0xc4, 0xf7, 0x60, 0x65, 0xdc, 0xfb, 0xa7, 0xf9, 0xfe, 0xfe, 0xbf, 0x39, 0x85, 0xd7,
+	0x13, 0xdb, 0x85, 0x68, 0x73, 0x7e, 0x06, 0x8d, 0xc7, 0x42, 0x6f, 0xc5, 0xb1, 0xad, 0x79, 0x9c,
+	0x77, 0xa6, 0xdb, 0x86, 0x92, 0x19, 0xd7, 0xdf, 0x9d, 0x65, 0xef, 0xda, 0xfa, 0x8a, 0x11, 0x6f,
+	0x64, 0xd3, 0x4e, 0xf5, 0x52, 0xc5, 0xff, 0x65, 0x09, 0xae, 0x3c, 0x16, 0xda, 0x6c, 0x44, 0xcd,
+	0x30, 0x2e, 0x7b, 0x10, 0x7f, 0x5b, 0x82, 0x37, 0x1f, 0x0b, 0xbd, 0x3b, 0xd8, 0xb7, 0xe3, 0xa0,
+	0x27, 0x7b, 0xd8, 0xd8, 0x4a, 0xc2, 0x57, 0x34, 0xa8, 0x7f, 0x28, 0xc1, 0xed, 0x5c, 0x33, 0x66,
+	0x6c, 0xbf, 0x09, 0x03, 0x63, 0x8f, 0x29, 0x14, 0xc8, 0x97, 0x2b, 0xfe, 0x18, 0xaa, 0x8f, 0x85,
+	0xa6, 0x6a, 0xfe, 0x72, 0x05, 0x1f, 0xc1, 0xa2, 0x11, 0x7c, 0xb9, 0x72, 0xff, 0xa6, 0x04, 0xeb,
+	0x8f, 0x85, 0x2e, 0x1e, 0x2a, 0xbe, 0xb2, 0x48, 0xf9, 0x29, 0xd4, 0x8d, 0x4f, 0xd2, 0xd9, 0xe3,
+	0xa5, 0xca, 0xfe, 0x39, 0x34, 0x76, 0xb5, 0x14, 0x7e, 0xef, 0x7c, 0x89, 0x72, 0x96, 0x63, 0xb3,
+	0xd6, 0x57, 0xee, 0x95, 0x9c, 0x4f, 0xa0, 0xce, 0xf2, 0x29, 0x24, 0x67, 0x13, 0x3e, 0xf5, 0x01,
+	0x1a, 0x49, 0xfe, 0xab, 0x12, 0xac, 0x1a, 0xd1, 0x85, 0xf7, 0x01, 0x33, 0x0d, 0x60, 0xb6, 0xb3,
+	0x2d, 0x1a, 0xc5, 0x9f, 0xc3, 0x4a, 0xbe, 0x52, 0xd0, 0x79, 0xda, 0x25, 0x07, 0xe0, 0xa7, 0xb0,
+	0x9c, 0x27, 0xc4, 0x57, 0x24, 0x3d, 0x3b, 0xc1, 0x54, 0xaf, 0x66, 0x9d, 0x1c, 0x3d, 0xd0, 0xbc,
+	0xe4, 0x41, 0x7c, 0x56, 0x82, 0x05, 0x3e, 0x0b, 0x73, 0xde, 0x9b, 0xfe, 0xfc, 0xcc, 0x4a, 0x7f,
+	0x7f, 0x96, 0x4f, 0xed, 0x18, 0xf6, 0x17, 0xe8, 0xaf, 0x0f, 0xde, 0xf9, 0xbf, 0x01, 0x00, 0xe3,
+	0xf9, 0xee, 0xb2, 0x37, 0x41, 0x00, 0x00,
 }
@@ -0,0 +1,184 @@
+package tenant
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	"github.com/desertjinn/mavenlink-communicator/cache"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/golang/protobuf/proto"
+	"github.com/micro/go-micro/metadata"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metadata keys callers pass their Mavenlink token, or the ID of a tenant
+// whose token is stored in the configuration, in
+const (
+	TokenKey  = "X-Mavenlink-Token"
+	TenantKey = "X-Mavenlink-Tenant"
+)
+
+// Number of tenants whose clients are kept at once, and how long a client
+// and its cache are kept before being rebuilt
+const (
+	defaultSize = 100
+	clientTTL   = time.Hour
+)
+
+// ErrUnknownTenant is returned for a tenant without a stored token
+var ErrUnknownTenant = errors.New("Unknown tenant")
+
+// ErrMissingCredentials is returned when a caller passes no credentials while they are required
+var ErrMissingCredentials = errors.New("A Mavenlink token or tenant is required")
+
+// Pool holds a Mavenlink client per token, each with a cache of its own so
+// that the results retrieved for a tenant are never served to another. The
+// clients call Mavenlink directly: the mirror holds the records visible to
+// the configured token only, so callers passing credentials never read it
+type Pool struct {
+	mutex         sync.RWMutex
+	configuration *communicator.EnvironmentConfiguration
+	clients       *cache.Store
+	totals        *cache.Totals
+}
+
+// NewPool creates a pool of clients configured like the configuration(param: configuration)
+// apart from their token
+func NewPool(configuration *communicator.EnvironmentConfiguration) *Pool {
+	return &Pool{configuration: configuration, clients: cache.NewStore(defaultSize), totals: &cache.Totals{}}
+}
+
+// For returns the client of the caller whose metadata is carried by the context(param: ctx),
+// or nil when the caller passed no credentials and the default client may be used
+func (pool *Pool) For(ctx context.Context) (API.MavenlinkApiInterface, error) {
+	configuration := pool.current()
+	token, tenant := credentials(ctx)
+	if len(tenant) > 0 {
+		stored, ok := configuration.TenantTokens[tenant]
+		if !ok {
+			return nil, errors.Wrapf(ErrUnknownTenant, "tenant %q", tenant)
+		}
+		token = stored
+	}
+	if len(token) < 1 {
+		if configuration.TenantRequired {
+			return nil, ErrMissingCredentials
+		}
+		return nil, nil
+	}
+	// Tokens are not kept in clear as keys of the pool
+	sum := sha256.Sum256([]byte(token))
+	client, err := pool.clients.Fetch(hex.EncodeToString(sum[:]), clientTTL, func() (interface{}, error) {
+		return newClient(configuration, token, pool.totals), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client.(API.MavenlinkApiInterface), nil
+}
+
+// Reload replaces the configuration of the pool(param: configuration), dropping the clients
+// configured with the previous one. Calls made by the dropped clients finish unchanged
+func (pool *Pool) Reload(configuration *communicator.EnvironmentConfiguration) {
+	pool.mutex.Lock()
+	pool.configuration = configuration
+	pool.mutex.Unlock()
+	pool.clients.Purge()
+}
+
+// InvalidateProject drops the results cached by every client which may include the workspace(param: id)
+func (pool *Pool) InvalidateProject(id string) {
+	pool.eachCache(func(cached *cache.MavenlinkCache) { cached.InvalidateProject(id) })
+}
+
+// InvalidateTask drops the results cached by every client which may include the story(param: id)
+// of the workspace(param: workspaceId)
+func (pool *Pool) InvalidateTask(workspaceId string, id string) {
+	pool.eachCache(func(cached *cache.MavenlinkCache) { cached.InvalidateTask(workspaceId, id) })
+}
+
+// InvalidateTimeEntry drops the results cached by every client which may include the time
+// entry(param: id) of the workspace(param: workspaceId)
+func (pool *Pool) InvalidateTimeEntry(workspaceId string, id string) {
+	pool.eachCache(func(cached *cache.MavenlinkCache) { cached.InvalidateTimeEntry(workspaceId, id) })
+}
+
+// InvalidateUser drops the results cached by every client which may include the user(param: id)
+func (pool *Pool) InvalidateUser(id string) {
+	pool.eachCache(func(cached *cache.MavenlinkCache) { cached.InvalidateUser(id) })
+}
+
+// Stats returns the hits and misses of the caches of every client the pool has held,
+// with the entries of the caches of its current clients
+func (pool *Pool) Stats() (uint64, uint64, int) {
+	entries := 0
+	pool.eachCache(func(cached *cache.MavenlinkCache) { entries += cached.Stats().Entries })
+	return pool.totals.Hits(), pool.totals.Misses(), entries
+}
+
+// eachCache calls the function(param: invalidate) with the cache of every client of the pool
+func (pool *Pool) eachCache(invalidate func(cached *cache.MavenlinkCache)) {
+	for _, client := range pool.clients.Values() {
+		if cached, ok := client.(*cache.MavenlinkCache); ok {
+			invalidate(cached)
+		}
+	}
+}
+
+func (pool *Pool) current() *communicator.EnvironmentConfiguration {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+	return pool.configuration
+}
+
+// newClient creates a client configured like the configuration(param: shared) calling Mavenlink with the token(param: token),
+// counting the hits and misses of its cache in the totals(param: totals)
+func newClient(shared *communicator.EnvironmentConfiguration, token string, totals *cache.Totals) API.MavenlinkApiInterface {
+	configuration := proto.Clone(shared).(*communicator.EnvironmentConfiguration)
+	configuration.Token = token
+	mavenlinkApi := &API.MavenlinkApi{}
+	mavenlinkApi.SetEnv(configuration)
+	if configuration.CacheDisabled {
+		return mavenlinkApi
+	}
+	cached := cache.New(mavenlinkApi, configuration)
+	cached.CountInto(totals)
+	return cached
+}
+
+// RequestContext returns the context the RPCs of an HTTP request(param: r) are called with,
+// carrying the Mavenlink credentials passed in its headers as metadata
+func RequestContext(r *http.Request) context.Context {
+	md := metadata.Metadata{}
+	for _, key := range []string{TokenKey, TenantKey} {
+		if value := r.Header.Get(key); len(value) > 0 {
+			md[key] = value
+		}
+	}
+	if len(md) < 1 {
+		return r.Context()
+	}
+	return metadata.NewContext(r.Context(), md)
+}
+
+// credentials returns the token and the tenant found in the metadata of the context(param: ctx).
+// Transports differ in the case of the keys they carry so keys are matched case insensitively
+func credentials(ctx context.Context) (token string, tenant string) {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	for key, value := range md {
+		if strings.EqualFold(key, TokenKey) {
+			token = value
+		} else if strings.EqualFold(key, TenantKey) {
+			tenant = value
+		}
+	}
+	return token, tenant
+}
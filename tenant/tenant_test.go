@@ -0,0 +1,39 @@
+package tenant
+
+import (
+	"github.com/desertjinn/mavenlink-communicator/mavenlinktest"
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+	"testing"
+)
+
+func TestInvalidationReachesTheCachesOfTheTenants(t *testing.T) {
+	server := mavenlinktest.NewServer()
+	defer server.Close()
+	configuration := server.Config()
+	configuration.TenantTokens = map[string]string{"acme": mavenlinktest.Token}
+	pool := NewPool(configuration)
+	ctx := metadata.NewContext(context.Background(), metadata.Metadata{TenantKey: "acme"})
+	mavenlink, err := pool.For(ctx)
+	if err != nil || mavenlink == nil {
+		t.Fatalf("expected a client for the tenant, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := mavenlink.GetProjects(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 1 {
+		t.Fatalf("expected the projects of the tenant to be cached, got %d requests", len(requests))
+	}
+	if hits, misses, entries := pool.Stats(); hits != 1 || misses != 1 || entries != 1 {
+		t.Errorf("expected the lookups of the tenant to be counted, got %d hits, %d misses and %d entries", hits, misses, entries)
+	}
+	pool.InvalidateProject("1001")
+	if _, err := mavenlink.GetProjects(); err != nil {
+		t.Fatal(err)
+	}
+	if requests := server.Requests(mavenlinktest.Workspaces); len(requests) != 2 {
+		t.Errorf("expected the projects of the tenant to be fetched again, got %d requests", len(requests))
+	}
+}
@@ -0,0 +1,236 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Names of the exporters the configuration can choose from
+const (
+	StdoutExporterName = "stdout"
+	OTLPExporterName   = "otlp"
+)
+
+// Collector endpoint used by the OTLP exporter when the configuration does not provide one
+const defaultOTLPEndpoint = "http://localhost:4318"
+
+// Spans the OTLP exporter sends at once, how long it waits to fill a batch
+// and how many spans it buffers before dropping new ones
+const (
+	batchSize     = 256
+	batchInterval = 5 * time.Second
+	queueSize     = 2048
+)
+
+// SpanData is an ended span as handed to the exporters
+type SpanData struct {
+	TraceID      string                 `json:"trace_id"`
+	SpanID       string                 `json:"span_id"`
+	ParentSpanID string                 `json:"parent_span_id,omitempty"`
+	Name         string                 `json:"name"`
+	Kind         Kind                   `json:"kind"`
+	Start        time.Time              `json:"start"`
+	End          time.Time              `json:"end"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+}
+
+// Exporter sends the ended spans to their destination
+type Exporter interface {
+	Export(span *SpanData)
+}
+
+var (
+	exporterMutex sync.RWMutex
+	exporter      Exporter
+)
+
+// SetExporter sets the exporter(param: spanExporter) of the spans, tracing being disabled without one
+func SetExporter(spanExporter Exporter) {
+	exporterMutex.Lock()
+	defer exporterMutex.Unlock()
+	exporter = spanExporter
+}
+
+// Enabled tells whether spans are recorded
+func Enabled() bool {
+	return currentExporter() != nil
+}
+
+func currentExporter() Exporter {
+	exporterMutex.RLock()
+	defer exporterMutex.RUnlock()
+	return exporter
+}
+
+// NewExporter creates the exporter named by the configuration(param: configuration)
+func NewExporter(configuration *communicator.EnvironmentConfiguration, serviceName string) (Exporter, error) {
+	switch strings.ToLower(configuration.TraceExporter) {
+	case StdoutExporterName:
+		return NewStdoutExporter(os.Stdout), nil
+	case OTLPExporterName:
+		endpoint := configuration.TraceOtlpEndpoint
+		if len(endpoint) < 1 {
+			endpoint = defaultOTLPEndpoint
+		}
+		return NewOTLPExporter(endpoint, serviceName), nil
+	}
+	return nil, errors.Errorf("Unknown trace exporter %q, expected %s or %s",
+		configuration.TraceExporter, StdoutExporterName, OTLPExporterName)
+}
+
+// StdoutExporter writes every span as a JSON object on its own line
+type StdoutExporter struct {
+	mutex sync.Mutex
+	out   io.Writer
+}
+
+// NewStdoutExporter creates an exporter writing spans to the writer(param: out)
+func NewStdoutExporter(out io.Writer) *StdoutExporter {
+	return &StdoutExporter{out: out}
+}
+
+func (stdout *StdoutExporter) Export(span *SpanData) {
+	line, err := json.Marshal(span)
+	if err != nil {
+		return
+	}
+	stdout.mutex.Lock()
+	defer stdout.mutex.Unlock()
+	stdout.out.Write(append(line, '\n'))
+}
+
+// OTLPExporter sends spans in batches to an OpenTelemetry collector with OTLP over HTTP, JSON encoded
+type OTLPExporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	queue       chan *SpanData
+}
+
+// NewOTLPExporter creates an exporter sending spans to the collector at the endpoint(param: endpoint)
+// on behalf of the service(param: serviceName)
+func NewOTLPExporter(endpoint string, serviceName string) *OTLPExporter {
+	otlp := &OTLPExporter{
+		url:         strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		queue:       make(chan *SpanData, queueSize),
+	}
+	go otlp.run()
+	return otlp
+}
+
+// Export queues the span(param: span), dropping it when the collector cannot keep up
+func (otlp *OTLPExporter) Export(span *SpanData) {
+	select {
+	case otlp.queue <- span:
+	default:
+	}
+}
+
+func (otlp *OTLPExporter) run() {
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+	var batch []*SpanData
+	for {
+		select {
+		case span := <-otlp.queue:
+			batch = append(batch, span)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) < 1 {
+				continue
+			}
+		}
+		if err := otlp.send(batch); err != nil {
+			LOG.Error("Spans not exported", LOG.Fields{"url": otlp.url, "error": err})
+		}
+		batch = nil
+	}
+}
+
+func (otlp *OTLPExporter) send(batch []*SpanData) error {
+	spans := make([]map[string]interface{}, 0, len(batch))
+	for _, span := range batch {
+		encoded := map[string]interface{}{
+			"traceId":           span.TraceID,
+			"spanId":            span.SpanID,
+			"name":              span.Name,
+			"kind":              int(span.Kind),
+			"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(span.End.UnixNano(), 10),
+			"attributes":        otlpAttributes(span.Attributes),
+			"status":            map[string]interface{}{"code": 1},
+		}
+		if len(span.ParentSpanID) > 0 {
+			encoded["parentSpanId"] = span.ParentSpanID
+		}
+		if len(span.Error) > 0 {
+			encoded["status"] = map[string]interface{}{"code": 2, "message": span.Error}
+		}
+		spans = append(spans, encoded)
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": otlpAttributes(map[string]interface{}{"service.name": otlp.serviceName}),
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "mavenlink-communicator"},
+				"spans": spans,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	httpResp, err := otlp.client.Post(otlp.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpResp.Body.Close()
+	if httpResp.StatusCode >= 300 {
+		return errors.Errorf("the collector answered %s", httpResp.Status)
+	}
+	return nil
+}
+
+// otlpAttributes encodes the attributes(param: attributes) as OTLP key/values
+func otlpAttributes(attributes map[string]interface{}) []interface{} {
+	encoded := make([]interface{}, 0, len(attributes))
+	for key, value := range attributes {
+		var typed map[string]interface{}
+		switch v := value.(type) {
+		case string:
+			typed = map[string]interface{}{"stringValue": v}
+		case bool:
+			typed = map[string]interface{}{"boolValue": v}
+		case int:
+			typed = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case int32:
+			typed = map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+		case int64:
+			typed = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			typed = map[string]interface{}{"doubleValue": v}
+		default:
+			typed = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": key, "value": typed})
+	}
+	return encoded
+}
@@ -0,0 +1,177 @@
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/micro/go-micro/metadata"
+	"golang.org/x/net/context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceparentKey is the metadata key and HTTP header carrying the W3C trace context of a request
+const TraceparentKey = "traceparent"
+
+// Kind tells the role of a span in its trace
+type Kind int
+
+// Kinds of spans, numbered as in OTLP
+const (
+	Internal Kind = 1
+	Server   Kind = 2
+	Client   Kind = 3
+)
+
+// SpanContext identifies a span across process boundaries
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid tells whether the span context identifies a span
+func (spanContext SpanContext) IsValid() bool {
+	return spanContext.TraceID != [16]byte{} && spanContext.SpanID != [8]byte{}
+}
+
+// Traceparent formats the span context as a W3C traceparent value
+func (spanContext SpanContext) Traceparent() string {
+	flags := "00"
+	if spanContext.Sampled {
+		flags = "01"
+	}
+	return "00-" + hex.EncodeToString(spanContext.TraceID[:]) + "-" + hex.EncodeToString(spanContext.SpanID[:]) + "-" + flags
+}
+
+// ParseTraceparent returns the span context of a W3C traceparent value(param: value)
+func ParseTraceparent(value string) (SpanContext, bool) {
+	var spanContext SpanContext
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return spanContext, false
+	}
+	traceID, traceErr := hex.DecodeString(parts[1])
+	spanID, spanErr := hex.DecodeString(parts[2])
+	flags, flagsErr := hex.DecodeString(parts[3])
+	if traceErr != nil || spanErr != nil || flagsErr != nil || len(traceID) != 16 || len(spanID) != 8 || len(flags) != 1 {
+		return spanContext, false
+	}
+	copy(spanContext.TraceID[:], traceID)
+	copy(spanContext.SpanID[:], spanID)
+	spanContext.Sampled = flags[0]&1 == 1
+	return spanContext, spanContext.IsValid()
+}
+
+// Span is a timed operation of a trace. A nil span records nothing, so that
+// callers need not check whether tracing is enabled
+type Span struct {
+	mutex      sync.Mutex
+	name       string
+	kind       Kind
+	context    SpanContext
+	parent     [8]byte
+	start      time.Time
+	attributes map[string]interface{}
+}
+
+// Keys of the current span and of the remote parent in a context
+type (
+	spanKey   struct{}
+	remoteKey struct{}
+)
+
+// Start starts a span named name as a child of the span of the context(param: ctx), or
+// of the remote parent it carries, and returns a context carrying the new span
+func Start(ctx context.Context, name string, kind Kind) (context.Context, *Span) {
+	if currentExporter() == nil {
+		return ctx, nil
+	}
+	span := &Span{name: name, kind: kind, start: time.Now(), attributes: make(map[string]interface{})}
+	if parent, ok := SpanContextFrom(ctx); ok {
+		span.context.TraceID = parent.TraceID
+		span.context.Sampled = parent.Sampled
+		span.parent = parent.SpanID
+	} else {
+		rand.Read(span.context.TraceID[:])
+		span.context.Sampled = true
+	}
+	rand.Read(span.context.SpanID[:])
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// SpanContextFrom returns the context of the span carried by the context(param: ctx), or
+// of the remote parent extracted from the metadata of the request
+func SpanContextFrom(ctx context.Context) (SpanContext, bool) {
+	if span, ok := ctx.Value(spanKey{}).(*Span); ok && span != nil {
+		return span.context, true
+	}
+	if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		return remote, true
+	}
+	return SpanContext{}, false
+}
+
+// Extract returns a copy of the context(param: ctx) carrying the remote parent whose
+// trace context was passed by the caller in the metadata of the request
+func Extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	for key, value := range md {
+		if strings.EqualFold(key, TraceparentKey) {
+			if remote, ok := ParseTraceparent(value); ok {
+				return context.WithValue(ctx, remoteKey{}, remote)
+			}
+		}
+	}
+	return ctx
+}
+
+// SetAttribute records the value(param: value) of an attribute(param: key) of the span
+func (span *Span) SetAttribute(key string, value interface{}) {
+	if span == nil {
+		return
+	}
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	span.attributes[key] = value
+}
+
+// Context returns the span context identifying the span
+func (span *Span) Context() SpanContext {
+	if span == nil {
+		return SpanContext{}
+	}
+	return span.context
+}
+
+// End ends the span, failed with the error(param: err) when not nil, and exports it when sampled
+func (span *Span) End(err error) {
+	if span == nil || !span.context.Sampled {
+		return
+	}
+	exporter := currentExporter()
+	if exporter == nil {
+		return
+	}
+	span.mutex.Lock()
+	data := &SpanData{
+		TraceID:    hex.EncodeToString(span.context.TraceID[:]),
+		SpanID:     hex.EncodeToString(span.context.SpanID[:]),
+		Name:       span.name,
+		Kind:       span.kind,
+		Start:      span.start,
+		End:        time.Now(),
+		Attributes: span.attributes,
+	}
+	span.mutex.Unlock()
+	if span.parent != [8]byte{} {
+		data.ParentSpanID = hex.EncodeToString(span.parent[:])
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	exporter.Export(data)
+}
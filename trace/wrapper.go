@@ -0,0 +1,27 @@
+package trace
+
+import (
+	"github.com/micro/go-micro/server"
+	"golang.org/x/net/context"
+)
+
+// The server ends successful streams with this error
+const endOfStream = "EOS"
+
+// Wrapper implements the server.HandlerWrapper starting a server span for every
+// request, continuing the trace whose context the caller passed in the metadata
+func Wrapper(fn server.HandlerFunc) server.HandlerFunc {
+	return func(ctx context.Context, req server.Request, rsp interface{}) error {
+		ctx, span := Start(Extract(ctx), req.Method(), Server)
+		span.SetAttribute("rpc.service", req.Service())
+		span.SetAttribute("rpc.method", req.Method())
+		span.SetAttribute("rpc.stream", req.Stream())
+		err := fn(ctx, req, rsp)
+		if err != nil && err.Error() == endOfStream {
+			span.End(nil)
+		} else {
+			span.End(err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,345 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	API "github.com/desertjinn/mavenlink-communicator/api"
+	LOG "github.com/desertjinn/mavenlink-communicator/log"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Address listened on when the configuration does not provide one
+const defaultAddress = ":8090"
+
+// Header carrying the hex encoded HMAC-SHA256 of the request body
+const signatureHeader = "X-Mavenlink-Signature"
+
+// Largest notification body accepted
+const maxBodySize = 1 << 20
+
+// Time allowed to republish a single notification on the broker
+const publishTimeout = 10 * time.Second
+
+// Number of republished notification IDs remembered to recognize the notifications delivered again
+const rememberedIds = 10000
+
+// Resources the notifications are normalized into, keyed by Mavenlink subject type
+var resources = map[string]string{
+	"Workspace": "project",
+	"Story":     "task",
+	"TimeEntry": "timeentry",
+	"User":      "user",
+}
+
+// Invalidator drops the cached results affected by a change in Mavenlink
+type Invalidator interface {
+	InvalidateProject(id string)
+	InvalidateTask(workspaceId string, id string)
+	InvalidateTimeEntry(workspaceId string, id string)
+	InvalidateUser(id string)
+}
+
+// Invalidators passes every invalidation on to each of its invalidators, e.g.
+// the shared cache and the caches of the tenants
+type Invalidators []Invalidator
+
+// InvalidateProject drops the cached results which may include the workspace(param: id)
+func (invalidators Invalidators) InvalidateProject(id string) {
+	for _, invalidator := range invalidators {
+		invalidator.InvalidateProject(id)
+	}
+}
+
+// InvalidateTask drops the cached results which may include the story(param: id) of the workspace(param: workspaceId)
+func (invalidators Invalidators) InvalidateTask(workspaceId string, id string) {
+	for _, invalidator := range invalidators {
+		invalidator.InvalidateTask(workspaceId, id)
+	}
+}
+
+// InvalidateTimeEntry drops the cached results which may include the time entry(param: id) of the workspace(param: workspaceId)
+func (invalidators Invalidators) InvalidateTimeEntry(workspaceId string, id string) {
+	for _, invalidator := range invalidators {
+		invalidator.InvalidateTimeEntry(workspaceId, id)
+	}
+}
+
+// InvalidateUser drops the cached results which may include the user(param: id)
+func (invalidators Invalidators) InvalidateUser(id string) {
+	for _, invalidator := range invalidators {
+		invalidator.InvalidateUser(id)
+	}
+}
+
+// notification is a single event sent by Mavenlink
+type notification struct {
+	Id          string          `json:"id"`
+	EventType   string          `json:"event_type"`
+	SubjectType string          `json:"subject_type"`
+	SubjectId   string          `json:"subject_id"`
+	WorkspaceId string          `json:"workspace_id"`
+	CreatedAt   string          `json:"created_at"`
+	Subject     json.RawMessage `json:"subject"`
+}
+
+// Handler receives the notifications of Mavenlink's event subscriptions. Signed
+// notifications are normalized into ResourceChanged messages, the cached results
+// they affect are dropped and the messages are republished on the broker. When a
+// batch fails partway through, Mavenlink delivers it again and the notifications
+// already republished are recognized by their ID and not republished twice
+type Handler struct {
+	secret      []byte
+	invalidator Invalidator
+	publisher   micro.Publisher
+	published   *recentIds
+}
+
+// recentIds remembers a bounded number of IDs, forgetting the oldest first
+type recentIds struct {
+	mutex sync.Mutex
+	ids   map[string]bool
+	order []string
+	limit int
+}
+
+// NewHandler creates a handler verifying notifications with the secret of the
+// configuration(param: configuration), invalidating the cache(param: invalidator)
+// when provided and republishing through the publisher(param: publisher)
+func NewHandler(configuration *communicator.EnvironmentConfiguration, invalidator Invalidator,
+	publisher micro.Publisher) *Handler {
+
+	return &Handler{
+		secret:      []byte(configuration.WebhookSecret),
+		invalidator: invalidator,
+		publisher:   publisher,
+		published:   &recentIds{ids: make(map[string]bool), limit: rememberedIds},
+	}
+}
+
+// ListenAndServe receives notifications on the address of the configuration(param: configuration)
+// until the listener fails. A secret is required to verify the notifications
+func ListenAndServe(configuration *communicator.EnvironmentConfiguration, invalidator Invalidator,
+	publisher micro.Publisher) error {
+
+	if len(configuration.WebhookSecret) < 1 {
+		return errors.New("A webhook secret is required to receive Mavenlink notifications")
+	}
+	address := configuration.WebhookAddress
+	if len(address) < 1 {
+		address = defaultAddress
+	}
+	return http.ListenAndServe(address, NewHandler(configuration, invalidator, publisher))
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, bodyErr := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if bodyErr != nil {
+		http.Error(w, "Failed to read the notification", http.StatusBadRequest)
+		return
+	}
+	if !handler.verify(body, r.Header.Get(signatureHeader)) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+	notifications, parseErr := parse(body)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, received := range notifications {
+		change, normalizeErr := normalize(received)
+		if normalizeErr != nil {
+			http.Error(w, normalizeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if change == nil {
+			LOG.Debug("Webhook notification ignored", LOG.Fields{
+				"event_type":   received.EventType,
+				"subject_type": received.SubjectType,
+			})
+			continue
+		}
+		handler.invalidate(change)
+		// Let Mavenlink deliver the notification again when it cannot be republished
+		if publishErr := handler.publish(change); publishErr != nil {
+			LOG.Error("Webhook notification not published", LOG.Fields{"error": publishErr})
+			http.Error(w, "Failed to publish the notification", http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// verify reports whether the signature(param: signature) is the HMAC-SHA256 of the body(param: body)
+func (handler *Handler) verify(body []byte, signature string) bool {
+	if len(handler.secret) < 1 {
+		return false
+	}
+	expected, decodeErr := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if decodeErr != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, handler.secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func (handler *Handler) invalidate(change *communicator.ResourceChanged) {
+	if handler.invalidator == nil {
+		return
+	}
+	switch change.Resource {
+	case "project":
+		handler.invalidator.InvalidateProject(change.Id)
+	case "task":
+		handler.invalidator.InvalidateTask(change.WorkspaceId, change.Id)
+	case "timeentry":
+		handler.invalidator.InvalidateTimeEntry(change.WorkspaceId, change.Id)
+	case "user":
+		handler.invalidator.InvalidateUser(change.Id)
+	}
+}
+
+// publish republishes the change(param: change) unless its notification was republished already
+func (handler *Handler) publish(change *communicator.ResourceChanged) error {
+	if handler.publisher == nil {
+		return nil
+	}
+	if len(change.EventId) > 0 && handler.published.contains(change.EventId) {
+		LOG.Debug("Webhook notification already published", LOG.Fields{"event_id": change.EventId})
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := handler.publisher.Publish(ctx, change); err != nil {
+		return err
+	}
+	if len(change.EventId) > 0 {
+		handler.published.add(change.EventId)
+	}
+	return nil
+}
+
+func (recent *recentIds) contains(id string) bool {
+	recent.mutex.Lock()
+	defer recent.mutex.Unlock()
+	return recent.ids[id]
+}
+
+func (recent *recentIds) add(id string) {
+	recent.mutex.Lock()
+	defer recent.mutex.Unlock()
+	if recent.ids[id] {
+		return
+	}
+	recent.ids[id] = true
+	recent.order = append(recent.order, id)
+	if len(recent.order) > recent.limit {
+		delete(recent.ids, recent.order[0])
+		recent.order = recent.order[1:]
+	}
+}
+
+// parse decodes either a single notification or a list of them under "events"
+func parse(body []byte) ([]notification, error) {
+	var batch struct {
+		Events []notification `json:"events"`
+	}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the notification")
+	}
+	if len(batch.Events) > 0 {
+		return batch.Events, nil
+	}
+	var single notification
+	if err := json.Unmarshal(body, &single); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode the notification")
+	}
+	if len(single.EventType) < 1 {
+		return nil, errors.New("Notification without an event type")
+	}
+	return []notification{single}, nil
+}
+
+// normalize maps a notification(param: received) onto a ResourceChanged message,
+// or nil when its subject is not a resource exposed by this service
+func normalize(received notification) (*communicator.ResourceChanged, error) {
+	resource, ok := resources[received.SubjectType]
+	if !ok {
+		return nil, nil
+	}
+	change := new(communicator.ResourceChanged)
+	change.EventId = received.Id
+	change.EventType = received.EventType
+	change.Resource = resource
+	change.Id = received.SubjectId
+	change.WorkspaceId = received.WorkspaceId
+	change.OccurredAt = received.CreatedAt
+	if len(received.Subject) > 0 && string(received.Subject) != "null" {
+		if err := attachSubject(change, received.Subject); err != nil {
+			return nil, errors.Wrapf(err, "Failed to decode the %s of the notification", received.SubjectType)
+		}
+	}
+	if len(change.Id) < 1 {
+		return nil, errors.New("Notification without a subject ID")
+	}
+	return change, nil
+}
+
+// attachSubject decodes the Mavenlink record(param: subject) sent with a notification
+// into the message exposed by this service, filling in the IDs missing from the change
+func attachSubject(change *communicator.ResourceChanged, subject json.RawMessage) error {
+	var id, workspaceId string
+	switch change.Resource {
+	case "project":
+		var workspace communicator.MavenlinkWorkspace
+		if err := json.Unmarshal(subject, &workspace); err != nil {
+			return err
+		}
+		change.Project = API.WorkspaceToProject(&workspace)
+		id, workspaceId = workspace.Id, workspace.Id
+	case "task":
+		var story communicator.MavenlinkStory
+		if err := json.Unmarshal(subject, &story); err != nil {
+			return err
+		}
+		change.Task = API.StoryToTask(&story)
+		id, workspaceId = story.Id, story.WorkspaceId
+	case "timeentry":
+		var timeentry communicator.MavenlinkTimeentry
+		if err := json.Unmarshal(subject, &timeentry); err != nil {
+			return err
+		}
+		change.TimeEntry = API.TimeEntryToTimeentry(&timeentry)
+		id, workspaceId = timeentry.Id, timeentry.WorkspaceId
+	case "user":
+		var user communicator.MavenlinkUser
+		if err := json.Unmarshal(subject, &user); err != nil {
+			return err
+		}
+		change.User = API.MavenlinkUserToUser(&user)
+		id = user.Id
+	}
+	if len(change.Id) < 1 {
+		change.Id = id
+	}
+	if len(change.WorkspaceId) < 1 {
+		change.WorkspaceId = workspaceId
+	}
+	return nil
+}
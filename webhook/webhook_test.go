@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	communicator "github.com/desertjinn/mavenlink-communicator/proto/mavenlink-communicator"
+	"github.com/micro/go-micro/client"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testSecret = "webhook-secret"
+
+// fakeInvalidator records the invalidations it is asked for
+type fakeInvalidator struct {
+	mutex         sync.Mutex
+	invalidations []string
+}
+
+func (invalidator *fakeInvalidator) record(invalidation string) {
+	invalidator.mutex.Lock()
+	defer invalidator.mutex.Unlock()
+	invalidator.invalidations = append(invalidator.invalidations, invalidation)
+}
+
+func (invalidator *fakeInvalidator) InvalidateProject(id string) { invalidator.record("project " + id) }
+func (invalidator *fakeInvalidator) InvalidateTask(workspaceId string, id string) {
+	invalidator.record("task " + workspaceId + "/" + id)
+}
+func (invalidator *fakeInvalidator) InvalidateTimeEntry(workspaceId string, id string) {
+	invalidator.record("timeentry " + workspaceId + "/" + id)
+}
+func (invalidator *fakeInvalidator) InvalidateUser(id string) { invalidator.record("user " + id) }
+
+// fakePublisher records the changes it publishes, failing the ones of the events listed in fail
+type fakePublisher struct {
+	mutex     sync.Mutex
+	published []*communicator.ResourceChanged
+	fail      map[string]bool
+}
+
+func (publisher *fakePublisher) Publish(ctx context.Context, msg interface{}, opts ...client.PublishOption) error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	change := msg.(*communicator.ResourceChanged)
+	if publisher.fail[change.EventId] {
+		return errors.New("broker unavailable")
+	}
+	publisher.published = append(publisher.published, change)
+	return nil
+}
+
+// send posts the body(param: body) to the handler as Mavenlink does, signed with the secret(param: secret)
+func send(t *testing.T, server *httptest.Server, body string, secret string) int {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString(body))
+	req.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func newTestHandler() (*httptest.Server, *fakeInvalidator, *fakePublisher) {
+	invalidator := &fakeInvalidator{}
+	publisher := &fakePublisher{fail: make(map[string]bool)}
+	configuration := &communicator.EnvironmentConfiguration{WebhookSecret: testSecret}
+	return httptest.NewServer(NewHandler(configuration, invalidator, publisher)), invalidator, publisher
+}
+
+func TestRejectsInvalidSignatures(t *testing.T) {
+	server, invalidator, publisher := newTestHandler()
+	defer server.Close()
+	body := `{"id": "1", "event_type": "updated", "subject_type": "Story", "subject_id": "3001"}`
+	if status := send(t, server, body, "another-secret"); status != http.StatusUnauthorized {
+		t.Errorf("expected the notification to be rejected, got %d", status)
+	}
+	res, err := http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected an unsigned notification to be rejected, got %d", res.StatusCode)
+	}
+	if len(invalidator.invalidations) != 0 || len(publisher.published) != 0 {
+		t.Error("expected rejected notifications to be ignored")
+	}
+}
+
+func TestInvalidatesAndPublishes(t *testing.T) {
+	server, invalidator, publisher := newTestHandler()
+	defer server.Close()
+	body := `{"events": [
+		{"id": "1", "event_type": "updated", "subject_type": "Story", "subject_id": "3001",
+			"subject": {"id": "3001", "title": "Design", "workspace_id": "1001"}},
+		{"id": "2", "event_type": "created", "subject_type": "TimeEntry", "subject_id": "4001", "workspace_id": "1001"},
+		{"id": "3", "event_type": "created", "subject_type": "Comment", "subject_id": "9001"}
+	]}`
+	if status := send(t, server, body, testSecret); status != http.StatusNoContent {
+		t.Fatalf("expected the notifications to be accepted, got %d", status)
+	}
+	if strings.Join(invalidator.invalidations, ",") != "task 1001/3001,timeentry 1001/4001" {
+		t.Errorf("unexpected invalidations %v", invalidator.invalidations)
+	}
+	if len(publisher.published) != 2 {
+		t.Fatalf("expected the exposed resources to be published, got %v", publisher.published)
+	}
+	task := publisher.published[0]
+	if task.Resource != "task" || task.WorkspaceId != "1001" || task.Task == nil || task.Task.Title != "Design" {
+		t.Errorf("unexpected change %v", task)
+	}
+}
+
+func TestRedeliveryPublishesOnce(t *testing.T) {
+	server, _, publisher := newTestHandler()
+	defer server.Close()
+	body := `{"events": [
+		{"id": "1", "event_type": "updated", "subject_type": "Workspace", "subject_id": "1001"},
+		{"id": "2", "event_type": "updated", "subject_type": "User", "subject_id": "2001"}
+	]}`
+	publisher.fail["2"] = true
+	if status := send(t, server, body, testSecret); status != http.StatusInternalServerError {
+		t.Fatalf("expected the batch to be delivered again, got %d", status)
+	}
+	publisher.fail["2"] = false
+	if status := send(t, server, body, testSecret); status != http.StatusNoContent {
+		t.Fatalf("expected the batch delivered again to be accepted, got %d", status)
+	}
+	var ids []string
+	for _, change := range publisher.published {
+		ids = append(ids, change.EventId)
+	}
+	if strings.Join(ids, ",") != "1,2" {
+		t.Errorf("expected every notification to be published once, got %v", ids)
+	}
+}